@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+)
+
+type appOutput struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name,omitempty"`
+	Sources []sourceOutput `json:"sources"`
+}
+
+type sourceOutput struct {
+	Name       string `json:"name"`
+	ExternalID string `json:"externalId"`
+}
+
+// sourceFlag collects repeated -source name[:externalID] flags.
+type sourceFlag []app.Source
+
+func (f *sourceFlag) String() string {
+	values := make([]string, len(*f))
+	for i, source := range *f {
+		values[i] = source.Name
+		if source.ExternalID != "" {
+			values[i] += ":" + source.ExternalID
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *sourceFlag) Set(value string) error {
+	name, externalID, _ := strings.Cut(value, ":")
+	if name == "" {
+		return fmt.Errorf("invalid source %q, expected name[:externalID]", value)
+	}
+	*f = append(*f, app.Source{Name: name, ExternalID: externalID})
+	return nil
+}
+
+func runApps(ctx context.Context, ctl *cli, args []string) error {
+	name, args, err := subcommand(args, "add", "list", "remove")
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "add":
+		return runAppsAdd(ctx, ctl, args)
+	case "remove":
+		return runAppsRemove(ctx, ctl, args)
+	default:
+		return runAppsList(ctx, ctl, args)
+	}
+}
+
+func runAppsAdd(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("apps add", "apps add [-name name] [-source name[:externalID]]... <appID>")
+	name := flags.String("name", "", "display name of the app, used in reply templates")
+	var sources sourceFlag
+	flags.Var(&sources, "source", "review source to read the app from, repeatable (default appstore)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	useCase, jobsUseCase, err := ctl.addAppUseCase()
+	if err != nil {
+		return err
+	}
+
+	appID := flags.Arg(0)
+	backfill, err := useCase.Execute(ctx, appID, *name, sources)
+	if err != nil {
+		return err
+	}
+
+	// The server fetches a new app's reviews in the background; here the
+	// fetch runs before the command returns.
+	jobsUseCase.Drain(ctx)
+	backfill, err = jobsUseCase.Get(ctx, backfill.ID)
+	if err != nil {
+		return err
+	}
+
+	status := map[string]any{"added": appID, "newReviews": backfill.NewReviews, "updatedReviews": backfill.UpdatedReviews}
+	if backfill.Status == job.StatusFailed {
+		status["error"] = backfill.Error
+		return ctl.printer.message(status, "Added app %s; fetching its reviews failed: %s", appID, backfill.Error)
+	}
+	return ctl.printer.message(status, "Added app %s with %d new reviews", appID, backfill.NewReviews)
+}
+
+func runAppsList(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("apps list", "apps list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	repos, err := ctl.repositories()
+	if err != nil {
+		return err
+	}
+
+	apps, err := repos.appFile.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+
+	output := make([]appOutput, len(apps))
+	rows := make([][]string, len(apps))
+	for i, app := range apps {
+		output[i] = appOutput{ID: app.ID, Name: app.Name, Sources: []sourceOutput{}}
+		names := make([]string, 0, len(app.ReviewSources()))
+		for _, source := range app.ReviewSources() {
+			output[i].Sources = append(output[i].Sources, sourceOutput{Name: source.Name, ExternalID: source.ExternalID})
+			names = append(names, source.Name+":"+source.ExternalID)
+		}
+		rows[i] = []string{app.ID, app.Name, strings.Join(names, ", ")}
+	}
+
+	return ctl.printer.print(output, []string{"ID", "NAME", "SOURCES"}, rows)
+}
+
+func runAppsRemove(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("apps remove", "apps remove <appID>")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	useCase, err := ctl.removeAppUseCase()
+	if err != nil {
+		return err
+	}
+
+	appID := flags.Arg(0)
+	if err := useCase.Execute(ctx, appID); err != nil {
+		return fmt.Errorf("failed to remove app: %w", err)
+	}
+
+	return ctl.printer.message(map[string]any{"removed": appID}, "Removed app %s", appID)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"appstorereviewsviewer/internal/infrastructure/persistence/datadir"
+)
+
+type migrationOutput struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+}
+
+func runDB(ctx context.Context, ctl *cli, args []string) error {
+	name, args, err := subcommand(args, "migrate", "verify")
+	if err != nil {
+		return err
+	}
+
+	if name == "verify" {
+		return runDBVerify(ctx, ctl, args)
+	}
+	return runDBMigrate(ctx, ctl, args)
+}
+
+func runDBMigrate(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("db migrate", "db migrate [-dry-run]")
+	dryRun := flags.Bool("dry-run", false, "list pending migrations without applying them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var migrations []datadir.Migration
+	var err error
+	if *dryRun {
+		migrations, err = datadir.Pending(ctl.dataDir)
+	} else {
+		migrations, err = datadir.Migrate(ctl.dataDir)
+	}
+
+	output := make([]migrationOutput, len(migrations))
+	rows := make([][]string, len(migrations))
+	for i, migration := range migrations {
+		output[i] = migrationOutput{Version: migration.Version, Description: migration.Description}
+		rows[i] = []string{strconv.Itoa(migration.Version), migration.Description}
+	}
+	if printErr := ctl.printer.print(output, []string{"VERSION", "MIGRATION"}, rows); printErr != nil {
+		return printErr
+	}
+
+	return err
+}
+
+func runDBVerify(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("db verify", "db verify")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	issues, err := datadir.Verify(ctl.dataDir)
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+	rows := make([][]string, len(issues))
+	for i, issue := range issues {
+		if issue.Severity == datadir.SeverityError {
+			errorCount++
+		}
+		rows[i] = []string{string(issue.Severity), issue.File, issue.Message}
+	}
+
+	if issues == nil {
+		issues = []datadir.Issue{}
+	}
+	if err := ctl.printer.print(issues, []string{"SEVERITY", "FILE", "ISSUE"}, rows); err != nil {
+		return err
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("data directory has %d error(s)", errorCount)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func runFetch(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("fetch", "fetch [appID...]")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	useCase, err := ctl.reloadReviewsUseCase()
+	if err != nil {
+		return err
+	}
+
+	summary, err := useCase.Execute(ctx, flags.Args()...)
+	if summary == nil {
+		return err
+	}
+
+	rows := [][]string{{
+		strconv.Itoa(summary.AppsOK),
+		strconv.Itoa(summary.AppsFailed),
+		strconv.Itoa(summary.NewReviews),
+		strconv.Itoa(summary.UpdatedReviews),
+		summary.Duration.Round(time.Millisecond).String(),
+	}}
+	if printErr := ctl.printer.print(summary, []string{"APPS OK", "APPS FAILED", "NEW", "UPDATED", "DURATION"}, rows); printErr != nil {
+		return printErr
+	}
+
+	if !ctl.printer.json {
+		for _, failure := range summary.Failures {
+			fmt.Fprintf(ctl.stderr, "%s: %s\n", failure.AppID, failure.Error)
+		}
+	}
+
+	if err == nil && summary.AppsFailed > 0 {
+		err = fmt.Errorf("%d app(s) failed", summary.AppsFailed)
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/user"
+)
+
+type keyOutput struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Role       string   `json:"role"`
+	CreatedAt  string   `json:"createdAt"`
+	Workspaces []string `json:"workspaces"`
+}
+
+// workspacesFlag collects repeated -workspace flags.
+type workspacesFlag []string
+
+func (f *workspacesFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *workspacesFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func runKeys(ctx context.Context, ctl *cli, args []string) error {
+	name, args, err := subcommand(args, "create", "list", "revoke")
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "create":
+		return runKeysCreate(ctx, ctl, args)
+	case "revoke":
+		return runKeysRevoke(ctx, ctl, args)
+	default:
+		return runKeysList(ctx, ctl, args)
+	}
+}
+
+func runKeysCreate(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("keys create", "keys create -name name [-role viewer|triager|admin] -workspace id|* [-workspace id]...")
+	name := flags.String("name", "", "what the key is used for, e.g. the person or script holding it")
+	roleName := flags.String("role", string(user.RoleViewer), "role granted to the key: viewer, triager or admin")
+	var workspaces workspacesFlag
+	flags.Var(&workspaces, "workspace", "workspace the key is limited to, repeatable; * for every workspace")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 || *name == "" || len(workspaces) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	role, err := user.ParseRole(*roleName)
+	if err != nil {
+		return err
+	}
+
+	useCase, err := ctl.apiKeysUseCase()
+	if err != nil {
+		return err
+	}
+
+	key, secret, err := useCase.Create(ctx, *name, role, workspaces)
+	if err != nil {
+		return err
+	}
+
+	return ctl.printer.message(map[string]any{"id": key.ID, "name": key.Name, "role": key.Role, "workspaces": key.Workspaces, "key": secret},
+		"Created %s key %s (%s). It will not be shown again:\n%s", key.Role, key.ID, key.Name, secret)
+}
+
+func runKeysList(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("keys list", "keys list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	useCase, err := ctl.apiKeysUseCase()
+	if err != nil {
+		return err
+	}
+
+	keys, err := useCase.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	output := make([]keyOutput, len(keys))
+	rows := make([][]string, len(keys))
+	for i, key := range keys {
+		output[i] = keyOutput{
+			ID:         key.ID,
+			Name:       key.Name,
+			Role:       string(key.Role),
+			CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+			Workspaces: key.Workspaces,
+		}
+		workspaces := strings.Join(key.Workspaces, ",")
+		if workspaces == "" {
+			workspaces = "none"
+		}
+		rows[i] = []string{output[i].ID, output[i].Name, output[i].Role, workspaces, output[i].CreatedAt}
+	}
+
+	return ctl.printer.print(output, []string{"ID", "NAME", "ROLE", "WORKSPACES", "CREATED"}, rows)
+}
+
+func runKeysRevoke(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("keys revoke", "keys revoke <keyID>")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	useCase, err := ctl.apiKeysUseCase()
+	if err != nil {
+		return err
+	}
+
+	id := flags.Arg(0)
+	if err := useCase.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return ctl.printer.message(map[string]any{"revoked": id}, "Revoked API key %s", id)
+}
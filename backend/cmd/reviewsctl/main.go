@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"appstorereviewsviewer/internal/application/addapp"
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/application/exportreviews"
+	"appstorereviewsviewer/internal/application/jobs"
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/application/removeapp"
+	"appstorereviewsviewer/internal/application/reviewstats"
+	"appstorereviewsviewer/internal/application/workspaces"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/appstoreauth"
+	"appstorereviewsviewer/internal/infrastructure/config"
+	"appstorereviewsviewer/internal/infrastructure/googleauth"
+	persistenceapikey "appstorereviewsviewer/internal/infrastructure/persistence/apikey"
+	persistenceapp "appstorereviewsviewer/internal/infrastructure/persistence/app"
+	"appstorereviewsviewer/internal/infrastructure/persistence/datadir"
+	persistencejob "appstorereviewsviewer/internal/infrastructure/persistence/job"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	persistenceworkspace "appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+	"appstorereviewsviewer/internal/infrastructure/ratelimit"
+)
+
+const usage = `Usage: reviewsctl [-data-dir dir] [-workspace id] [-output table|json] <command> [arguments]
+
+Settings are read from the server's config file (` + config.ConfigFileEnv + `) and
+environment variables; -data-dir overrides the configured data directory.
+Apps, reviews and stats are those of the workspace named by -workspace.
+
+Commands:
+  apps add <appID>            start tracking an app and fetch its reviews
+  apps list                   list tracked apps
+  apps remove <appID>         stop tracking an app (stored reviews are kept)
+  fetch [appID]               fetch reviews once for one or all tracked apps
+  reviews list -app <appID>   list stored reviews
+  reviews export -app <appID> export stored reviews as csv, jsonl or xlsx
+  stats                       summarise stored reviews per tracked app
+  workspaces add <id>         create a workspace
+  workspaces list             list workspaces
+  keys create -name <name>    create an API key, printing its secret once
+  keys list                   list API keys
+  keys revoke <keyID>         revoke an API key
+  db migrate                  apply pending data directory migrations
+  db verify                   check the data directory for problems
+
+Run "reviewsctl <command> -h" for the flags of a command.
+`
+
+var errUsage = errors.New("invalid usage")
+
+type command func(ctx context.Context, ctl *cli, args []string) error
+
+var commands = map[string]command{
+	"apps":       runApps,
+	"fetch":      runFetch,
+	"reviews":    runReviews,
+	"stats":      runStats,
+	"keys":       runKeys,
+	"workspaces": runWorkspaces,
+	"db":         runDB,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg, _, err := config.Load(nil, os.LookupEnv, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("reviewsctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	dataDir := flags.String("data-dir", cfg.DataDir, "directory holding the app and review files")
+	workspaceID := flags.String("workspace", workspace.DefaultID, "workspace whose apps and reviews commands act on")
+	output := flags.String("output", "table", "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	printer, err := newPrinter(*output, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", flags.Arg(0))
+		flags.Usage()
+		return 2
+	}
+
+	ctl := &cli{dataDir: *dataDir, workspaceID: *workspaceID, config: cfg, printer: printer, stdout: stdout, stderr: stderr}
+	// Interrupting a command cancels in-flight fetches instead of killing
+	// the process mid-write.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ctx = workspace.WithID(ctx, ctl.workspaceID)
+
+	if err := cmd(ctx, ctl, flags.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	return 0
+}
+
+type cli struct {
+	dataDir     string
+	workspaceID string
+	config      *config.Config
+	printer     *printer
+	stdout      io.Writer
+	stderr      io.Writer
+	repos       *repositories
+}
+
+type repositories struct {
+	reviewFile review.Repository
+	sources    *review.SourceRegistry
+	appFile    app.Repository
+}
+
+func (c *cli) repositories() (*repositories, error) {
+	if c.repos != nil {
+		return c.repos, nil
+	}
+
+	// Data from before workspaces sits outside the workspace directories
+	// until it is migrated and would look missing.
+	pending, err := datadir.Pending(c.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("data directory has %d pending migration(s), run `reviewsctl db migrate` first", len(pending))
+	}
+
+	// Commands reach workspace data only through these repositories, so an
+	// unknown -workspace is caught here instead of creating its directory.
+	workspacesUseCase, err := c.workspacesUseCase()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := workspacesUseCase.Get(context.Background(), c.workspaceID); err != nil {
+		return nil, err
+	}
+
+	reviewFileRepo := persistencereview.NewWorkspaceRepository(c.dataDir)
+	appFileRepo := persistenceapp.NewWorkspaceRepository(c.dataDir)
+
+	feedCache, err := persistencereview.NewFeedCache(c.dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := review.NewSourceRegistry()
+	sources.Register(review.SourceAppStore, persistencereview.NewRSSRepository(persistencereview.RSSOptions{
+		BaseURL: c.config.ITunes.BaseURL,
+		Timeout: c.config.ITunes.Timeout,
+		Limiter: ratelimit.NewTokenBucket(c.config.ITunes.RateLimit, c.config.ITunes.Burst),
+		Retry: persistencereview.RetryPolicy{
+			MaxRetries: c.config.ITunes.MaxRetries,
+			BaseDelay:  c.config.ITunes.RetryBaseDelay,
+			MaxDelay:   c.config.ITunes.RetryMaxDelay,
+		},
+		Cache: feedCache,
+	}))
+	if c.config.Sources.JSONURL != "" {
+		sources.Register(persistencereview.SourceJSON, persistencereview.NewJSONSource(persistencereview.JSONSourceOptions{
+			URL:     c.config.Sources.JSONURL,
+			Timeout: c.config.Sources.JSONTimeout,
+		}))
+	}
+	if c.config.Sources.GooglePlayCredentials != "" {
+		account, err := googleauth.LoadServiceAccount(
+			c.config.Sources.GooglePlayCredentials,
+			googleauth.ScopeAndroidPublisher,
+			c.config.Sources.GooglePlayTimeout,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sources.Register(review.SourceGooglePlay, persistencereview.NewGooglePlaySource(persistencereview.GooglePlayOptions{
+			Timeout: c.config.Sources.GooglePlayTimeout,
+			Tokens:  account,
+		}))
+	}
+	if c.config.Sources.AppStoreConnectKeyFile != "" {
+		apiKey, err := appstoreauth.LoadAPIKey(
+			c.config.Sources.AppStoreConnectKeyFile,
+			c.config.Sources.AppStoreConnectKeyID,
+			c.config.Sources.AppStoreConnectIssuerID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sources.Register(review.SourceAppStoreConnect, persistencereview.NewAppStoreConnectSource(persistencereview.AppStoreConnectOptions{
+			BaseURL:     c.config.Sources.AppStoreConnectBaseURL,
+			Timeout:     c.config.Sources.AppStoreConnectTimeout,
+			Tokens:      apiKey,
+			Territories: c.config.Sources.Territories(),
+		}))
+	}
+
+	c.repos = &repositories{
+		reviewFile: reviewFileRepo,
+		sources:    sources,
+		appFile:    appFileRepo,
+	}
+	return c.repos, nil
+}
+
+func (c *cli) reloadReviewsUseCase() (reloadreviews.UseCase, error) {
+	repos, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return reloadreviews.NewUseCase(repos.reviewFile, repos.sources, repos.appFile, reloadreviews.Options{
+		Concurrency: c.config.Reload.Concurrency,
+		Timeout:     c.config.Reload.Timeout,
+	}), nil
+}
+
+// addAppUseCase returns the add app use case and the jobs use case queuing
+// the fetch of the added app's reviews, which the caller runs with Drain.
+func (c *cli) addAppUseCase() (addapp.UseCase, jobs.UseCase, error) {
+	reloadReviewsUseCase, err := c.reloadReviewsUseCase()
+	if err != nil {
+		return nil, nil, err
+	}
+	jobsUseCase := jobs.NewUseCase(persistencejob.NewMemoryRepository(0), reloadReviewsUseCase, jobs.Options{})
+	return addapp.NewUseCase(c.repos.appFile, c.repos.sources, jobsUseCase), jobsUseCase, nil
+}
+
+func (c *cli) removeAppUseCase() (removeapp.UseCase, error) {
+	repos, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return removeapp.NewUseCase(repos.appFile), nil
+}
+
+func (c *cli) listReviewsUseCase() (listreviews.UseCase, error) {
+	repos, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return listreviews.NewUseCase(repos.reviewFile), nil
+}
+
+func (c *cli) exportReviewsUseCase() (exportreviews.UseCase, error) {
+	repos, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return exportreviews.NewUseCase(repos.appFile, repos.reviewFile), nil
+}
+
+func (c *cli) reviewStatsUseCase() (reviewstats.UseCase, error) {
+	repos, err := c.repositories()
+	if err != nil {
+		return nil, err
+	}
+	return reviewstats.NewUseCase(repos.appFile, repos.reviewFile), nil
+}
+
+// apiKeysUseCase does not need the review sources, so keys can be managed
+// without their credentials.
+func (c *cli) apiKeysUseCase() (apikeys.UseCase, error) {
+	apiKeyFileRepo, err := persistenceapikey.NewFileRepository(c.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	workspaceFileRepo, err := persistenceworkspace.NewFileRepository(c.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return apikeys.NewUseCase(apiKeyFileRepo, workspaceFileRepo), nil
+}
+
+func (c *cli) workspacesUseCase() (workspaces.UseCase, error) {
+	workspaceFileRepo, err := persistenceworkspace.NewFileRepository(c.dataDir)
+	if err != nil {
+		return nil, err
+	}
+	return workspaces.NewUseCase(workspaceFileRepo), nil
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors instead
+// of exiting, so run decides the exit code.
+func (c *cli) newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: reviewsctl %s\n", usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func subcommand(args []string, names ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%w: expected one of %v", errUsage, names)
+	}
+	for _, name := range names {
+		if args[0] == name {
+			return name, args[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("unknown subcommand %q, expected one of %v", args[0], names)
+}
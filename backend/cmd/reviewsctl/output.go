@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+type printer struct {
+	json bool
+	out  io.Writer
+}
+
+func newPrinter(format string, out io.Writer) (*printer, error) {
+	switch format {
+	case "table":
+		return &printer{out: out}, nil
+	case "json":
+		return &printer{json: true, out: out}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, expected table or json", format)
+	}
+}
+
+// print writes value as indented JSON, or as a table built from header and
+// rows when the table format is selected.
+func (p *printer) print(value any, header []string, rows [][]string) error {
+	if p.json {
+		encoder := json.NewEncoder(p.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// message prints a confirmation line in table mode and a status object in
+// JSON mode so scripts always get parseable output.
+func (p *printer) message(status map[string]any, format string, args ...any) error {
+	if p.json {
+		return p.print(status, nil, nil)
+	}
+	_, err := fmt.Fprintf(p.out, format+"\n", args...)
+	return err
+}
+
+func truncate(value string, length int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+	return string(runes[:length-1]) + "…"
+}
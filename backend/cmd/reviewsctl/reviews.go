@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"appstorereviewsviewer/internal/application/exportreviews"
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type reviewOutput struct {
+	ID          string    `json:"id"`
+	AppID       string    `json:"appId"`
+	Author      string    `json:"author"`
+	Score       int       `json:"score"`
+	Content     string    `json:"content"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	ReplyStatus string    `json:"replyStatus"`
+}
+
+// replyStatus is the state of the review's developer reply, or "none".
+func replyStatus(review *review.Review) string {
+	if review.Reply == nil {
+		return "none"
+	}
+	return string(review.Reply.State)
+}
+
+func runReviews(ctx context.Context, ctl *cli, args []string) error {
+	name, args, err := subcommand(args, "list", "export")
+	if err != nil {
+		return err
+	}
+
+	if name == "export" {
+		return runReviewsExport(ctx, ctl, args)
+	}
+	return runReviewsList(ctx, ctl, args)
+}
+
+func runReviewsList(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("reviews list", "reviews list -app <appID> [flags]")
+	appID := flags.String("app", "", "App Store ID of the app (required)")
+	since := flags.String("since", "", "only list reviews submitted at or after this RFC 3339 time")
+	minScore := flags.Int("min-score", 0, "only list reviews with at least this score")
+	maxScore := flags.Int("max-score", 0, "only list reviews with at most this score")
+	limit := flags.Int("limit", 50, "maximum number of reviews to list, 0 for all")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *appID == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	filter := listreviews.Filter{MinScore: *minScore, MaxScore: *maxScore, Limit: *limit}
+	var err error
+	if filter.Since, err = parseTime(*since); err != nil {
+		return fmt.Errorf("invalid -since: %w", err)
+	}
+
+	useCase, err := ctl.listReviewsUseCase()
+	if err != nil {
+		return err
+	}
+
+	reviews, err := useCase.Execute(ctx, *appID, filter)
+	if err != nil {
+		return err
+	}
+
+	output := make([]reviewOutput, len(reviews))
+	rows := make([][]string, len(reviews))
+	for i, review := range reviews {
+		output[i] = reviewOutput{
+			ID:          review.ID,
+			AppID:       review.AppID,
+			Author:      review.Author,
+			Score:       review.Score,
+			Content:     review.Content,
+			SubmittedAt: review.SubmittedAt,
+			ReplyStatus: replyStatus(review),
+		}
+		rows[i] = []string{
+			review.ID,
+			review.SubmittedAt.Format(time.RFC3339),
+			strconv.Itoa(review.Score),
+			truncate(review.Author, 20),
+			truncate(review.Content, 60),
+			replyStatus(review),
+		}
+	}
+
+	return ctl.printer.print(output, []string{"ID", "SUBMITTED", "SCORE", "AUTHOR", "CONTENT", "REPLY"}, rows)
+}
+
+func runReviewsExport(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("reviews export", "reviews export -app <appID> [flags]")
+	appID := flags.String("app", "", "App Store ID of the app (required)")
+	format := flags.String("format", "csv", "export format: csv, jsonl or xlsx")
+	since := flags.String("since", "", "only export reviews submitted at or after this RFC 3339 time")
+	until := flags.String("until", "", "only export reviews submitted before this RFC 3339 time")
+	bom := flags.Bool("bom", false, "prefix CSV output with a UTF-8 byte order mark for Excel")
+	outputPath := flags.String("o", "", "output file (defaults to stdout)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *appID == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	options := exportreviews.Options{BOM: *bom}
+	var err error
+	if options.Format, err = exportreviews.ParseFormat(*format); err != nil {
+		return err
+	}
+	if options.Since, err = parseTime(*since); err != nil {
+		return fmt.Errorf("invalid -since: %w", err)
+	}
+	if options.Until, err = parseTime(*until); err != nil {
+		return fmt.Errorf("invalid -until: %w", err)
+	}
+
+	useCase, err := ctl.exportReviewsUseCase()
+	if err != nil {
+		return err
+	}
+
+	out := ctl.stdout
+	if *outputPath != "" {
+		file, err := os.Create(*outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer := bufio.NewWriter(out)
+	if err := useCase.Execute(ctx, writer, *appID, options); err != nil {
+		return fmt.Errorf("failed to export reviews: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if *outputPath != "" {
+		fmt.Fprintf(ctl.stderr, "Exported reviews for app %s to %s\n", *appID, *outputPath)
+	}
+	return nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
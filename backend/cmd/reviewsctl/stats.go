@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+func runStats(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("stats", "stats")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	useCase, err := ctl.reviewStatsUseCase()
+	if err != nil {
+		return err
+	}
+
+	stats, err := useCase.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, len(stats))
+	for i, appStats := range stats {
+		latest := "-"
+		if appStats.LatestReviewAt != nil {
+			latest = appStats.LatestReviewAt.Format(time.RFC3339)
+		}
+		rows[i] = []string{
+			appStats.AppID,
+			strconv.Itoa(appStats.Total),
+			strconv.Itoa(appStats.Recent),
+			strconv.FormatFloat(appStats.AverageScore, 'f', 2, 64),
+			strconv.Itoa(appStats.ScoreCounts[1]),
+			strconv.Itoa(appStats.ScoreCounts[2]),
+			strconv.Itoa(appStats.ScoreCounts[3]),
+			strconv.Itoa(appStats.ScoreCounts[4]),
+			strconv.Itoa(appStats.ScoreCounts[5]),
+			latest,
+		}
+	}
+
+	header := []string{"APP", "TOTAL", "LAST 48H", "AVG", "1★", "2★", "3★", "4★", "5★", "LATEST"}
+	return ctl.printer.print(stats, header, rows)
+}
@@ -0,0 +1,72 @@
+package main
+
+import "context"
+
+type workspaceOutput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func runWorkspaces(ctx context.Context, ctl *cli, args []string) error {
+	name, args, err := subcommand(args, "add", "list")
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "add":
+		return runWorkspacesAdd(ctx, ctl, args)
+	default:
+		return runWorkspacesList(ctx, ctl, args)
+	}
+}
+
+func runWorkspacesAdd(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("workspaces add", "workspaces add [-name name] <workspaceID>")
+	name := flags.String("name", "", "display name of the workspace (default its ID)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errUsage
+	}
+
+	useCase, err := ctl.workspacesUseCase()
+	if err != nil {
+		return err
+	}
+
+	w, err := useCase.Create(ctx, flags.Arg(0), *name)
+	if err != nil {
+		return err
+	}
+
+	return ctl.printer.message(map[string]any{"id": w.ID, "name": w.Name}, "Added workspace %s (%s)", w.ID, w.Name)
+}
+
+func runWorkspacesList(ctx context.Context, ctl *cli, args []string) error {
+	flags := ctl.newFlagSet("workspaces list", "workspaces list")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	useCase, err := ctl.workspacesUseCase()
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := useCase.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	output := make([]workspaceOutput, len(workspaces))
+	rows := make([][]string, len(workspaces))
+	for i, w := range workspaces {
+		output[i] = workspaceOutput{ID: w.ID, Name: w.Name}
+		rows[i] = []string{w.ID, w.Name}
+	}
+
+	return ctl.printer.print(output, []string{"ID", "NAME"}, rows)
+}
@@ -1,92 +1,354 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"appstorereviewsviewer/internal/application/addapp"
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/application/appstatus"
+	"appstorereviewsviewer/internal/application/exportreviews"
 	"appstorereviewsviewer/internal/application/getrecentreviews"
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/application/jobs"
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/application/previewreplytemplate"
 	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/application/removeapp"
+	"appstorereviewsviewer/internal/application/replyreview"
+	"appstorereviewsviewer/internal/application/replytemplates"
+	"appstorereviewsviewer/internal/application/workspaces"
+	"appstorereviewsviewer/internal/domain/apikey"
 	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/replytemplate"
 	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/appstoreauth"
+	"appstorereviewsviewer/internal/infrastructure/config"
 	"appstorereviewsviewer/internal/infrastructure/cron"
+	"appstorereviewsviewer/internal/infrastructure/googleauth"
 	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	"appstorereviewsviewer/internal/infrastructure/metrics"
+	"appstorereviewsviewer/internal/infrastructure/oidc"
+	persistenceapikey "appstorereviewsviewer/internal/infrastructure/persistence/apikey"
 	persistenceapp "appstorereviewsviewer/internal/infrastructure/persistence/app"
+	"appstorereviewsviewer/internal/infrastructure/persistence/datadir"
+	persistencejob "appstorereviewsviewer/internal/infrastructure/persistence/job"
+	persistencereplytemplate "appstorereviewsviewer/internal/infrastructure/persistence/replytemplate"
 	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	persistenceworkspace "appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+	"appstorereviewsviewer/internal/infrastructure/ratelimit"
+	"appstorereviewsviewer/internal/infrastructure/worker"
 )
 
+// jobsKept is the number of recent background jobs whose progress can be
+// looked up.
+const jobsKept = 1000
+
 func main() {
-	dataDir := "data"
-	port := "8080"
+	cfg, printConfig, err := config.Load(os.Args[1:], os.LookupEnv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	if printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatalf("Failed to print configuration: %v", err)
+		}
+		return
+	}
+
+	migrations, err := datadir.Migrate(cfg.DataDir)
+	if err != nil {
+		log.Fatalf("Failed to migrate data directory: %v", err)
+	}
+	for _, migration := range migrations {
+		log.Printf("Applied data migration %d: %s", migration.Version, migration.Description)
+	}
 
-	repos, err := setupRepositories(dataDir)
+	serverMetrics := metrics.New()
+	repos, err := setupRepositories(cfg, serverMetrics)
 	if err != nil {
 		log.Fatalf("Failed to setup repositories: %v", err)
 	}
 
-	useCases := setupUseCases(repos)
-	server := infrahttp.NewServer(useCases.getRecentReviews, useCases.addApp, port)
+	useCases := setupUseCases(cfg, repos, serverMetrics)
+	tokens, err := setupTokenVerifier(cfg)
+	if err != nil {
+		log.Fatalf("Failed to setup token verification: %v", err)
+	}
+	server := infrahttp.NewServer(infrahttp.UseCases{
+		GetRecentReviews:     useCases.getRecentReviews,
+		AddApp:               useCases.addApp,
+		ListReviews:          useCases.listReviews,
+		ExportReviews:        useCases.exportReviews,
+		ImportReviews:        useCases.importReviews,
+		AppStatus:            useCases.appStatus,
+		ReplyReview:          useCases.replyReview,
+		ReplyTemplates:       useCases.replyTemplates,
+		PreviewReplyTemplate: useCases.previewReplyTemplate,
+		RemoveApp:            useCases.removeApp,
+		APIKeys:              useCases.apiKeys,
+		Workspaces:           useCases.workspaces,
+		Jobs:                 useCases.jobs,
+	}, infrahttp.Config{
+		Port:             strconv.Itoa(cfg.Server.Port),
+		BaseURL:          cfg.Server.BaseURL,
+		AuthEnabled:      cfg.Auth.Enabled,
+		Tokens:           tokens,
+		RateLimit:        cfg.Server.RateLimit,
+		RateBurst:        cfg.Server.RateBurst,
+		AddressRateLimit: cfg.Server.AddressRateLimit,
+		AddressRateBurst: cfg.Server.AddressRateBurst,
+		MaxBodyBytes:     int64(cfg.Server.MaxBodyBytes),
+		MaxImportBytes:   int64(cfg.Server.MaxImportBytes),
+		Cors: infrahttp.CorsPolicy{
+			AllowedOrigins:   cfg.CORS.Origins(),
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			AllowedMethods:   cfg.CORS.Methods(),
+			AllowedHeaders:   cfg.CORS.Headers(),
+			MaxAge:           cfg.CORS.MaxAge,
+		},
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		Metrics:           serverMetrics,
+	})
+	if !cfg.Auth.Enabled {
+		log.Println("API authentication is disabled; anyone who can reach the server can use every endpoint")
+	}
 	server.Start()
 
-	reloadReviews := cron.NewReloadReviews(useCases.reloadReviews)
+	jobRunner := worker.NewJobs(useCases.jobs, cfg.Reload.Concurrency)
+	jobRunner.Start()
+
+	reloadReviews := cron.NewReloadReviews(useCases.reloadReviews, useCases.workspaces, cfg.Reload.Interval)
 	reloadReviews.Start()
 
-	handleGracefulShutdown(reloadReviews)
+	handleGracefulShutdown(server, jobRunner, reloadReviews, cfg.Server.ShutdownTimeout)
 }
 
 type repositories struct {
-	reviewFile review.Repository
-	reviewRSS  review.Repository
-	appFile    app.Repository
+	reviewFile    review.Repository
+	reviewRSS     *persistencereview.RSSRepository
+	sources       *review.SourceRegistry
+	responders    map[string]review.Responder
+	appFile       app.Repository
+	templateFile  replytemplate.Repository
+	apiKeyFile    apikey.Repository
+	workspaceFile workspace.Repository
+	jobMemory     job.Repository
 }
 
-func setupRepositories(dataDir string) (*repositories, error) {
-	reviewFileRepo, err := persistencereview.NewFileRepository(dataDir)
+// setupRepositories creates the repositories and review sources, recording
+// their operations in m.
+func setupRepositories(cfg *config.Config, m *metrics.Metrics) (*repositories, error) {
+	reviewFileRepo := persistencereview.NewWorkspaceRepository(cfg.DataDir)
+
+	feedCache, err := persistencereview.NewFeedCache(cfg.DataDir)
 	if err != nil {
 		return nil, err
 	}
 
-	rssReviewRepo := persistencereview.NewRSSRepository()
+	rssReviewRepo := persistencereview.NewRSSRepository(persistencereview.RSSOptions{
+		BaseURL: cfg.ITunes.BaseURL,
+		Timeout: cfg.ITunes.Timeout,
+		Limiter: ratelimit.NewTokenBucket(cfg.ITunes.RateLimit, cfg.ITunes.Burst),
+		Retry: persistencereview.RetryPolicy{
+			MaxRetries: cfg.ITunes.MaxRetries,
+			BaseDelay:  cfg.ITunes.RetryBaseDelay,
+			MaxDelay:   cfg.ITunes.RetryMaxDelay,
+		},
+		Cache: feedCache,
+	})
+
+	sources := review.NewSourceRegistry()
+	responders := make(map[string]review.Responder)
+	sources.Register(review.SourceAppStore, m.Source(review.SourceAppStore, rssReviewRepo))
+	if cfg.Sources.JSONURL != "" {
+		jsonSource := persistencereview.NewJSONSource(persistencereview.JSONSourceOptions{
+			URL:     cfg.Sources.JSONURL,
+			Timeout: cfg.Sources.JSONTimeout,
+		})
+		sources.Register(persistencereview.SourceJSON, m.Source(persistencereview.SourceJSON, jsonSource))
+	}
+	if cfg.Sources.GooglePlayCredentials != "" {
+		account, err := googleauth.LoadServiceAccount(
+			cfg.Sources.GooglePlayCredentials,
+			googleauth.ScopeAndroidPublisher,
+			cfg.Sources.GooglePlayTimeout,
+		)
+		if err != nil {
+			return nil, err
+		}
+		googlePlay := persistencereview.NewGooglePlaySource(persistencereview.GooglePlayOptions{
+			Timeout: cfg.Sources.GooglePlayTimeout,
+			Tokens:  account,
+		})
+		sources.Register(review.SourceGooglePlay, m.Source(review.SourceGooglePlay, googlePlay))
+	}
+	if cfg.Sources.AppStoreConnectKeyFile != "" {
+		apiKey, err := appstoreauth.LoadAPIKey(
+			cfg.Sources.AppStoreConnectKeyFile,
+			cfg.Sources.AppStoreConnectKeyID,
+			cfg.Sources.AppStoreConnectIssuerID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		appStoreConnect := persistencereview.NewAppStoreConnectSource(persistencereview.AppStoreConnectOptions{
+			BaseURL:     cfg.Sources.AppStoreConnectBaseURL,
+			Timeout:     cfg.Sources.AppStoreConnectTimeout,
+			Tokens:      apiKey,
+			Territories: cfg.Sources.Territories(),
+		})
+		sources.Register(review.SourceAppStoreConnect, m.Source(review.SourceAppStoreConnect, appStoreConnect))
+		responders[review.SourceAppStoreConnect] = appStoreConnect
+	}
+
+	appFileRepo := persistenceapp.NewWorkspaceRepository(cfg.DataDir)
+	templateFileRepo := persistencereplytemplate.NewWorkspaceRepository(cfg.DataDir)
 
-	appFileRepo, err := persistenceapp.NewFileRepository(dataDir)
+	workspaceFileRepo, err := persistenceworkspace.NewFileRepository(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyFileRepo, err := persistenceapikey.NewFileRepository(cfg.DataDir)
 	if err != nil {
 		return nil, err
 	}
 
 	return &repositories{
-		reviewFile: reviewFileRepo,
-		reviewRSS:  rssReviewRepo,
-		appFile:    appFileRepo,
+		reviewFile:    m.ReviewRepository(reviewFileRepo),
+		reviewRSS:     rssReviewRepo,
+		sources:       sources,
+		responders:    responders,
+		appFile:       m.AppRepository(appFileRepo),
+		templateFile:  m.ReplyTemplateRepository(templateFileRepo),
+		apiKeyFile:    m.APIKeyRepository(apiKeyFileRepo),
+		workspaceFile: m.WorkspaceRepository(workspaceFileRepo),
+		jobMemory:     m.JobRepository(persistencejob.NewMemoryRepository(jobsKept)),
 	}, nil
 }
 
+// setupTokenVerifier accepts tokens from the configured OpenID Connect
+// issuer, or returns nil when none is configured.
+func setupTokenVerifier(cfg *config.Config) (infrahttp.TokenVerifier, error) {
+	if cfg.Auth.OIDCIssuer == "" {
+		return nil, nil
+	}
+
+	roleMapping, err := cfg.Auth.RoleMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	return oidc.NewVerifier(oidc.Options{
+		Issuer:          cfg.Auth.OIDCIssuer,
+		Audience:        cfg.Auth.OIDCAudience,
+		JWKSURL:         cfg.Auth.OIDCJWKSURL,
+		RolesClaim:      cfg.Auth.OIDCRolesClaim,
+		RoleMapping:     roleMapping,
+		DefaultRole:     user.Role(cfg.Auth.OIDCDefaultRole),
+		WorkspacesClaim: cfg.Auth.OIDCWorkspacesClaim,
+		ClockSkew:       cfg.Auth.OIDCClockSkew,
+	}), nil
+}
+
 type useCases struct {
-	reloadReviews    reloadreviews.UseCase
-	getRecentReviews getrecentreviews.UseCase
-	addApp           addapp.UseCase
+	reloadReviews        reloadreviews.UseCase
+	getRecentReviews     getrecentreviews.UseCase
+	addApp               addapp.UseCase
+	listReviews          listreviews.UseCase
+	exportReviews        exportreviews.UseCase
+	importReviews        importreviews.UseCase
+	appStatus            appstatus.UseCase
+	replyReview          replyreview.UseCase
+	replyTemplates       replytemplates.UseCase
+	previewReplyTemplate previewreplytemplate.UseCase
+	removeApp            removeapp.UseCase
+	apiKeys              apikeys.UseCase
+	workspaces           workspaces.UseCase
+	jobs                 jobs.UseCase
 }
 
-func setupUseCases(repos *repositories) *useCases {
-	reloadReviewsUseCase := reloadreviews.NewUseCase(repos.reviewFile, repos.reviewRSS, repos.appFile)
+// setupUseCases creates the use cases, recording the reloads of the
+// scheduled runs and of background jobs in m.
+func setupUseCases(cfg *config.Config, repos *repositories, m *metrics.Metrics) *useCases {
+	breaker := reloadreviews.NewCircuitBreaker(cfg.Reload.BreakerThreshold, cfg.Reload.BreakerCooldown, cfg.Reload.BreakerMaxCooldown)
+	reloadReviewsUseCase := reloadreviews.NewUseCase(repos.reviewFile, repos.sources, repos.appFile, reloadreviews.Options{
+		Concurrency: cfg.Reload.Concurrency,
+		Timeout:     cfg.Reload.Timeout,
+		Breaker:     breaker,
+	})
 	getRecentReviewsUseCase := getrecentreviews.NewUseCase(repos.reviewFile)
-	addAppUseCase := addapp.NewUseCase(repos.appFile, reloadReviewsUseCase)
+	jobsUseCase := jobs.NewUseCase(repos.jobMemory, m.ReloadReviews(reloadReviewsUseCase, "job"), jobs.Options{})
+	addAppUseCase := addapp.NewUseCase(repos.appFile, repos.sources, jobsUseCase)
+	listReviewsUseCase := listreviews.NewUseCase(repos.reviewFile)
+	exportReviewsUseCase := exportreviews.NewUseCase(repos.appFile, repos.reviewFile)
+	importReviewsUseCase := importreviews.NewUseCase(repos.appFile, repos.reviewFile)
+	appStatusUseCase := appstatus.NewUseCase(repos.appFile, breaker, repos.reviewRSS)
+	replyReviewUseCase := replyreview.NewUseCase(repos.reviewFile, repos.responders)
+	replyTemplatesUseCase := replytemplates.NewUseCase(repos.templateFile)
+	previewReplyTemplateUseCase := previewreplytemplate.NewUseCase(repos.templateFile, repos.reviewFile, repos.appFile)
+	removeAppUseCase := removeapp.NewUseCase(repos.appFile)
+	apiKeysUseCase := apikeys.NewUseCase(repos.apiKeyFile, repos.workspaceFile)
+	workspacesUseCase := workspaces.NewUseCase(repos.workspaceFile)
 
 	return &useCases{
-		reloadReviews:    reloadReviewsUseCase,
-		getRecentReviews: getRecentReviewsUseCase,
-		addApp:           addAppUseCase,
+		reloadReviews:        m.ReloadReviews(reloadReviewsUseCase, "cron"),
+		getRecentReviews:     getRecentReviewsUseCase,
+		addApp:               addAppUseCase,
+		listReviews:          listReviewsUseCase,
+		exportReviews:        exportReviewsUseCase,
+		importReviews:        importReviewsUseCase,
+		appStatus:            appStatusUseCase,
+		replyReview:          replyReviewUseCase,
+		replyTemplates:       replyTemplatesUseCase,
+		previewReplyTemplate: previewReplyTemplateUseCase,
+		removeApp:            removeAppUseCase,
+		apiKeys:              apiKeysUseCase,
+		workspaces:           workspacesUseCase,
+		jobs:                 jobsUseCase,
 	}
 }
 
-func handleGracefulShutdown(reloadReviews *cron.ReloadReviews) {
+func handleGracefulShutdown(server *infrahttp.Server, jobRunner *worker.Jobs, reloadReviews *cron.ReloadReviews, timeout time.Duration) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
 	<-sigChan
 	log.Println("Shutting down server...")
 
-	reloadReviews.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Stop accepting requests first so no new writes start, then let the
+	// in-flight requests and reload finish within the same deadline.
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("HTTP server did not shut down cleanly: %v", err)
+	}
+
+	if err := reloadReviews.Stop(ctx); err != nil {
+		log.Printf("Reviews reload did not finish before shutdown: %v", err)
+	}
+
+	if err := jobRunner.Stop(ctx); err != nil {
+		log.Printf("Background jobs did not stop before shutdown: %v", err)
+	}
+
 	log.Println("Server stopped")
 }
@@ -1,39 +1,56 @@
 package addapp
 
 import (
-	"log/slog"
+	"context"
+	"errors"
 
-	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/application/jobs"
 	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/review"
 )
 
 type UseCase interface {
-	Execute(appID string) error
+	// Execute tracks the app under an optional display name, reading its
+	// reviews from the given sources, or from the App Store feed when none
+	// are given. Its reviews are fetched by the returned background job.
+	// When the job queue is full the app is tracked all the same and the job
+	// is returned marked failed; the next scheduled reload fetches the
+	// reviews instead.
+	Execute(ctx context.Context, appID, name string, sources []app.Source) (*job.Job, error)
 }
 
 type useCase struct {
-	appRepo              app.Repository
-	reloadReviewsUseCase reloadreviews.UseCase
+	appRepo     app.Repository
+	sources     *review.SourceRegistry
+	jobsUseCase jobs.UseCase
 }
 
-func NewUseCase(appRepo app.Repository, reloadReviewsUseCase reloadreviews.UseCase) *useCase {
-	return &useCase{appRepo: appRepo, reloadReviewsUseCase: reloadReviewsUseCase}
+func NewUseCase(appRepo app.Repository, sources *review.SourceRegistry, jobsUseCase jobs.UseCase) *useCase {
+	return &useCase{appRepo: appRepo, sources: sources, jobsUseCase: jobsUseCase}
 }
 
-func (u *useCase) Execute(appID string) error {
-	app, err := app.NewApp(appID)
+func (u *useCase) Execute(ctx context.Context, appID, name string, sources []app.Source) (*job.Job, error) {
+	app, err := app.NewApp(appID, sources...)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	app.Name = name
 
-	err = u.appRepo.Save(app)
-	if err != nil {
-		return err
+	for _, source := range app.Sources {
+		if _, err := u.sources.Get(source.Name); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := u.reloadReviewsUseCase.Execute(); err != nil {
-		slog.Error("failed to execute reload reviews", "error", err)
+	err = u.appRepo.Save(ctx, app)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	backfill, err := u.jobsUseCase.EnqueueBackfill(ctx, app.ID)
+	if errors.Is(err, job.ErrQueueFull) && backfill != nil {
+		return backfill, nil
+	}
+	return backfill, err
 }
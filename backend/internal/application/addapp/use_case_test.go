@@ -1,65 +1,122 @@
 package addapp_test
 
 import (
+	"context"
 	"testing"
 
 	"appstorereviewsviewer/internal/application/addapp"
 	"appstorereviewsviewer/internal/domain/app"
-	reloadreviewsmocks "appstorereviewsviewer/mocks/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/review"
+	jobsmocks "appstorereviewsviewer/mocks/application/jobs"
 	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type AddAppUseCaseTestSuite struct {
 	suite.Suite
-	mockAppRepo              *appmocks.Repository
-	mockReloadReviewsUseCase *reloadreviewsmocks.UseCase
-	useCase                  addapp.UseCase
+	mockAppRepo     *appmocks.Repository
+	mockJobsUseCase *jobsmocks.UseCase
+	useCase         addapp.UseCase
 }
 
 func (s *AddAppUseCaseTestSuite) SetupSubTest() {
 	s.mockAppRepo = appmocks.NewRepository(s.T())
-	s.mockReloadReviewsUseCase = reloadreviewsmocks.NewUseCase(s.T())
-	s.useCase = addapp.NewUseCase(s.mockAppRepo, s.mockReloadReviewsUseCase)
+	s.mockJobsUseCase = jobsmocks.NewUseCase(s.T())
+	sources := review.NewSourceRegistry()
+	sources.Register(review.SourceAppStore, reviewmocks.NewSource(s.T()))
+	sources.Register(review.SourceGooglePlay, reviewmocks.NewSource(s.T()))
+	s.useCase = addapp.NewUseCase(s.mockAppRepo, sources, s.mockJobsUseCase)
 }
 
 func (s *AddAppUseCaseTestSuite) TestExecute() {
-	s.Run("should save app and reload reviews when valid app ID provided", func() {
+	s.Run("should save app and queue the backfill of its reviews when valid app ID provided", func() {
 		expectedApp, _ := app.NewApp("12345")
-		s.mockAppRepo.EXPECT().Save(expectedApp).Return(nil)
-		s.mockReloadReviewsUseCase.EXPECT().Execute().Return(nil)
+		queued := &job.Job{ID: "j1", Kind: job.KindBackfill, AppID: "12345", Status: job.StatusQueued}
+		s.mockAppRepo.EXPECT().Save(mock.Anything, expectedApp).Return(nil)
+		s.mockJobsUseCase.EXPECT().EnqueueBackfill(mock.Anything, "12345").Return(queued, nil)
 
-		err := s.useCase.Execute("12345")
+		j, err := s.useCase.Execute(context.Background(), "12345", "", nil)
 
 		s.NoError(err)
+		s.Equal(queued, j)
 	})
 
 	s.Run("should return error when app creation fails", func() {
-		err := s.useCase.Execute("")
+		_, err := s.useCase.Execute(context.Background(), "", "", nil)
 
-		s.Error(err)
-		s.Equal("id is required", err.Error())
+		s.ErrorIs(err, app.ErrInvalidID)
+	})
+
+	s.Run("should reject app IDs that leave the workspace directory", func() {
+		for _, id := range []string{"../b/123", `..\b`, "..", "a/b"} {
+			_, err := s.useCase.Execute(context.Background(), id, "", nil)
+
+			s.ErrorIs(err, app.ErrInvalidID, id)
+		}
+	})
+
+	s.Run("should save the declared sources", func() {
+		expectedApp := &app.App{ID: "12345", Sources: []app.Source{
+			{Name: review.SourceAppStore, ExternalID: "12345"},
+			{Name: review.SourceGooglePlay, ExternalID: "com.example.app"},
+		}}
+		s.mockAppRepo.EXPECT().Save(mock.Anything, expectedApp).Return(nil)
+		s.mockJobsUseCase.EXPECT().EnqueueBackfill(mock.Anything, "12345").Return(&job.Job{ID: "j1"}, nil)
+
+		_, err := s.useCase.Execute(context.Background(), "12345", "", []app.Source{
+			{Name: review.SourceAppStore},
+			{Name: review.SourceGooglePlay, ExternalID: "com.example.app"},
+		})
+
+		s.NoError(err)
+	})
+
+	s.Run("should reject sources that are not registered", func() {
+		_, err := s.useCase.Execute(context.Background(), "12345", "", []app.Source{{Name: "unknown"}})
+
+		s.ErrorIs(err, review.ErrUnknownSource)
+	})
+
+	s.Run("should reject Google Play sources without a package name", func() {
+		_, err := s.useCase.Execute(context.Background(), "12345", "", []app.Source{{Name: review.SourceGooglePlay}})
+
+		s.ErrorIs(err, app.ErrInvalidExternalID)
 	})
 
 	s.Run("should return error when app repository save fails", func() {
 		expectedApp, _ := app.NewApp("12345")
-		s.mockAppRepo.EXPECT().Save(expectedApp).Return(assert.AnError)
+		s.mockAppRepo.EXPECT().Save(mock.Anything, expectedApp).Return(assert.AnError)
 
-		err := s.useCase.Execute("12345")
+		_, err := s.useCase.Execute(context.Background(), "12345", "", nil)
 
 		s.Error(err)
 	})
 
-	s.Run("should continue when reload reviews fails", func() {
+	s.Run("should return the failed backfill when the job queue is full", func() {
 		expectedApp, _ := app.NewApp("12345")
-		s.mockAppRepo.EXPECT().Save(expectedApp).Return(nil)
-		s.mockReloadReviewsUseCase.EXPECT().Execute().Return(assert.AnError)
+		failed := &job.Job{ID: "j1", AppID: "12345", Status: job.StatusFailed, Error: job.ErrQueueFull.Error()}
+		s.mockAppRepo.EXPECT().Save(mock.Anything, expectedApp).Return(nil)
+		s.mockJobsUseCase.EXPECT().EnqueueBackfill(mock.Anything, "12345").Return(failed, job.ErrQueueFull)
 
-		err := s.useCase.Execute("12345")
+		j, err := s.useCase.Execute(context.Background(), "12345", "", nil)
 
 		s.NoError(err)
+		s.Equal(failed, j)
+	})
+
+	s.Run("should return error when the backfill cannot be recorded", func() {
+		expectedApp, _ := app.NewApp("12345")
+		s.mockAppRepo.EXPECT().Save(mock.Anything, expectedApp).Return(nil)
+		s.mockJobsUseCase.EXPECT().EnqueueBackfill(mock.Anything, "12345").Return(nil, assert.AnError)
+
+		_, err := s.useCase.Execute(context.Background(), "12345", "", nil)
+
+		s.ErrorIs(err, assert.AnError)
 	})
 }
 
@@ -0,0 +1,146 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+var (
+	ErrEmptyName          = fault.New(fault.KindValidation, "API key name is required")
+	ErrNoWorkspaces       = fault.New(fault.KindValidation, "API key workspaces are required, use * for every workspace")
+	ErrAllWorkspacesAlone = fault.New(fault.KindValidation, "* must be the only workspace of an API key")
+	ErrInvalidKey         = errors.New("invalid API key")
+)
+
+// secretPrefix marks API key secrets so they are recognisable in configs
+// and secret scanners.
+const secretPrefix = "arv_"
+
+type UseCase interface {
+	List(ctx context.Context) ([]*apikey.Key, error)
+	// Create stores a new key and returns it with its secret, which is not
+	// kept and cannot be shown again. The key is limited to workspaces,
+	// which must name at least one workspace or be apikey.AllWorkspaces
+	// alone.
+	Create(ctx context.Context, name string, role user.Role, workspaces []string) (*apikey.Key, string, error)
+	Delete(ctx context.Context, id string) error
+	// Authenticate returns the key a secret belongs to, or ErrInvalidKey.
+	Authenticate(ctx context.Context, secret string) (*apikey.Key, error)
+}
+
+type useCase struct {
+	keyRepo       apikey.Repository
+	workspaceRepo workspace.Repository
+	now           func() time.Time
+}
+
+func NewUseCase(keyRepo apikey.Repository, workspaceRepo workspace.Repository) *useCase {
+	return &useCase{keyRepo: keyRepo, workspaceRepo: workspaceRepo, now: time.Now}
+}
+
+func (u *useCase) List(ctx context.Context) ([]*apikey.Key, error) {
+	return u.keyRepo.FindAll(ctx)
+}
+
+func (u *useCase) Create(ctx context.Context, name string, role user.Role, workspaces []string) (*apikey.Key, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrEmptyName
+	}
+	if _, err := user.ParseRole(string(role)); err != nil {
+		return nil, "", err
+	}
+	if err := u.checkWorkspaces(ctx, workspaces); err != nil {
+		return nil, "", err
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+	secret = secretPrefix + secret
+
+	key := &apikey.Key{
+		ID:         id,
+		Name:       name,
+		Role:       role,
+		Hash:       apikey.Hash(secret),
+		CreatedAt:  u.now(),
+		Workspaces: workspaces,
+	}
+	if err := u.keyRepo.Save(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save API key: %w", err)
+	}
+	return key, secret, nil
+}
+
+func (u *useCase) Delete(ctx context.Context, id string) error {
+	return u.keyRepo.Delete(ctx, id)
+}
+
+func (u *useCase) Authenticate(ctx context.Context, secret string) (*apikey.Key, error) {
+	if secret == "" {
+		return nil, ErrInvalidKey
+	}
+
+	key, err := u.keyRepo.FindByHash(ctx, apikey.Hash(secret))
+	if errors.Is(err, apikey.ErrNotFound) {
+		return nil, ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (u *useCase) checkWorkspaces(ctx context.Context, workspaceIDs []string) error {
+	if len(workspaceIDs) == 0 {
+		return ErrNoWorkspaces
+	}
+	if slices.Contains(workspaceIDs, apikey.AllWorkspaces) {
+		if len(workspaceIDs) > 1 {
+			return ErrAllWorkspacesAlone
+		}
+		return nil
+	}
+
+	workspaces, err := u.workspaceRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	known := make(map[string]bool, len(workspaces))
+	for _, w := range workspaces {
+		known[w.ID] = true
+	}
+	for _, id := range workspaceIDs {
+		if !known[id] {
+			// The workspace is part of the request, so naming an unknown
+			// one is invalid input rather than a missing resource.
+			return fault.Wrap(fault.KindValidation, fmt.Errorf("%w: %s", workspace.ErrNotFound, id))
+		}
+	}
+	return nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
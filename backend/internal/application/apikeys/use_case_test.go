@@ -0,0 +1,139 @@
+package apikeys_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/domain/workspace"
+	apikeymocks "appstorereviewsviewer/mocks/domain/apikey"
+	workspacemocks "appstorereviewsviewer/mocks/domain/workspace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeysUseCaseTestSuite struct {
+	suite.Suite
+	mockKeyRepo       *apikeymocks.Repository
+	mockWorkspaceRepo *workspacemocks.Repository
+	useCase           apikeys.UseCase
+}
+
+func (s *APIKeysUseCaseTestSuite) SetupSubTest() {
+	s.mockKeyRepo = apikeymocks.NewRepository(s.T())
+	s.mockWorkspaceRepo = workspacemocks.NewRepository(s.T())
+	s.useCase = apikeys.NewUseCase(s.mockKeyRepo, s.mockWorkspaceRepo)
+}
+
+func (s *APIKeysUseCaseTestSuite) TestCreate() {
+	s.Run("should store the hash of a new secret", func() {
+		var saved *apikey.Key
+		s.mockKeyRepo.EXPECT().Save(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, key *apikey.Key) error {
+				saved = key
+				return nil
+			})
+
+		key, secret, err := s.useCase.Create(context.Background(), " CI ", user.RoleViewer, []string{apikey.AllWorkspaces})
+
+		s.Require().NoError(err)
+		s.Same(saved, key)
+		s.True(strings.HasPrefix(secret, "arv_"))
+		s.Equal(apikey.Hash(secret), key.Hash)
+		s.NotContains(key.Hash, secret)
+		s.Equal("CI", key.Name)
+		s.Equal(user.RoleViewer, key.Role)
+		s.NotEmpty(key.ID)
+		s.False(key.CreatedAt.IsZero())
+		s.Equal([]string{"*"}, key.Workspaces)
+	})
+
+	s.Run("should limit the key to existing workspaces", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}, {ID: "team-a"}}, nil)
+		s.mockKeyRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+
+		key, _, err := s.useCase.Create(context.Background(), "CI", user.RoleViewer, []string{"team-a"})
+
+		s.Require().NoError(err)
+		s.Equal([]string{"team-a"}, key.Workspaces)
+	})
+
+	s.Run("should reject unknown workspaces", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+
+		_, _, err := s.useCase.Create(context.Background(), "CI", user.RoleViewer, []string{"team-b"})
+
+		s.ErrorIs(err, workspace.ErrNotFound)
+	})
+
+	s.Run("should require workspaces or * alone", func() {
+		_, _, err := s.useCase.Create(context.Background(), "CI", user.RoleViewer, []string{})
+		s.ErrorIs(err, apikeys.ErrNoWorkspaces)
+
+		_, _, err = s.useCase.Create(context.Background(), "CI", user.RoleViewer, []string{"*", "team-a"})
+		s.ErrorIs(err, apikeys.ErrAllWorkspacesAlone)
+	})
+
+	s.Run("should reject unknown roles", func() {
+		_, _, err := s.useCase.Create(context.Background(), "CI", user.Role("owner"), nil)
+
+		s.ErrorIs(err, user.ErrInvalidRole)
+	})
+
+	s.Run("should require a name", func() {
+		_, _, err := s.useCase.Create(context.Background(), " ", user.RoleAdmin, nil)
+
+		s.ErrorIs(err, apikeys.ErrEmptyName)
+	})
+
+	s.Run("should return error when saving fails", func() {
+		s.mockKeyRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, _, err := s.useCase.Create(context.Background(), "CI", user.RoleAdmin, []string{apikey.AllWorkspaces})
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (s *APIKeysUseCaseTestSuite) TestAuthenticate() {
+	s.Run("should return the key of a known secret", func() {
+		key := &apikey.Key{ID: "k1", Role: user.RoleTriager}
+		s.mockKeyRepo.EXPECT().FindByHash(mock.Anything, apikey.Hash("arv_secret")).Return(key, nil)
+
+		result, err := s.useCase.Authenticate(context.Background(), "arv_secret")
+
+		s.Require().NoError(err)
+		s.Same(key, result)
+	})
+
+	s.Run("should reject unknown secrets", func() {
+		s.mockKeyRepo.EXPECT().FindByHash(mock.Anything, apikey.Hash("arv_other")).Return(nil, apikey.ErrNotFound)
+
+		_, err := s.useCase.Authenticate(context.Background(), "arv_other")
+
+		s.ErrorIs(err, apikeys.ErrInvalidKey)
+	})
+
+	s.Run("should reject empty secrets without a lookup", func() {
+		_, err := s.useCase.Authenticate(context.Background(), "")
+
+		s.ErrorIs(err, apikeys.ErrInvalidKey)
+	})
+
+	s.Run("should return repository errors", func() {
+		s.mockKeyRepo.EXPECT().FindByHash(mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		_, err := s.useCase.Authenticate(context.Background(), "arv_secret")
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func TestAPIKeysUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeysUseCaseTestSuite))
+}
@@ -0,0 +1,62 @@
+package appstatus
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+// HealthReporter reports how reliably an app's reviews can be fetched.
+type HealthReporter interface {
+	Health(appID string) reloadreviews.AppHealth
+}
+
+// FetchStatsReporter reports the request counters of the remote review
+// source.
+type FetchStatsReporter interface {
+	FetchStats() review.FetchStats
+}
+
+type Status struct {
+	Apps  []reloadreviews.AppHealth `json:"apps"`
+	Fetch review.FetchStats         `json:"fetch"`
+}
+
+type UseCase interface {
+	Execute(ctx context.Context) (*Status, error)
+}
+
+type useCase struct {
+	appRepo    app.Repository
+	health     HealthReporter
+	fetchStats FetchStatsReporter
+}
+
+func NewUseCase(appRepo app.Repository, health HealthReporter, fetchStats FetchStatsReporter) *useCase {
+	return &useCase{
+		appRepo:    appRepo,
+		health:     health,
+		fetchStats: fetchStats,
+	}
+}
+
+// Execute returns the fetch health of every tracked app together with the
+// request counters of the review source.
+func (s *useCase) Execute(ctx context.Context) (*Status, error) {
+	apps, err := s.appRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Apps:  make([]reloadreviews.AppHealth, 0, len(apps)),
+		Fetch: s.fetchStats.FetchStats(),
+	}
+	for _, app := range apps {
+		status.Apps = append(status.Apps, s.health.Health(app.ID))
+	}
+
+	return status, nil
+}
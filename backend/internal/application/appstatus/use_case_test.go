@@ -0,0 +1,79 @@
+package appstatus_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/appstatus"
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	appstatusmocks "appstorereviewsviewer/mocks/application/appstatus"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AppStatusUseCaseTestSuite struct {
+	suite.Suite
+	mockAppRepo *appmocks.Repository
+	mockHealth  *appstatusmocks.HealthReporter
+	mockStats   *appstatusmocks.FetchStatsReporter
+	useCase     appstatus.UseCase
+}
+
+func (s *AppStatusUseCaseTestSuite) SetupSubTest() {
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.mockHealth = appstatusmocks.NewHealthReporter(s.T())
+	s.mockStats = appstatusmocks.NewFetchStatsReporter(s.T())
+	s.useCase = appstatus.NewUseCase(s.mockAppRepo, s.mockHealth, s.mockStats)
+}
+
+func (s *AppStatusUseCaseTestSuite) TestExecute() {
+	s.Run("should report the health of every tracked app", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}, {ID: "app2"}}, nil)
+		s.mockStats.EXPECT().FetchStats().Return(review.FetchStats{Requests: 10, NotModified: 7, BytesSaved: 7000})
+		s.mockHealth.EXPECT().Health("app1").Return(reloadreviews.AppHealth{AppID: "app1", State: reloadreviews.BreakerClosed})
+		s.mockHealth.EXPECT().Health("app2").Return(reloadreviews.AppHealth{
+			AppID:               "app2",
+			State:               reloadreviews.BreakerOpen,
+			ConsecutiveFailures: 3,
+			LastError:           "feed unavailable",
+		})
+
+		status, err := s.useCase.Execute(context.Background())
+
+		s.Require().NoError(err)
+		s.Require().Len(status.Apps, 2)
+		s.Equal(reloadreviews.BreakerClosed, status.Apps[0].State)
+		s.Equal(reloadreviews.BreakerOpen, status.Apps[1].State)
+		s.Equal(3, status.Apps[1].ConsecutiveFailures)
+		s.Equal(review.FetchStats{Requests: 10, NotModified: 7, BytesSaved: 7000}, status.Fetch)
+	})
+
+	s.Run("should return an empty list when no apps are tracked", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{}, nil)
+		s.mockStats.EXPECT().FetchStats().Return(review.FetchStats{})
+
+		status, err := s.useCase.Execute(context.Background())
+
+		s.Require().NoError(err)
+		s.NotNil(status.Apps)
+		s.Empty(status.Apps)
+	})
+
+	s.Run("should return error when apps cannot be listed", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(nil, assert.AnError)
+
+		status, err := s.useCase.Execute(context.Background())
+
+		s.ErrorIs(err, assert.AnError)
+		s.Nil(status)
+	})
+}
+
+func TestAppStatusUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AppStatusUseCaseTestSuite))
+}
@@ -0,0 +1,244 @@
+package exportreviews
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+const utf8BOM = "\ufeff"
+
+var columns = []string{"id", "appId", "author", "score", "content", "submittedAt", "retrievedAt", "source"}
+
+type encoder interface {
+	Encode(review *review.Review) error
+	Close() error
+}
+
+func newEncoder(w io.Writer, options Options) (encoder, error) {
+	switch options.Format {
+	case FormatCSV:
+		return newCSVEncoder(w, options.BOM)
+	case FormatJSONL:
+		return &jsonlEncoder{encoder: json.NewEncoder(w)}, nil
+	case FormatXLSX:
+		return newXLSXEncoder(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", options.Format)
+	}
+}
+
+func record(review *review.Review) []string {
+	return []string{
+		review.ID,
+		review.AppID,
+		review.Author,
+		strconv.Itoa(review.Score),
+		review.Content,
+		review.SubmittedAt.UTC().Format(time.RFC3339),
+		review.RetrievedAt.UTC().Format(time.RFC3339),
+		review.Source,
+	}
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer, bom bool) (*csvEncoder, error) {
+	if bom {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	// Excel expects CRLF line endings, including inside quoted multi-line content.
+	writer.UseCRLF = true
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+
+	return &csvEncoder{writer: writer}, nil
+}
+
+func (e *csvEncoder) Encode(review *review.Review) error {
+	values := record(review)
+	for i, value := range values {
+		values[i] = escapeFormula(value)
+	}
+	return e.writer.Write(values)
+}
+
+// escapeFormula prefixes values that spreadsheets would evaluate as formulas
+// with an apostrophe, so that review text cannot run formulas when the CSV is
+// opened. XLSX cells are written as strings and need no escaping.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonlRecord struct {
+	ID          string    `json:"id"`
+	AppID       string    `json:"appId"`
+	Author      string    `json:"author"`
+	Score       int       `json:"score"`
+	Content     string    `json:"content"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	RetrievedAt time.Time `json:"retrievedAt"`
+	Source      string    `json:"source,omitempty"`
+}
+
+type jsonlEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(review *review.Review) error {
+	return e.encoder.Encode(jsonlRecord{
+		ID:          review.ID,
+		AppID:       review.AppID,
+		Author:      review.Author,
+		Score:       review.Score,
+		Content:     review.Content,
+		SubmittedAt: review.SubmittedAt.UTC(),
+		RetrievedAt: review.RetrievedAt.UTC(),
+		Source:      review.Source,
+	})
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+// xlsxEncoder writes a minimal single-sheet SpreadsheetML workbook. Rows are
+// streamed straight into the zip entry so the sheet is never held in memory.
+type xlsxEncoder struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ` +
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Reviews" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+func newXLSXEncoder(w io.Writer) (*xlsxEncoder, error) {
+	archive := zip.NewWriter(w)
+
+	for _, part := range xlsxStaticParts {
+		partWriter, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(partWriter, xml.Header+part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	sheetHeader := xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	if _, err := io.WriteString(sheet, sheetHeader); err != nil {
+		return nil, err
+	}
+
+	encoder := &xlsxEncoder{archive: archive, sheet: sheet}
+	if err := encoder.writeRow(columns, nil); err != nil {
+		return nil, err
+	}
+
+	return encoder, nil
+}
+
+func (e *xlsxEncoder) Encode(review *review.Review) error {
+	// The score column is written as a number so it can be summed and charted.
+	return e.writeRow(record(review), map[int]bool{3: true})
+}
+
+func (e *xlsxEncoder) writeRow(values []string, numeric map[int]bool) error {
+	e.row++
+	if _, err := fmt.Fprintf(e.sheet, `<row r="%d">`, e.row); err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		ref := fmt.Sprintf("%c%d", 'A'+i, e.row)
+		if numeric[i] {
+			if _, err := fmt.Fprintf(e.sheet, `<c r="%s"><v>%s</v></c>`, ref, value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(e.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(e.sheet, []byte(value)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(e.sheet, `</t></is></c>`); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(e.sheet, `</row>`)
+	return err
+}
+
+func (e *xlsxEncoder) Close() error {
+	if _, err := io.WriteString(e.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return e.archive.Close()
+}
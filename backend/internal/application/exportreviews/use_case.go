@@ -0,0 +1,72 @@
+package exportreviews
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+	FormatXLSX  Format = "xlsx"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatCSV, FormatJSONL, FormatXLSX:
+		return format, nil
+	default:
+		return "", fault.New(fault.KindValidation, fmt.Sprintf("unsupported export format %q", value))
+	}
+}
+
+type Options struct {
+	Format Format
+	Since  time.Time
+	Until  time.Time
+	// BOM prefixes CSV output with a UTF-8 byte order mark so Excel detects the encoding.
+	BOM bool
+}
+
+type UseCase interface {
+	// Execute writes the reviews of a tracked app to w, returning
+	// app.ErrNotFound before writing anything when the app is not tracked.
+	Execute(ctx context.Context, w io.Writer, appID string, options Options) error
+}
+
+type useCase struct {
+	appRepo    app.Repository
+	reviewRepo review.Repository
+}
+
+func NewUseCase(appRepo app.Repository, reviewRepo review.Repository) *useCase {
+	return &useCase{
+		appRepo:    appRepo,
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (s *useCase) Execute(ctx context.Context, w io.Writer, appID string, options Options) error {
+	if _, err := app.FindByID(ctx, s.appRepo, appID); err != nil {
+		return err
+	}
+
+	encoder, err := newEncoder(w, options)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reviewRepo.StreamByAppIDBetween(ctx, appID, options.Since, options.Until, encoder.Encode); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
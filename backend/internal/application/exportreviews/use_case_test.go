@@ -0,0 +1,216 @@
+package exportreviews_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/exportreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportReviewsUseCaseTestSuite struct {
+	suite.Suite
+	mockAppRepo    *appmocks.Repository
+	mockReviewRepo *reviewmocks.Repository
+	useCase        exportreviews.UseCase
+}
+
+func (s *ExportReviewsUseCaseTestSuite) SetupSubTest() {
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "12345"}}, nil).Maybe()
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.useCase = exportreviews.NewUseCase(s.mockAppRepo, s.mockReviewRepo)
+}
+
+func (s *ExportReviewsUseCaseTestSuite) expectStream(since, until time.Time) {
+	s.expectReviews(since, until, []*review.Review{
+		{
+			ID:          "review1",
+			AppID:       "12345",
+			Author:      "John \"JD\" Doe",
+			Content:     "Great app,\nbut it crashes sometimes",
+			Score:       4,
+			SubmittedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			RetrievedAt: time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:          "review2",
+			AppID:       "12345",
+			Author:      "Jane <Smith>",
+			Content:     "Love it & use it daily",
+			Score:       5,
+			SubmittedAt: time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+			RetrievedAt: time.Date(2025, 1, 2, 13, 0, 0, 0, time.UTC),
+		},
+	})
+}
+
+func (s *ExportReviewsUseCaseTestSuite) expectReviews(since, until time.Time, reviews []*review.Review) {
+	s.mockReviewRepo.EXPECT().
+		StreamByAppIDBetween(mock.Anything, "12345", since, until, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _, _ time.Time, fn func(*review.Review) error) error {
+			for _, review := range reviews {
+				if err := fn(review); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (s *ExportReviewsUseCaseTestSuite) TestExecute() {
+	s.Run("should export CSV with quoted multi-line content", func() {
+		s.expectStream(time.Time{}, time.Time{})
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatCSV})
+
+		s.Require().NoError(err)
+		s.Contains(out.String(), "\"Great app,\r\nbut it crashes sometimes\"")
+		records, err := csv.NewReader(&out).ReadAll()
+		s.Require().NoError(err)
+		s.Require().Len(records, 3)
+		s.Equal([]string{"id", "appId", "author", "score", "content", "submittedAt", "retrievedAt", "source"}, records[0])
+		s.Equal("John \"JD\" Doe", records[1][2])
+		s.Equal("Great app,\nbut it crashes sometimes", records[1][4])
+		s.Equal("2025-01-02T12:00:00Z", records[2][5])
+	})
+
+	s.Run("should escape CSV values that spreadsheets would run as formulas", func() {
+		s.expectReviews(time.Time{}, time.Time{}, []*review.Review{{
+			ID:      "review1",
+			AppID:   "12345",
+			Author:  "=HYPERLINK(\"https://example.com\")",
+			Content: "@SUM(A1:A2)",
+			Source:  "-1+2",
+		}})
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatCSV})
+
+		s.Require().NoError(err)
+		records, err := csv.NewReader(&out).ReadAll()
+		s.Require().NoError(err)
+		s.Require().Len(records, 2)
+		s.Equal("'=HYPERLINK(\"https://example.com\")", records[1][2])
+		s.Equal("'@SUM(A1:A2)", records[1][4])
+		s.Equal("'-1+2", records[1][7])
+		s.Equal("review1", records[1][0])
+	})
+
+	s.Run("should prefix CSV with a byte order mark when requested", func() {
+		s.expectStream(time.Time{}, time.Time{})
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatCSV, BOM: true})
+
+		s.NoError(err)
+		s.True(strings.HasPrefix(out.String(), "\ufeffid,appId"))
+	})
+
+	s.Run("should export one JSON object per line", func() {
+		since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		until := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+		s.expectStream(since, until)
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatJSONL, Since: since, Until: until})
+
+		s.Require().NoError(err)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		s.Require().Len(lines, 2)
+		var record map[string]any
+		s.Require().NoError(json.Unmarshal([]byte(lines[0]), &record))
+		s.Equal("review1", record["id"])
+		s.Equal("Great app,\nbut it crashes sometimes", record["content"])
+		s.EqualValues(4, record["score"])
+	})
+
+	s.Run("should export a valid XLSX workbook", func() {
+		s.expectStream(time.Time{}, time.Time{})
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatXLSX})
+
+		s.Require().NoError(err)
+		archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		s.Require().NoError(err)
+
+		files := map[string]*zip.File{}
+		for _, file := range archive.File {
+			files[file.Name] = file
+		}
+		s.Contains(files, "[Content_Types].xml")
+		s.Contains(files, "xl/workbook.xml")
+		s.Require().Contains(files, "xl/worksheets/sheet1.xml")
+
+		sheetReader, err := files["xl/worksheets/sheet1.xml"].Open()
+		s.Require().NoError(err)
+		sheet, err := io.ReadAll(sheetReader)
+		s.Require().NoError(err)
+		s.Contains(string(sheet), `<row r="3">`)
+		s.Contains(string(sheet), "Jane &lt;Smith&gt;")
+		s.Contains(string(sheet), "Love it &amp; use it daily")
+		s.Contains(string(sheet), `<c r="D2"><v>4</v></c>`)
+	})
+
+	s.Run("should return error when format is unsupported", func() {
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: "pdf"})
+
+		s.Error(err)
+		s.Empty(out.String())
+	})
+
+	s.Run("should return not found for untracked apps before writing", func() {
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "67890", exportreviews.Options{Format: exportreviews.FormatCSV})
+
+		s.ErrorIs(err, app.ErrNotFound)
+		s.Empty(out.String())
+	})
+
+	s.Run("should return error when repository fails", func() {
+		s.mockReviewRepo.EXPECT().StreamByAppIDBetween(mock.Anything, "12345", time.Time{}, time.Time{}, mock.Anything).Return(assert.AnError)
+		var out bytes.Buffer
+
+		err := s.useCase.Execute(context.Background(), &out, "12345", exportreviews.Options{Format: exportreviews.FormatJSONL})
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (s *ExportReviewsUseCaseTestSuite) TestParseFormat() {
+	s.Run("should accept supported formats", func() {
+		for _, value := range []string{"csv", "jsonl", "xlsx"} {
+			format, err := exportreviews.ParseFormat(value)
+			s.NoError(err)
+			s.Equal(exportreviews.Format(value), format)
+		}
+	})
+
+	s.Run("should reject unknown formats", func() {
+		_, err := exportreviews.ParseFormat("xml")
+		s.Error(err)
+	})
+}
+
+func TestExportReviewsUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportReviewsUseCaseTestSuite))
+}
@@ -1,13 +1,14 @@
 package getrecentreviews
 
 import (
+	"context"
 	"time"
 
 	"appstorereviewsviewer/internal/domain/review"
 )
 
 type UseCase interface {
-	Execute(appID string) ([]*review.Review, error)
+	Execute(ctx context.Context, appID string) ([]*review.Review, error)
 }
 
 type useCase struct {
@@ -20,7 +21,7 @@ func NewUseCase(reviewRepo review.Repository) *useCase {
 	}
 }
 
-func (s *useCase) Execute(appID string) ([]*review.Review, error) {
+func (s *useCase) Execute(ctx context.Context, appID string) ([]*review.Review, error) {
 	since := time.Now().Add(-time.Duration(review.RecentReviewHourThreshold) * time.Hour)
-	return s.reviewRepo.FindByAppIDSince(appID, since)
+	return s.reviewRepo.FindByAppIDSince(ctx, appID, since)
 }
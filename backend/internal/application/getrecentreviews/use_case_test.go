@@ -1,6 +1,7 @@
 package getrecentreviews_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -48,9 +49,9 @@ func (s *GetRecentReviewsUseCaseTestSuite) TestExecute() {
 			},
 		}
 
-		s.mockReviewRepo.EXPECT().FindByAppIDSince(appID, mock.AnythingOfType("time.Time")).Return(expectedReviews, nil)
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, appID, mock.AnythingOfType("time.Time")).Return(expectedReviews, nil)
 
-		reviews, err := s.useCase.Execute(appID)
+		reviews, err := s.useCase.Execute(context.Background(), appID)
 
 		s.NoError(err)
 		s.Equal(expectedReviews, reviews)
@@ -60,9 +61,9 @@ func (s *GetRecentReviewsUseCaseTestSuite) TestExecute() {
 		appID := "12345"
 		expectedReviews := []*review.Review{}
 
-		s.mockReviewRepo.EXPECT().FindByAppIDSince(appID, mock.AnythingOfType("time.Time")).Return(expectedReviews, nil)
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, appID, mock.AnythingOfType("time.Time")).Return(expectedReviews, nil)
 
-		reviews, err := s.useCase.Execute(appID)
+		reviews, err := s.useCase.Execute(context.Background(), appID)
 
 		s.NoError(err)
 		s.Equal(expectedReviews, reviews)
@@ -71,9 +72,9 @@ func (s *GetRecentReviewsUseCaseTestSuite) TestExecute() {
 	s.Run("should return error when repository fails", func() {
 		appID := "12345"
 
-		s.mockReviewRepo.EXPECT().FindByAppIDSince(appID, mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, appID, mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
 
-		reviews, err := s.useCase.Execute(appID)
+		reviews, err := s.useCase.Execute(context.Background(), appID)
 
 		s.Error(err)
 		s.Nil(reviews)
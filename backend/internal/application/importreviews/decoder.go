@@ -0,0 +1,203 @@
+package importreviews
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// decoder yields one record at a time as a map from review field to raw value.
+// The returned row number is what users see in validation reports. Problems
+// confined to a single record are reported as *rowError so the import can
+// carry on with the next one.
+type decoder interface {
+	Next() (row int, values map[string]string, err error)
+}
+
+type rowError struct {
+	message string
+}
+
+func (e *rowError) Error() string {
+	return e.message
+}
+
+func newDecoder(r io.Reader, options Options) (decoder, error) {
+	switch options.Format {
+	case FormatCSV:
+		return newCSVDecoder(r, options.Mapping)
+	case FormatJSONL:
+		return newJSONLDecoder(r, options.Mapping), nil
+	default:
+		return nil, fault.New(fault.KindValidation, fmt.Sprintf("unsupported import format %q", options.Format))
+	}
+}
+
+func sourceName(mapping map[string]string, field string) string {
+	if name, ok := mapping[field]; ok {
+		return name
+	}
+	return field
+}
+
+type csvDecoder struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVDecoder(r io.Reader, mapping map[string]string) (*csvDecoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fault.Wrap(fault.KindValidation, fmt.Errorf("failed to read CSV header: %w", err))
+	}
+
+	indexes := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		indexes[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int)
+	for _, field := range Fields {
+		if index, ok := indexes[strings.ToLower(sourceName(mapping, field))]; ok {
+			columns[field] = index
+		}
+	}
+
+	return &csvDecoder{reader: reader, columns: columns, row: 1}, nil
+}
+
+func (d *csvDecoder) Next() (int, map[string]string, error) {
+	record, err := d.reader.Read()
+	d.row++
+	if err == io.EOF {
+		return d.row, nil, io.EOF
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return d.row, nil, &rowError{message: parseErr.Err.Error()}
+		}
+		return d.row, nil, err
+	}
+
+	values := make(map[string]string, len(d.columns))
+	for field, index := range d.columns {
+		if index < len(record) {
+			values[field] = record[index]
+		}
+	}
+
+	return d.row, values, nil
+}
+
+type jsonlDecoder struct {
+	lines   *lineReader
+	mapping map[string]string
+}
+
+func newJSONLDecoder(r io.Reader, mapping map[string]string) *jsonlDecoder {
+	return &jsonlDecoder{lines: newLineReader(r), mapping: mapping}
+}
+
+func (d *jsonlDecoder) Next() (int, map[string]string, error) {
+	line, row, err := d.lines.next()
+	if err != nil {
+		return row, nil, err
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(line, &object); err != nil {
+		return row, nil, &rowError{message: "invalid JSON object"}
+	}
+
+	values := make(map[string]string)
+	for _, field := range Fields {
+		value, ok := object[sourceName(d.mapping, field)]
+		if !ok || value == nil {
+			continue
+		}
+		switch typed := value.(type) {
+		case string:
+			values[field] = typed
+		case float64:
+			values[field] = strconv.FormatFloat(typed, 'f', -1, 64)
+		default:
+			return row, nil, &rowError{message: field + " must be a string or number"}
+		}
+	}
+
+	return row, values, nil
+}
+
+func parseReview(appID string, values map[string]string, retrievedAt time.Time) (*review.Review, error) {
+	id := strings.TrimSpace(values["id"])
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	if rowAppID := strings.TrimSpace(values["appId"]); rowAppID != "" && rowAppID != appID {
+		return nil, fmt.Errorf("appId %q does not match app %q", rowAppID, appID)
+	}
+
+	score, err := strconv.Atoi(strings.TrimSpace(values["score"]))
+	if err != nil || score < 1 || score > 5 {
+		return nil, errors.New("score must be a whole number between 1 and 5")
+	}
+
+	submittedAt, err := parseTime(values["submittedAt"])
+	if err != nil {
+		return nil, fmt.Errorf("submittedAt: %w", err)
+	}
+	if submittedAt.IsZero() {
+		return nil, errors.New("submittedAt is required")
+	}
+
+	if value, err := parseTime(values["retrievedAt"]); err != nil {
+		return nil, fmt.Errorf("retrievedAt: %w", err)
+	} else if !value.IsZero() {
+		retrievedAt = value
+	}
+
+	return &review.Review{
+		ID:          id,
+		AppID:       appID,
+		Author:      values["author"],
+		Content:     values["content"],
+		Score:       score,
+		SubmittedAt: submittedAt,
+		RetrievedAt: retrievedAt,
+		Source:      strings.TrimSpace(values["source"]),
+	}, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised time %q", value)
+}
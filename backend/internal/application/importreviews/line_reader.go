@@ -0,0 +1,41 @@
+package importreviews
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+const maxLineSize = 1 << 20
+
+// lineReader returns the non-blank lines of a JSON Lines stream together with
+// their 1-based line numbers.
+type lineReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return &lineReader{scanner: scanner}
+}
+
+func (l *lineReader) next() ([]byte, int, error) {
+	for l.scanner.Scan() {
+		l.line++
+		line := bytes.TrimSpace(l.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if l.line == 1 {
+			line = bytes.TrimPrefix(line, []byte("\ufeff"))
+		}
+		return line, l.line, nil
+	}
+
+	if err := l.scanner.Err(); err != nil {
+		return nil, l.line + 1, err
+	}
+	return nil, l.line, io.EOF
+}
@@ -0,0 +1,174 @@
+package importreviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatCSV, FormatJSONL:
+		return format, nil
+	default:
+		return "", fault.New(fault.KindValidation, fmt.Sprintf("unsupported import format %q", value))
+	}
+}
+
+// Fields lists the review fields that can be imported, in the column order
+// used by the export.
+var Fields = []string{"id", "appId", "author", "score", "content", "submittedAt", "retrievedAt", "source"}
+
+type Options struct {
+	Format Format
+	// Mapping maps a review field to the CSV column or JSON key holding it in
+	// the source file. Unmapped fields are read from the column named after them.
+	Mapping map[string]string
+	DryRun  bool
+}
+
+type RowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type Result struct {
+	DryRun     bool       `json:"dryRun"`
+	Total      int        `json:"total"`
+	Imported   int        `json:"imported"`
+	Duplicates int        `json:"duplicates"`
+	Invalid    int        `json:"invalid"`
+	Errors     []RowError `json:"errors"`
+}
+
+type UseCase interface {
+	// Execute imports reviews of a tracked app, returning app.ErrNotFound
+	// for apps that are not tracked.
+	Execute(ctx context.Context, r io.Reader, appID string, options Options) (*Result, error)
+}
+
+type useCase struct {
+	appRepo    app.Repository
+	reviewRepo review.Repository
+	now        func() time.Time
+}
+
+func NewUseCase(appRepo app.Repository, reviewRepo review.Repository) *useCase {
+	return &useCase{
+		appRepo:    appRepo,
+		reviewRepo: reviewRepo,
+		now:        time.Now,
+	}
+}
+
+func (s *useCase) Execute(ctx context.Context, r io.Reader, appID string, options Options) (*Result, error) {
+	for field := range options.Mapping {
+		if !isField(field) {
+			return nil, fault.New(fault.KindValidation, fmt.Sprintf("unknown review field %q in column mapping", field))
+		}
+	}
+
+	// Reviews of untracked apps would be stored where no app lists them.
+	if _, err := app.FindByID(ctx, s.appRepo, appID); err != nil {
+		return nil, err
+	}
+
+	decoder, err := newDecoder(r, options)
+	if err != nil {
+		return nil, err
+	}
+
+	seenIDs, err := s.existingIDs(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: options.DryRun, Errors: []RowError{}}
+	var reviews []*review.Review
+	retrievedAt := s.now()
+
+	for {
+		row, values, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		var invalidRow *rowError
+		if errors.As(err, &invalidRow) {
+			result.Total++
+			result.reject(row, invalidRow.Error())
+			continue
+		}
+		if err != nil {
+			return nil, fault.Wrap(fault.KindValidation, fmt.Errorf("failed to read row %d: %w", row, err))
+		}
+		result.Total++
+
+		review, err := parseReview(appID, values, retrievedAt)
+		if err != nil {
+			result.reject(row, err.Error())
+			continue
+		}
+
+		if seenIDs[review.ID] {
+			result.Duplicates++
+			continue
+		}
+		seenIDs[review.ID] = true
+
+		reviews = append(reviews, review)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(reviews)
+	if options.DryRun || len(reviews) == 0 {
+		return result, nil
+	}
+
+	if err := s.reviewRepo.Save(ctx, reviews...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *useCase) existingIDs(ctx context.Context, appID string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	err := s.reviewRepo.StreamByAppIDBetween(ctx, appID, time.Time{}, time.Time{}, func(review *review.Review) error {
+		ids[review.ID] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *Result) reject(row int, message string) {
+	r.Invalid++
+	r.Errors = append(r.Errors, RowError{Row: row, Message: message})
+}
+
+func isField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
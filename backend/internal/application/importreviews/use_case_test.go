@@ -0,0 +1,190 @@
+package importreviews_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportReviewsUseCaseTestSuite struct {
+	suite.Suite
+	mockAppRepo    *appmocks.Repository
+	mockReviewRepo *reviewmocks.Repository
+	useCase        importreviews.UseCase
+}
+
+func (s *ImportReviewsUseCaseTestSuite) SetupSubTest() {
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "12345"}}, nil).Maybe()
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.useCase = importreviews.NewUseCase(s.mockAppRepo, s.mockReviewRepo)
+}
+
+func (s *ImportReviewsUseCaseTestSuite) expectExistingIDs(ids ...string) {
+	s.mockReviewRepo.EXPECT().
+		StreamByAppIDBetween(mock.Anything, "12345", time.Time{}, time.Time{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _, _ time.Time, fn func(*review.Review) error) error {
+			for _, id := range ids {
+				if err := fn(&review.Review{ID: id, AppID: "12345"}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (s *ImportReviewsUseCaseTestSuite) TestExecute() {
+	s.Run("should import valid CSV rows through the repository", func() {
+		input := "id,author,score,content,submittedAt\n" +
+			"r1,John Doe,5,\"Great app,\nreally\",2024-03-01T10:00:00Z\n" +
+			"r2,Jane Smith,2,Meh,2024-03-02\n"
+		s.expectExistingIDs()
+
+		var saved []*review.Review
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, reviews ...*review.Review) error {
+				saved = reviews
+				return nil
+			})
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{Format: importreviews.FormatCSV})
+
+		s.Require().NoError(err)
+		s.Equal(2, result.Total)
+		s.Equal(2, result.Imported)
+		s.Empty(result.Errors)
+		s.Require().Len(saved, 2)
+		s.Equal("12345", saved[0].AppID)
+		s.Equal("Great app,\nreally", saved[0].Content)
+		s.Equal(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), saved[1].SubmittedAt)
+		s.False(saved[1].RetrievedAt.IsZero())
+	})
+
+	s.Run("should return not found for untracked apps without reading the file", func() {
+		_, err := s.useCase.Execute(context.Background(), strings.NewReader("id,content\nr1,Hi\n"), "67890", importreviews.Options{Format: importreviews.FormatCSV})
+
+		s.ErrorIs(err, app.ErrNotFound)
+	})
+
+	s.Run("should read columns through the mapping", func() {
+		input := "Review ID,User,Stars,Body,Date\nr1,John,4,Nice,2024-03-01 10:00:00\n"
+		s.expectExistingIDs()
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(reviews []*review.Review) bool {
+			r := reviews[0]
+			return len(reviews) == 1 && r.ID == "r1" && r.Author == "John" && r.Score == 4 && r.Content == "Nice"
+		})).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{
+			Format: importreviews.FormatCSV,
+			Mapping: map[string]string{
+				"id":          "Review ID",
+				"author":      "User",
+				"score":       "Stars",
+				"content":     "Body",
+				"submittedAt": "Date",
+			},
+		})
+
+		s.Require().NoError(err)
+		s.Equal(1, result.Imported)
+	})
+
+	s.Run("should report invalid rows and skip duplicates", func() {
+		input := "id,score,submittedAt,appId\n" +
+			"existing,5,2024-03-01T10:00:00Z,12345\n" +
+			",5,2024-03-01T10:00:00Z,12345\n" +
+			"r2,7,2024-03-01T10:00:00Z,12345\n" +
+			"r3,3,not a date,12345\n" +
+			"r4,3,2024-03-01T10:00:00Z,99999\n" +
+			"r5,1,2024-03-01T10:00:00Z,12345\n" +
+			"r5,1,2024-03-01T10:00:00Z,12345\n"
+		s.expectExistingIDs("existing")
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(reviews []*review.Review) bool {
+			return len(reviews) == 1 && reviews[0].ID == "r5"
+		})).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{Format: importreviews.FormatCSV})
+
+		s.Require().NoError(err)
+		s.Equal(7, result.Total)
+		s.Equal(1, result.Imported)
+		s.Equal(2, result.Duplicates)
+		s.Equal(4, result.Invalid)
+		s.Equal([]importreviews.RowError{
+			{Row: 3, Message: "id is required"},
+			{Row: 4, Message: "score must be a whole number between 1 and 5"},
+			{Row: 5, Message: `submittedAt: unrecognised time "not a date"`},
+			{Row: 6, Message: `appId "99999" does not match app "12345"`},
+		}, result.Errors)
+	})
+
+	s.Run("should import JSON Lines and report malformed lines", func() {
+		input := `{"id":"r1","author":"John","score":5,"content":"Great","submittedAt":"2024-03-01T10:00:00Z"}` + "\n" +
+			"\n" +
+			`{"id":"r2",` + "\n" +
+			`{"id":"r3","score":"4","submittedAt":"2024-03-03T10:00:00Z"}` + "\n"
+		s.expectExistingIDs()
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{Format: importreviews.FormatJSONL})
+
+		s.Require().NoError(err)
+		s.Equal(3, result.Total)
+		s.Equal(2, result.Imported)
+		s.Equal([]importreviews.RowError{{Row: 3, Message: "invalid JSON object"}}, result.Errors)
+	})
+
+	s.Run("should not save anything in dry-run mode", func() {
+		input := "id,score,submittedAt\nr1,5,2024-03-01T10:00:00Z\n"
+		s.expectExistingIDs()
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{Format: importreviews.FormatCSV, DryRun: true})
+
+		s.Require().NoError(err)
+		s.True(result.DryRun)
+		s.Equal(1, result.Imported)
+	})
+
+	s.Run("should return error when mapping references an unknown field", func() {
+		_, err := s.useCase.Execute(context.Background(), strings.NewReader(""), "12345", importreviews.Options{
+			Format:  importreviews.FormatCSV,
+			Mapping: map[string]string{"rating": "Stars"},
+		})
+
+		s.Error(err)
+		s.Contains(err.Error(), `unknown review field "rating"`)
+	})
+
+	s.Run("should return error when CSV header is missing", func() {
+		_, err := s.useCase.Execute(context.Background(), strings.NewReader(""), "12345", importreviews.Options{Format: importreviews.FormatCSV})
+
+		s.Error(err)
+		s.Contains(err.Error(), "failed to read CSV header")
+	})
+
+	s.Run("should return error when repository save fails", func() {
+		input := "id,score,submittedAt\nr1,5,2024-03-01T10:00:00Z\n"
+		s.expectExistingIDs()
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		result, err := s.useCase.Execute(context.Background(), strings.NewReader(input), "12345", importreviews.Options{Format: importreviews.FormatCSV})
+
+		s.ErrorIs(err, assert.AnError)
+		s.Nil(result)
+	})
+}
+
+func TestImportReviewsUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportReviewsUseCaseTestSuite))
+}
@@ -0,0 +1,168 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+const defaultQueueSize = 100
+
+type Options struct {
+	// QueueSize is the number of jobs that may wait for a worker; more are
+	// refused with ErrQueueFull. Zero uses a default.
+	QueueSize int
+}
+
+type UseCase interface {
+	// EnqueueBackfill queues the first fetch of the reviews of an app in the
+	// workspace of ctx and returns the queued job. When the queue is full the
+	// job is recorded as failed and returned with ErrQueueFull.
+	EnqueueBackfill(ctx context.Context, appID string) (*job.Job, error)
+	// Get returns a job of the workspace of ctx, or ErrNotFound.
+	Get(ctx context.Context, id string) (*job.Job, error)
+	// Run runs queued jobs until ctx is done. It may be called from several
+	// goroutines to run jobs at the same time.
+	Run(ctx context.Context)
+	// Drain runs the jobs that are queued and returns once none is left.
+	Drain(ctx context.Context)
+}
+
+type useCase struct {
+	jobRepo              job.Repository
+	reloadReviewsUseCase reloadreviews.UseCase
+	queue                chan *job.Job
+	now                  func() time.Time
+}
+
+func NewUseCase(jobRepo job.Repository, reloadReviewsUseCase reloadreviews.UseCase, options Options) *useCase {
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultQueueSize
+	}
+	return &useCase{
+		jobRepo:              jobRepo,
+		reloadReviewsUseCase: reloadReviewsUseCase,
+		queue:                make(chan *job.Job, options.QueueSize),
+		now:                  time.Now,
+	}
+}
+
+func (u *useCase) EnqueueBackfill(ctx context.Context, appID string) (*job.Job, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &job.Job{
+		ID:        id,
+		Kind:      job.KindBackfill,
+		Workspace: workspace.IDFromContext(ctx),
+		AppID:     appID,
+		Status:    job.StatusQueued,
+		CreatedAt: u.now(),
+	}
+	if err := u.jobRepo.Save(ctx, j); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	queued := *j
+	select {
+	case u.queue <- &queued:
+		return j, nil
+	default:
+		j.Status = job.StatusFailed
+		j.Error = job.ErrQueueFull.Error()
+		j.FinishedAt = j.CreatedAt
+		u.save(ctx, j)
+		return j, job.ErrQueueFull
+	}
+}
+
+func (u *useCase) Get(ctx context.Context, id string) (*job.Job, error) {
+	j, err := u.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Jobs of other workspaces are hidden rather than forbidden, so their
+	// IDs reveal nothing.
+	if j.Workspace != workspace.IDFromContext(ctx) {
+		return nil, fmt.Errorf("%w: %s", job.ErrNotFound, id)
+	}
+	return j, nil
+}
+
+func (u *useCase) Run(ctx context.Context) {
+	for {
+		select {
+		case j := <-u.queue:
+			u.process(ctx, j)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (u *useCase) Drain(ctx context.Context) {
+	for {
+		select {
+		case j := <-u.queue:
+			u.process(ctx, j)
+		default:
+			return
+		}
+	}
+}
+
+// process runs a backfill and records its outcome. A job whose run is
+// cancelled is recorded as failed, so it does not stay running forever.
+func (u *useCase) process(ctx context.Context, j *job.Job) {
+	j.Status = job.StatusRunning
+	j.StartedAt = u.now()
+	u.save(ctx, j)
+
+	summary, err := u.reloadReviewsUseCase.Execute(workspace.WithID(ctx, j.Workspace), j.AppID)
+
+	j.FinishedAt = u.now()
+	j.Status = job.StatusFailed
+	if summary != nil {
+		j.NewReviews = summary.NewReviews
+		j.UpdatedReviews = summary.UpdatedReviews
+	}
+	switch {
+	case err != nil:
+		j.Error = err.Error()
+	case summary.AppsFailed > 0:
+		j.Error = summary.Failures[0].Error
+	case summary.AppsSkipped > 0:
+		j.Error = "skipped because the app's sources keep failing"
+	default:
+		j.Status = job.StatusSucceeded
+	}
+	u.save(ctx, j)
+
+	slog.Info("job finished", "job", j.ID, "kind", j.Kind, "workspace", j.Workspace, "app", j.AppID,
+		"status", j.Status, "newReviews", j.NewReviews, "updatedReviews", j.UpdatedReviews, "error", j.Error)
+}
+
+// save records the state of a job. The state of a job cancelled by shutdown
+// is still recorded.
+func (u *useCase) save(ctx context.Context, j *job.Job) {
+	if err := u.jobRepo.Save(context.WithoutCancel(ctx), j); err != nil {
+		slog.Error("failed to save job", "job", j.ID, "error", err)
+	}
+}
+
+func randomHex(n int) (string, error) {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(data), nil
+}
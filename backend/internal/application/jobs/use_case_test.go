@@ -0,0 +1,165 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/jobs"
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/workspace"
+	reloadreviewsmocks "appstorereviewsviewer/mocks/application/reloadreviews"
+	jobmocks "appstorereviewsviewer/mocks/domain/job"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobsUseCaseTestSuite struct {
+	suite.Suite
+	mockJobRepo              *jobmocks.Repository
+	mockReloadReviewsUseCase *reloadreviewsmocks.UseCase
+	useCase                  jobs.UseCase
+	saved                    []job.Job
+}
+
+func (s *JobsUseCaseTestSuite) SetupSubTest() {
+	s.mockJobRepo = jobmocks.NewRepository(s.T())
+	s.mockReloadReviewsUseCase = reloadreviewsmocks.NewUseCase(s.T())
+	s.useCase = jobs.NewUseCase(s.mockJobRepo, s.mockReloadReviewsUseCase, jobs.Options{QueueSize: 1})
+	s.saved = nil
+}
+
+// expectSaves records the state of the job on every save.
+func (s *JobsUseCaseTestSuite) expectSaves() {
+	s.mockJobRepo.EXPECT().Save(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, j *job.Job) error {
+		s.saved = append(s.saved, *j)
+		return nil
+	})
+}
+
+func inWorkspace(id string) any {
+	return mock.MatchedBy(func(ctx context.Context) bool { return workspace.IDFromContext(ctx) == id })
+}
+
+func (s *JobsUseCaseTestSuite) TestEnqueueBackfill() {
+	s.Run("should queue a backfill job for the app in the workspace", func() {
+		s.expectSaves()
+
+		j, err := s.useCase.EnqueueBackfill(workspace.WithID(context.Background(), "team-a"), "12345")
+
+		s.Require().NoError(err)
+		s.NotEmpty(j.ID)
+		s.Equal(job.KindBackfill, j.Kind)
+		s.Equal("team-a", j.Workspace)
+		s.Equal("12345", j.AppID)
+		s.Equal(job.StatusQueued, j.Status)
+		s.Equal([]job.Job{*j}, s.saved)
+	})
+
+	s.Run("should refuse jobs when the queue is full", func() {
+		s.expectSaves()
+		_, err := s.useCase.EnqueueBackfill(context.Background(), "12345")
+		s.Require().NoError(err)
+
+		j, err := s.useCase.EnqueueBackfill(context.Background(), "67890")
+
+		s.ErrorIs(err, job.ErrQueueFull)
+		s.Require().NotNil(j)
+		s.Equal(job.StatusFailed, j.Status)
+		s.Equal(job.StatusFailed, s.saved[len(s.saved)-1].Status)
+	})
+
+	s.Run("should return error when the job cannot be saved", func() {
+		s.mockJobRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := s.useCase.EnqueueBackfill(context.Background(), "12345")
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (s *JobsUseCaseTestSuite) TestDrain() {
+	s.Run("should fetch the app's reviews in its workspace and record the counts", func() {
+		s.expectSaves()
+		s.mockReloadReviewsUseCase.EXPECT().Execute(inWorkspace("team-a"), []string{"12345"}).
+			Return(&reloadreviews.Summary{AppsOK: 1, NewReviews: 3, UpdatedReviews: 1}, nil)
+		_, err := s.useCase.EnqueueBackfill(workspace.WithID(context.Background(), "team-a"), "12345")
+		s.Require().NoError(err)
+
+		s.useCase.Drain(context.Background())
+
+		s.Require().Len(s.saved, 3)
+		s.Equal(job.StatusRunning, s.saved[1].Status)
+		finished := s.saved[2]
+		s.Equal(job.StatusSucceeded, finished.Status)
+		s.Equal(3, finished.NewReviews)
+		s.Equal(1, finished.UpdatedReviews)
+		s.False(finished.FinishedAt.IsZero())
+	})
+
+	s.Run("should record why the fetch failed", func() {
+		s.expectSaves()
+		s.mockReloadReviewsUseCase.EXPECT().Execute(mock.Anything, []string{"12345"}).
+			Return(&reloadreviews.Summary{AppsFailed: 1, Failures: []reloadreviews.AppFailure{{AppID: "12345", Error: "feed unavailable"}}}, nil)
+		_, err := s.useCase.EnqueueBackfill(context.Background(), "12345")
+		s.Require().NoError(err)
+
+		s.useCase.Drain(context.Background())
+
+		finished := s.saved[len(s.saved)-1]
+		s.Equal(job.StatusFailed, finished.Status)
+		s.Equal("feed unavailable", finished.Error)
+	})
+
+	s.Run("should fail the job when the reload fails", func() {
+		s.expectSaves()
+		s.mockReloadReviewsUseCase.EXPECT().Execute(mock.Anything, []string{"12345"}).Return(nil, assert.AnError)
+		_, err := s.useCase.EnqueueBackfill(context.Background(), "12345")
+		s.Require().NoError(err)
+
+		s.useCase.Drain(context.Background())
+
+		finished := s.saved[len(s.saved)-1]
+		s.Equal(job.StatusFailed, finished.Status)
+		s.Equal(assert.AnError.Error(), finished.Error)
+	})
+
+	s.Run("should return when nothing is queued", func() {
+		s.useCase.Drain(context.Background())
+	})
+}
+
+func (s *JobsUseCaseTestSuite) TestRun() {
+	s.Run("should return when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s.useCase.Run(ctx)
+	})
+}
+
+func (s *JobsUseCaseTestSuite) TestGet() {
+	s.Run("should return a job of the workspace", func() {
+		stored := &job.Job{ID: "j1", Workspace: "team-a"}
+		s.mockJobRepo.EXPECT().FindByID(mock.Anything, "j1").Return(stored, nil)
+
+		j, err := s.useCase.Get(workspace.WithID(context.Background(), "team-a"), "j1")
+
+		s.NoError(err)
+		s.Equal(stored, j)
+	})
+
+	s.Run("should hide jobs of other workspaces", func() {
+		s.mockJobRepo.EXPECT().FindByID(mock.Anything, "j1").Return(&job.Job{ID: "j1", Workspace: "team-a"}, nil)
+
+		_, err := s.useCase.Get(context.Background(), "j1")
+
+		s.ErrorIs(err, job.ErrNotFound)
+	})
+}
+
+func TestJobsUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(JobsUseCaseTestSuite))
+}
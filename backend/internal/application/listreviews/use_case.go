@@ -0,0 +1,58 @@
+package listreviews
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type Filter struct {
+	Since    time.Time
+	MinScore int
+	MaxScore int
+	Limit    int
+}
+
+type UseCase interface {
+	Execute(ctx context.Context, appID string, filter Filter) ([]*review.Review, error)
+}
+
+type useCase struct {
+	reviewRepo review.Repository
+}
+
+func NewUseCase(reviewRepo review.Repository) *useCase {
+	return &useCase{
+		reviewRepo: reviewRepo,
+	}
+}
+
+func (s *useCase) Execute(ctx context.Context, appID string, filter Filter) ([]*review.Review, error) {
+	reviews, err := s.reviewRepo.FindByAppIDSince(ctx, appID, filter.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredReviews := make([]*review.Review, 0, len(reviews))
+	for _, review := range reviews {
+		if filter.MinScore > 0 && review.Score < filter.MinScore {
+			continue
+		}
+		if filter.MaxScore > 0 && review.Score > filter.MaxScore {
+			continue
+		}
+		filteredReviews = append(filteredReviews, review)
+	}
+
+	sort.SliceStable(filteredReviews, func(i, j int) bool {
+		return filteredReviews[i].SubmittedAt.After(filteredReviews[j].SubmittedAt)
+	})
+
+	if filter.Limit > 0 && len(filteredReviews) > filter.Limit {
+		filteredReviews = filteredReviews[:filter.Limit]
+	}
+
+	return filteredReviews, nil
+}
@@ -0,0 +1,85 @@
+package listreviews_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/domain/review"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListReviewsUseCaseTestSuite struct {
+	suite.Suite
+	mockReviewRepo *reviewmocks.Repository
+	useCase        listreviews.UseCase
+}
+
+func (s *ListReviewsUseCaseTestSuite) SetupSubTest() {
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.useCase = listreviews.NewUseCase(s.mockReviewRepo)
+}
+
+func (s *ListReviewsUseCaseTestSuite) storedReviews(now time.Time) []*review.Review {
+	return []*review.Review{
+		{ID: "review1", AppID: "12345", Score: 5, SubmittedAt: now.Add(-3 * time.Hour)},
+		{ID: "review2", AppID: "12345", Score: 1, SubmittedAt: now.Add(-1 * time.Hour)},
+		{ID: "review3", AppID: "12345", Score: 3, SubmittedAt: now.Add(-2 * time.Hour)},
+	}
+}
+
+func (s *ListReviewsUseCaseTestSuite) TestExecute() {
+	s.Run("should return reviews sorted by newest first", func() {
+		now := time.Now()
+		since := now.Add(-24 * time.Hour)
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "12345", since).Return(s.storedReviews(now), nil)
+
+		reviews, err := s.useCase.Execute(context.Background(), "12345", listreviews.Filter{Since: since})
+
+		s.NoError(err)
+		s.Require().Len(reviews, 3)
+		s.Equal("review2", reviews[0].ID)
+		s.Equal("review3", reviews[1].ID)
+		s.Equal("review1", reviews[2].ID)
+	})
+
+	s.Run("should filter reviews by score range", func() {
+		now := time.Now()
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "12345", time.Time{}).Return(s.storedReviews(now), nil)
+
+		reviews, err := s.useCase.Execute(context.Background(), "12345", listreviews.Filter{MinScore: 2, MaxScore: 4})
+
+		s.NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal("review3", reviews[0].ID)
+	})
+
+	s.Run("should limit the number of reviews returned", func() {
+		now := time.Now()
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "12345", time.Time{}).Return(s.storedReviews(now), nil)
+
+		reviews, err := s.useCase.Execute(context.Background(), "12345", listreviews.Filter{Limit: 2})
+
+		s.NoError(err)
+		s.Require().Len(reviews, 2)
+		s.Equal("review2", reviews[0].ID)
+	})
+
+	s.Run("should return error when repository fails", func() {
+		s.mockReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "12345", time.Time{}).Return(nil, assert.AnError)
+
+		reviews, err := s.useCase.Execute(context.Background(), "12345", listreviews.Filter{})
+
+		s.Error(err)
+		s.Nil(reviews)
+	})
+}
+
+func TestListReviewsUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ListReviewsUseCaseTestSuite))
+}
@@ -0,0 +1,58 @@
+package previewreplytemplate
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type UseCase interface {
+	// Execute renders a saved reply template for a stored review.
+	Execute(ctx context.Context, templateID, appID, reviewID string) (string, error)
+}
+
+type useCase struct {
+	templateRepo replytemplate.Repository
+	reviewRepo   review.Repository
+	appRepo      app.Repository
+}
+
+func NewUseCase(templateRepo replytemplate.Repository, reviewRepo review.Repository, appRepo app.Repository) *useCase {
+	return &useCase{templateRepo: templateRepo, reviewRepo: reviewRepo, appRepo: appRepo}
+}
+
+func (u *useCase) Execute(ctx context.Context, templateID, appID, reviewID string) (string, error) {
+	template, err := u.templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := review.FindByID(ctx, u.reviewRepo, appID, reviewID)
+	if err != nil {
+		return "", err
+	}
+
+	appName, err := u.appName(ctx, appID)
+	if err != nil {
+		return "", err
+	}
+
+	return template.Render(r, appName)
+}
+
+// appName returns the display name of the app, or its ID when the app is
+// not tracked.
+func (u *useCase) appName(ctx context.Context, appID string) (string, error) {
+	apps, err := u.appRepo.FindAll(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range apps {
+		if a.ID == appID {
+			return a.DisplayName(), nil
+		}
+	}
+	return appID, nil
+}
@@ -0,0 +1,104 @@
+package previewreplytemplate_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/previewreplytemplate"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/domain/review"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	replytemplatemocks "appstorereviewsviewer/mocks/domain/replytemplate"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PreviewReplyTemplateUseCaseTestSuite struct {
+	suite.Suite
+	mockTemplateRepo *replytemplatemocks.Repository
+	mockReviewRepo   *reviewmocks.Repository
+	mockAppRepo      *appmocks.Repository
+	useCase          previewreplytemplate.UseCase
+}
+
+func (s *PreviewReplyTemplateUseCaseTestSuite) SetupSubTest() {
+	s.mockTemplateRepo = replytemplatemocks.NewRepository(s.T())
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.useCase = previewreplytemplate.NewUseCase(s.mockTemplateRepo, s.mockReviewRepo, s.mockAppRepo)
+}
+
+func (s *PreviewReplyTemplateUseCaseTestSuite) stored(reviews ...*review.Review) {
+	s.mockReviewRepo.EXPECT().StreamByAppIDBetween(mock.Anything, "app1", time.Time{}, time.Time{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _, _ time.Time, fn func(*review.Review) error) error {
+			for _, r := range reviews {
+				if err := fn(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (s *PreviewReplyTemplateUseCaseTestSuite) TestExecute() {
+	s.Run("should render the placeholders for the review", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "t1").Return(&replytemplate.Template{
+			ID:      "t1",
+			Content: "Hi {{author}}, thanks for rating {{appName}} {{version}} {{.Score}} stars!",
+		}, nil)
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Author: "Jane", Version: "2.1.0", Score: 5})
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1", Name: "Example"}}, nil)
+
+		preview, err := s.useCase.Execute(context.Background(), "t1", "app1", "r1")
+
+		s.Require().NoError(err)
+		s.Equal("Hi Jane, thanks for rating Example 2.1.0 5 stars!", preview)
+	})
+
+	s.Run("should use the app ID when the app has no name", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "t1").Return(&replytemplate.Template{ID: "t1", Content: "{{appName}}"}, nil)
+		s.stored(&review.Review{ID: "r1", AppID: "app1"})
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{}, nil)
+
+		preview, err := s.useCase.Execute(context.Background(), "t1", "app1", "r1")
+
+		s.Require().NoError(err)
+		s.Equal("app1", preview)
+	})
+
+	s.Run("should return not found when the template does not exist", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "missing").Return(nil, replytemplate.ErrNotFound)
+
+		_, err := s.useCase.Execute(context.Background(), "missing", "app1", "r1")
+
+		s.ErrorIs(err, replytemplate.ErrNotFound)
+	})
+
+	s.Run("should return not found when the review does not exist", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "t1").Return(&replytemplate.Template{ID: "t1", Content: "Thanks"}, nil)
+		s.stored()
+
+		_, err := s.useCase.Execute(context.Background(), "t1", "app1", "r1")
+
+		s.ErrorIs(err, review.ErrNotFound)
+	})
+
+	s.Run("should return error when loading apps fails", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "t1").Return(&replytemplate.Template{ID: "t1", Content: "Thanks"}, nil)
+		s.stored(&review.Review{ID: "r1", AppID: "app1"})
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(nil, assert.AnError)
+
+		_, err := s.useCase.Execute(context.Background(), "t1", "app1", "r1")
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func TestPreviewReplyTemplateUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewReplyTemplateUseCaseTestSuite))
+}
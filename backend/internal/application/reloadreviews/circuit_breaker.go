@@ -0,0 +1,176 @@
+package reloadreviews
+
+import (
+	"sync"
+	"time"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// AppHealth is the circuit breaker's view of one app.
+type AppHealth struct {
+	AppID               string       `json:"appId"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	LastError           string       `json:"lastError,omitempty"`
+	RetryAt             *time.Time   `json:"retryAt,omitempty"`
+}
+
+// CircuitBreaker stops fetching apps that keep failing. After threshold
+// consecutive failures an app is skipped for a cooldown, then a single trial
+// fetch is let through. A failed trial reopens the breaker with the cooldown
+// doubled up to maxCooldown; a success closes it. A nil breaker never skips.
+type CircuitBreaker struct {
+	threshold   int
+	cooldown    time.Duration
+	maxCooldown time.Duration
+	now         func() time.Time
+
+	mu   sync.Mutex
+	apps map[string]*breakerEntry
+}
+
+type breakerEntry struct {
+	failures  int
+	lastError string
+	cooldown  time.Duration
+	openUntil time.Time
+	trial     bool
+}
+
+func NewCircuitBreaker(threshold int, cooldown, maxCooldown time.Duration) *CircuitBreaker {
+	return newCircuitBreaker(threshold, cooldown, maxCooldown, time.Now)
+}
+
+func newCircuitBreaker(threshold int, cooldown, maxCooldown time.Duration, now func() time.Time) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:   max(threshold, 1),
+		cooldown:    cooldown,
+		maxCooldown: max(maxCooldown, cooldown),
+		now:         now,
+		apps:        make(map[string]*breakerEntry),
+	}
+}
+
+// Health reports the breaker state of an app.
+func (b *CircuitBreaker) Health(appID string) AppHealth {
+	health := AppHealth{AppID: appID, State: BreakerClosed}
+	if b == nil {
+		return health
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.apps[appID]
+	if !ok {
+		return health
+	}
+
+	health.ConsecutiveFailures = entry.failures
+	health.LastError = entry.lastError
+	health.State = b.state(entry)
+	if health.State == BreakerOpen {
+		retryAt := entry.openUntil
+		health.RetryAt = &retryAt
+	}
+	return health
+}
+
+// allow reports whether the app may be fetched now. While half-open only one
+// trial fetch is allowed at a time.
+func (b *CircuitBreaker) allow(appID string) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.apps[appID]
+	if !ok {
+		return true
+	}
+
+	switch b.state(entry) {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if entry.trial {
+			return false
+		}
+		entry.trial = true
+	}
+	return true
+}
+
+func (b *CircuitBreaker) success(appID string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.apps, appID)
+}
+
+func (b *CircuitBreaker) failure(appID string, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.apps[appID]
+	if !ok {
+		entry = &breakerEntry{}
+		b.apps[appID] = entry
+	}
+
+	entry.failures++
+	entry.lastError = err.Error()
+
+	switch {
+	case entry.trial:
+		entry.trial = false
+		entry.cooldown = min(entry.cooldown*2, b.maxCooldown)
+	case entry.failures >= b.threshold && entry.openUntil.IsZero():
+		entry.cooldown = b.cooldown
+	default:
+		return
+	}
+	entry.openUntil = b.now().Add(entry.cooldown)
+}
+
+// abort gives back a trial that ended without a verdict, such as when the run
+// was cancelled.
+func (b *CircuitBreaker) abort(appID string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if entry, ok := b.apps[appID]; ok {
+		entry.trial = false
+	}
+}
+
+func (b *CircuitBreaker) state(entry *breakerEntry) BreakerState {
+	switch {
+	case entry.openUntil.IsZero():
+		return BreakerClosed
+	case b.now().Before(entry.openUntil):
+		return BreakerOpen
+	default:
+		return BreakerHalfOpen
+	}
+}
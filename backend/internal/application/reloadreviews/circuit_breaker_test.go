@@ -0,0 +1,137 @@
+package reloadreviews_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CircuitBreakerTestSuite struct {
+	suite.Suite
+	mockLocalReviewRepo  *reviewmocks.Repository
+	mockRemoteReviewRepo *reviewmocks.Repository
+	mockAppRepo          *appmocks.Repository
+	now                  time.Time
+	breaker              *reloadreviews.CircuitBreaker
+	useCase              reloadreviews.UseCase
+}
+
+func (s *CircuitBreakerTestSuite) SetupSubTest() {
+	s.mockLocalReviewRepo = reviewmocks.NewRepository(s.T())
+	s.mockRemoteReviewRepo = reviewmocks.NewRepository(s.T())
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.breaker = reloadreviews.NewCircuitBreakerWithClock(2, time.Minute, 3*time.Minute, func() time.Time { return s.now })
+	s.useCase = reloadreviews.NewUseCase(s.mockLocalReviewRepo, appStoreSource(s.mockRemoteReviewRepo), s.mockAppRepo, reloadreviews.Options{
+		Concurrency: 1,
+		Breaker:     s.breaker,
+	})
+	s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}}, nil).Maybe()
+}
+
+func (s *CircuitBreakerTestSuite) reload() *reloadreviews.Summary {
+	summary, err := s.useCase.Execute(context.Background())
+	s.Require().NoError(err)
+	return summary
+}
+
+func (s *CircuitBreakerTestSuite) expectFetch(err error) {
+	call := s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Once()
+	if err != nil {
+		call.Return(nil, err)
+		return
+	}
+	call.Return([]*review.Review{}, nil)
+}
+
+func (s *CircuitBreakerTestSuite) TestCircuitBreaker() {
+	s.Run("should skip an app after consecutive failures", func() {
+		s.expectFetch(assert.AnError)
+		s.expectFetch(assert.AnError)
+
+		s.Equal(1, s.reload().AppsFailed)
+		s.Equal(reloadreviews.BreakerClosed, s.breaker.Health("app1").State)
+		s.Equal(1, s.reload().AppsFailed)
+
+		health := s.breaker.Health("app1")
+		s.Equal(reloadreviews.BreakerOpen, health.State)
+		s.Equal(2, health.ConsecutiveFailures)
+		s.Contains(health.LastError, assert.AnError.Error())
+		s.Require().NotNil(health.RetryAt)
+		s.Equal(s.now.Add(time.Minute), *health.RetryAt)
+
+		summary := s.reload()
+		s.Equal(1, summary.AppsSkipped)
+		s.Equal([]string{"app1"}, summary.Skipped)
+		s.Equal(0, summary.AppsFailed)
+	})
+
+	s.Run("should close again after a successful trial", func() {
+		s.expectFetch(assert.AnError)
+		s.expectFetch(assert.AnError)
+		s.reload()
+		s.reload()
+
+		s.now = s.now.Add(time.Minute)
+		s.Equal(reloadreviews.BreakerHalfOpen, s.breaker.Health("app1").State)
+
+		s.expectFetch(nil)
+		s.Equal(1, s.reload().AppsOK)
+
+		health := s.breaker.Health("app1")
+		s.Equal(reloadreviews.BreakerClosed, health.State)
+		s.Equal(0, health.ConsecutiveFailures)
+		s.Nil(health.RetryAt)
+	})
+
+	s.Run("should double the cooldown up to the maximum when trials fail", func() {
+		s.expectFetch(assert.AnError)
+		s.expectFetch(assert.AnError)
+		s.reload()
+		s.reload()
+
+		s.now = s.now.Add(time.Minute)
+		s.expectFetch(assert.AnError)
+		s.reload()
+		s.Equal(s.now.Add(2*time.Minute), *s.breaker.Health("app1").RetryAt)
+
+		s.now = s.now.Add(2 * time.Minute)
+		s.expectFetch(assert.AnError)
+		s.reload()
+		s.Equal(s.now.Add(3*time.Minute), *s.breaker.Health("app1").RetryAt)
+	})
+
+	s.Run("should not count failures caused by cancellation", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).
+			RunAndReturn(func(context.Context, string, time.Time) ([]*review.Review, error) {
+				cancel()
+				return nil, context.Canceled
+			}).Once()
+
+		_, err := s.useCase.Execute(ctx)
+
+		s.ErrorIs(err, context.Canceled)
+		s.Equal(0, s.breaker.Health("app1").ConsecutiveFailures)
+	})
+
+	s.Run("should report unknown apps as closed", func() {
+		health := s.breaker.Health("unknown")
+
+		s.Equal(reloadreviews.AppHealth{AppID: "unknown", State: reloadreviews.BreakerClosed}, health)
+	})
+}
+
+func TestCircuitBreakerTestSuite(t *testing.T) {
+	suite.Run(t, new(CircuitBreakerTestSuite))
+}
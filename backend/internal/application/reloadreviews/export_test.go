@@ -0,0 +1,7 @@
+package reloadreviews
+
+import "time"
+
+func NewCircuitBreakerWithClock(threshold int, cooldown, maxCooldown time.Duration, now func() time.Time) *CircuitBreaker {
+	return newCircuitBreaker(threshold, cooldown, maxCooldown, now)
+}
@@ -1,53 +1,246 @@
 package reloadreviews
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"appstorereviewsviewer/internal/domain/app"
 	"appstorereviewsviewer/internal/domain/review"
 )
 
+type Options struct {
+	// Concurrency is the number of apps fetched at the same time. Values
+	// below one fetch sequentially.
+	Concurrency int
+	// Timeout bounds a whole run. Zero means the run is only bounded by the
+	// caller's context.
+	Timeout time.Duration
+	// Breaker skips apps that keep failing. Nil fetches every app on every
+	// run.
+	Breaker *CircuitBreaker
+}
+
+type AppFailure struct {
+	AppID string `json:"appId"`
+	Error string `json:"error"`
+}
+
+// AppReviews counts the reviews a run stored for one app.
+type AppReviews struct {
+	AppID          string `json:"appId"`
+	NewReviews     int    `json:"newReviews"`
+	UpdatedReviews int    `json:"updatedReviews"`
+}
+
+// Summary describes the outcome of one reload run.
+type Summary struct {
+	AppsOK         int           `json:"appsOk"`
+	AppsFailed     int           `json:"appsFailed"`
+	AppsSkipped    int           `json:"appsSkipped"`
+	NewReviews     int           `json:"newReviews"`
+	UpdatedReviews int           `json:"updatedReviews"`
+	Duration       time.Duration `json:"duration"`
+	Failures       []AppFailure  `json:"failures"`
+	// Skipped lists the apps whose circuit breaker is open.
+	Skipped []string `json:"skipped"`
+	// Ingested lists the apps that got new or updated reviews.
+	Ingested []AppReviews `json:"ingested"`
+}
+
 type UseCase interface {
-	Execute() error
+	// Execute reloads the reviews of the given tracked apps, or of every
+	// tracked app when none are given. Failures of single apps are reported
+	// in the summary rather than as an error.
+	Execute(ctx context.Context, appIDs ...string) (*Summary, error)
 }
 
 type useCase struct {
-	localReviewRepo  review.Repository
-	remoteReviewRepo review.Repository
-	appRepo          app.Repository
+	localReviewRepo review.Repository
+	sources         *review.SourceRegistry
+	appRepo         app.Repository
+	options         Options
+	now             func() time.Time
 }
 
-func NewUseCase(localReviewRepo, remoteReviewRepo review.Repository, appRepo app.Repository) *useCase {
+func NewUseCase(localReviewRepo review.Repository, sources *review.SourceRegistry, appRepo app.Repository, options Options) *useCase {
 	return &useCase{
-		localReviewRepo:  localReviewRepo,
-		remoteReviewRepo: remoteReviewRepo,
-		appRepo:          appRepo,
+		localReviewRepo: localReviewRepo,
+		sources:         sources,
+		appRepo:         appRepo,
+		options:         options,
+		now:             time.Now,
 	}
 }
 
-func (s *useCase) Execute() error {
-	apps, err := s.appRepo.FindAll()
+func (s *useCase) Execute(ctx context.Context, appIDs ...string) (*Summary, error) {
+	start := s.now()
+
+	if s.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.options.Timeout)
+		defer cancel()
+	}
+
+	apps, err := s.appRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err = selectApps(apps, appIDs)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	summary := &Summary{Failures: []AppFailure{}, Skipped: []string{}, Ingested: []AppReviews{}}
+	var mu sync.Mutex
+
+	jobs := make(chan *app.App)
+	var wg sync.WaitGroup
+	for range min(max(s.options.Concurrency, 1), max(len(apps), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for app := range jobs {
+				// Apps reached after the run is cancelled are reported as
+				// failed without being fetched.
+				err := ctx.Err()
+				if err == nil && !s.options.Breaker.allow(app.ID) {
+					mu.Lock()
+					summary.AppsSkipped++
+					summary.Skipped = append(summary.Skipped, app.ID)
+					mu.Unlock()
+					continue
+				}
+
+				var newReviews, updatedReviews int
+				if err == nil {
+					newReviews, updatedReviews, err = s.reloadApp(ctx, app)
+					s.recordOutcome(ctx, app.ID, err)
+				}
+
+				mu.Lock()
+				if err != nil {
+					slog.Error("error reloading reviews for app", "app", app.ID, "error", err)
+					summary.AppsFailed++
+					summary.Failures = append(summary.Failures, AppFailure{AppID: app.ID, Error: err.Error()})
+				} else {
+					summary.AppsOK++
+				}
+				summary.NewReviews += newReviews
+				summary.UpdatedReviews += updatedReviews
+				if newReviews+updatedReviews > 0 {
+					summary.Ingested = append(summary.Ingested, AppReviews{AppID: app.ID, NewReviews: newReviews, UpdatedReviews: updatedReviews})
+				}
+				mu.Unlock()
+			}
+		}()
 	}
 
 	for _, app := range apps {
-		reviews, err := s.remoteReviewRepo.FindByAppIDSince(
-			app.ID,
-			time.Now().Add(-time.Duration(review.RecentReviewHourThreshold)*time.Hour),
-		)
+		jobs <- app
+	}
+	close(jobs)
+	wg.Wait()
+
+	summary.Duration = s.now().Sub(start)
+
+	if err := ctx.Err(); err != nil {
+		return summary, err
+	}
+	return summary, nil
+}
+
+// recordOutcome feeds the result of a fetch to the circuit breaker. Failures
+// caused by the run ending say nothing about the app and are not counted.
+func (s *useCase) recordOutcome(ctx context.Context, appID string, err error) {
+	switch {
+	case err == nil:
+		s.options.Breaker.success(appID)
+	case ctx.Err() != nil:
+		s.options.Breaker.abort(appID)
+	default:
+		s.options.Breaker.failure(appID, err)
+	}
+}
+
+// reloadApp fetches the recent reviews of an app from all of its sources and
+// saves the ones that are new or changed since they were last stored. Reviews
+// from sources that succeeded are saved even when another source failed; the
+// failure is still reported.
+func (s *useCase) reloadApp(ctx context.Context, app *app.App) (newReviews, updatedReviews int, err error) {
+	reviews, fetchErr := s.fetchReviews(ctx, app)
+	if len(reviews) == 0 {
+		return 0, 0, fetchErr
+	}
+
+	newReviews, updatedReviews, err = s.saveChanged(ctx, reviews)
+	if err != nil {
+		return 0, 0, err
+	}
+	return newReviews, updatedReviews, fetchErr
+}
+
+// fetchReviews reads the recent reviews of every source of app and tags them
+// with the app and the source they came from.
+func (s *useCase) fetchReviews(ctx context.Context, app *app.App) ([]*review.Review, error) {
+	since := s.now().Add(-time.Duration(review.RecentReviewHourThreshold) * time.Hour)
+
+	var reviews []*review.Review
+	var errs []error
+	for _, appSource := range app.ReviewSources() {
+		source, err := s.sources.Get(appSource.Name)
 		if err != nil {
-			slog.Error("error finding reviews for app", "app", app.ID, "error", err)
+			errs = append(errs, err)
 			continue
 		}
 
-		for _, review := range reviews {
-			if err := s.localReviewRepo.Save(review); err != nil {
-				slog.Error("error saving review", "review", review, "error", err)
-			}
+		fetched, err := source.FindByAppIDSince(ctx, appSource.ExternalID, since)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch reviews from %s: %w", appSource.Name, err))
+			continue
+		}
+
+		for _, fetchedReview := range fetched {
+			fetchedReview.AppID = app.ID
+			fetchedReview.Source = appSource.Name
+		}
+		reviews = append(reviews, fetched...)
+	}
+
+	return reviews, errors.Join(errs...)
+}
+
+// saveChanged stores the reviews that are new or differ from the stored ones.
+func (s *useCase) saveChanged(ctx context.Context, reviews []*review.Review) (newReviews, updatedReviews int, err error) {
+	newReviews, updatedReviews, err = s.localReviewRepo.Merge(ctx, reviews...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to save reviews: %w", err)
+	}
+	return newReviews, updatedReviews, nil
+}
+
+func selectApps(apps []*app.App, appIDs []string) ([]*app.App, error) {
+	if len(appIDs) == 0 {
+		return apps, nil
+	}
+
+	appsByID := make(map[string]*app.App, len(apps))
+	for _, app := range apps {
+		appsByID[app.ID] = app
+	}
+
+	selected := make([]*app.App, 0, len(appIDs))
+	for _, appID := range appIDs {
+		tracked, ok := appsByID[appID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", app.ErrNotFound, appID)
 		}
+		selected = append(selected, tracked)
 	}
 
-	return nil
+	return selected, nil
 }
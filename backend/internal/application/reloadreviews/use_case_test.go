@@ -1,6 +1,9 @@
 package reloadreviews_test
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,14 +26,23 @@ type ReloadReviewsUseCaseTestSuite struct {
 	useCase              reloadreviews.UseCase
 }
 
+// appStoreSource registers source as the App Store feed, the source of apps
+// that do not declare any.
+func appStoreSource(source review.Source) *review.SourceRegistry {
+	sources := review.NewSourceRegistry()
+	sources.Register(review.SourceAppStore, source)
+	return sources
+}
+
 func (s *ReloadReviewsUseCaseTestSuite) SetupSubTest() {
 	s.mockLocalReviewRepo = reviewmocks.NewRepository(s.T())
 	s.mockRemoteReviewRepo = reviewmocks.NewRepository(s.T())
 	s.mockAppRepo = appmocks.NewRepository(s.T())
 	s.useCase = reloadreviews.NewUseCase(
 		s.mockLocalReviewRepo,
-		s.mockRemoteReviewRepo,
+		appStoreSource(s.mockRemoteReviewRepo),
 		s.mockAppRepo,
+		reloadreviews.Options{Concurrency: 2},
 	)
 }
 
@@ -65,21 +77,26 @@ func (s *ReloadReviewsUseCaseTestSuite) TestExecute() {
 			},
 		}
 
-		s.mockAppRepo.EXPECT().FindAll().Return(apps, nil)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app1", mock.AnythingOfType("time.Time")).Return(app1Reviews, nil)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app2", mock.AnythingOfType("time.Time")).Return(app2Reviews, nil)
-		s.mockLocalReviewRepo.EXPECT().Save(mock.Anything).Return(nil)
-		s.mockLocalReviewRepo.EXPECT().Save(mock.Anything).Return(nil)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return(app1Reviews, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app2", mock.AnythingOfType("time.Time")).Return(app2Reviews, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, app1Reviews).Return(1, 0, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, app2Reviews).Return(1, 0, nil)
 
-		err := s.useCase.Execute()
+		summary, err := s.useCase.Execute(context.Background())
 
 		s.NoError(err)
+		s.Equal(2, summary.AppsOK)
+		s.Equal(0, summary.AppsFailed)
+		s.Equal(2, summary.NewReviews)
+		s.Equal(0, summary.UpdatedReviews)
+		s.ElementsMatch([]reloadreviews.AppReviews{{AppID: "app1", NewReviews: 1}, {AppID: "app2", NewReviews: 1}}, summary.Ingested)
 	})
 
 	s.Run("should return error when app repository fails", func() {
-		s.mockAppRepo.EXPECT().FindAll().Return(nil, assert.AnError)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(nil, assert.AnError)
 
-		err := s.useCase.Execute()
+		_, err := s.useCase.Execute(context.Background())
 
 		s.Error(err)
 	})
@@ -102,14 +119,18 @@ func (s *ReloadReviewsUseCaseTestSuite) TestExecute() {
 			},
 		}
 
-		s.mockAppRepo.EXPECT().FindAll().Return(apps, nil)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app1", mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app2", mock.AnythingOfType("time.Time")).Return(app2Reviews, nil)
-		s.mockLocalReviewRepo.EXPECT().Save(mock.Anything).Return(nil)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return(nil, assert.AnError)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app2", mock.AnythingOfType("time.Time")).Return(app2Reviews, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, mock.Anything).Return(1, 0, nil)
 
-		err := s.useCase.Execute()
+		summary, err := s.useCase.Execute(context.Background())
 
 		s.NoError(err)
+		s.Equal(1, summary.AppsOK)
+		s.Equal(1, summary.AppsFailed)
+		s.Require().Len(summary.Failures, 1)
+		s.Equal("app1", summary.Failures[0].AppID)
 	})
 
 	s.Run("should continue when local repository save fails for one review", func() {
@@ -129,23 +150,182 @@ func (s *ReloadReviewsUseCaseTestSuite) TestExecute() {
 			},
 		}
 
-		s.mockAppRepo.EXPECT().FindAll().Return(apps, nil)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app1", mock.AnythingOfType("time.Time")).Return(app1Reviews, nil)
-		s.mockLocalReviewRepo.EXPECT().Save(mock.Anything).Return(assert.AnError)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return(app1Reviews, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, mock.Anything).Return(0, 0, assert.AnError)
 
-		err := s.useCase.Execute()
+		summary, err := s.useCase.Execute(context.Background())
 
 		s.NoError(err)
+		s.Equal(1, summary.AppsFailed)
 	})
 
 	s.Run("should handle empty apps list", func() {
 		apps := []*app.App{}
 
-		s.mockAppRepo.EXPECT().FindAll().Return(apps, nil)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+
+		_, err := s.useCase.Execute(context.Background())
+
+		s.NoError(err)
+	})
+
+	s.Run("should reload only the given apps", func() {
+		apps := []*app.App{
+			{ID: "app1"},
+			{ID: "app2"},
+		}
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app2", mock.AnythingOfType("time.Time")).Return([]*review.Review{}, nil)
+
+		_, err := s.useCase.Execute(context.Background(), "app2")
+
+		s.NoError(err)
+	})
+
+	s.Run("should return not found when a given app is not tracked", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}}, nil)
+
+		_, err := s.useCase.Execute(context.Background(), "app3")
+
+		s.ErrorIs(err, app.ErrNotFound)
+	})
+
+	s.Run("should stop fetching when the context is cancelled", func() {
+		useCase := reloadreviews.NewUseCase(s.mockLocalReviewRepo, appStoreSource(s.mockRemoteReviewRepo), s.mockAppRepo, reloadreviews.Options{Concurrency: 1})
+		ctx, cancel := context.WithCancel(context.Background())
+		apps := []*app.App{
+			{ID: "app1"},
+			{ID: "app2"},
+		}
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).
+			RunAndReturn(func(context.Context, string, time.Time) ([]*review.Review, error) {
+				cancel()
+				return nil, context.Canceled
+			})
+
+		summary, err := useCase.Execute(ctx)
+
+		s.ErrorIs(err, context.Canceled)
+		s.Equal(0, summary.AppsOK)
+		s.Equal(2, summary.AppsFailed)
+	})
+
+	s.Run("should count the new and updated reviews the repository merged", func() {
+		fetched := []*review.Review{{ID: "review1", Score: 4}, {ID: "review2", Score: 5}, {ID: "review3", Score: 5}}
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}}, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return(fetched, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, fetched).Return(1, 1, nil)
+
+		summary, err := s.useCase.Execute(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, summary.AppsOK)
+		s.Equal(1, summary.NewReviews)
+		s.Equal(1, summary.UpdatedReviews)
+		s.Equal([]reloadreviews.AppReviews{{AppID: "app1", NewReviews: 1, UpdatedReviews: 1}}, summary.Ingested)
+	})
+
+	s.Run("should fetch from every source of an app and tag the reviews", func() {
+		playSource := reviewmocks.NewSource(s.T())
+		sources := appStoreSource(s.mockRemoteReviewRepo)
+		sources.Register(review.SourceGooglePlay, playSource)
+		useCase := reloadreviews.NewUseCase(s.mockLocalReviewRepo, sources, s.mockAppRepo, reloadreviews.Options{Concurrency: 1})
+
+		appStoreReview := &review.Review{ID: "review1", Author: "John", Score: 5, SubmittedAt: time.Now()}
+		playReview := &review.Review{ID: "gp:review2", Author: "Jane", Score: 4, SubmittedAt: time.Now()}
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1", Sources: []app.Source{
+			{Name: review.SourceAppStore, ExternalID: "12345"},
+			{Name: "googleplay", ExternalID: "com.example.app"},
+		}}}, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "12345", mock.AnythingOfType("time.Time")).Return([]*review.Review{appStoreReview}, nil)
+		playSource.EXPECT().FindByAppIDSince(mock.Anything, "com.example.app", mock.AnythingOfType("time.Time")).Return([]*review.Review{playReview}, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, []*review.Review{appStoreReview, playReview}).Return(2, 0, nil)
+
+		summary, err := useCase.Execute(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, summary.AppsOK)
+		s.Equal(2, summary.NewReviews)
+		s.Equal("app1", appStoreReview.AppID)
+		s.Equal(review.SourceAppStore, appStoreReview.Source)
+		s.Equal("app1", playReview.AppID)
+		s.Equal("googleplay", playReview.Source)
+	})
+
+	s.Run("should save reviews of working sources when another source fails", func() {
+		fetched := &review.Review{ID: "review1", Score: 5, SubmittedAt: time.Now()}
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1", Sources: []app.Source{
+			{Name: review.SourceAppStore, ExternalID: "app1"},
+			{Name: "unknown", ExternalID: "app1"},
+		}}}, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return([]*review.Review{fetched}, nil)
+		s.mockLocalReviewRepo.EXPECT().Merge(mock.Anything, []*review.Review{fetched}).Return(1, 0, nil)
 
-		err := s.useCase.Execute()
+		summary, err := s.useCase.Execute(context.Background())
 
 		s.NoError(err)
+		s.Equal(1, summary.AppsFailed)
+		s.Equal(1, summary.NewReviews)
+		s.Require().Len(summary.Failures, 1)
+		s.Contains(summary.Failures[0].Error, `unknown review source "unknown"`)
+	})
+
+	s.Run("should fetch no more apps at once than the configured concurrency", func() {
+		useCase := reloadreviews.NewUseCase(s.mockLocalReviewRepo, appStoreSource(s.mockRemoteReviewRepo), s.mockAppRepo, reloadreviews.Options{Concurrency: 3})
+		apps := make([]*app.App, 10)
+		for i := range apps {
+			apps[i] = &app.App{ID: fmt.Sprintf("app%d", i)}
+		}
+
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, mock.Anything, mock.AnythingOfType("time.Time")).
+			RunAndReturn(func(context.Context, string, time.Time) ([]*review.Review, error) {
+				mu.Lock()
+				running++
+				maxRunning = max(maxRunning, running)
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil, nil
+			}).Times(10)
+
+		summary, err := useCase.Execute(context.Background())
+
+		s.NoError(err)
+		s.Equal(10, summary.AppsOK)
+		s.Equal(3, maxRunning)
+	})
+
+	s.Run("should stop the run when its timeout passes", func() {
+		useCase := reloadreviews.NewUseCase(s.mockLocalReviewRepo, appStoreSource(s.mockRemoteReviewRepo), s.mockAppRepo, reloadreviews.Options{
+			Concurrency: 1,
+			Timeout:     20 * time.Millisecond,
+		})
+
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}, {ID: "app2"}}, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).
+			RunAndReturn(func(ctx context.Context, _ string, _ time.Time) ([]*review.Review, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+		summary, err := useCase.Execute(context.Background())
+
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.Equal(2, summary.AppsFailed)
 	})
 
 	s.Run("should handle empty reviews for app", func() {
@@ -153,10 +333,10 @@ func (s *ReloadReviewsUseCaseTestSuite) TestExecute() {
 			{ID: "app1"},
 		}
 
-		s.mockAppRepo.EXPECT().FindAll().Return(apps, nil)
-		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince("app1", mock.AnythingOfType("time.Time")).Return([]*review.Review{}, nil)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(apps, nil)
+		s.mockRemoteReviewRepo.EXPECT().FindByAppIDSince(mock.Anything, "app1", mock.AnythingOfType("time.Time")).Return([]*review.Review{}, nil)
 
-		err := s.useCase.Execute()
+		_, err := s.useCase.Execute(context.Background())
 
 		s.NoError(err)
 	})
@@ -0,0 +1,25 @@
+package removeapp
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/domain/app"
+)
+
+type UseCase interface {
+	Execute(ctx context.Context, appID string) error
+}
+
+type useCase struct {
+	appRepo app.Repository
+}
+
+func NewUseCase(appRepo app.Repository) *useCase {
+	return &useCase{appRepo: appRepo}
+}
+
+// Execute stops tracking the app. Its stored reviews are kept so they remain
+// available if the app is added again.
+func (u *useCase) Execute(ctx context.Context, appID string) error {
+	return u.appRepo.Delete(ctx, appID)
+}
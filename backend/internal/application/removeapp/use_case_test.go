@@ -0,0 +1,46 @@
+package removeapp_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/removeapp"
+	"appstorereviewsviewer/internal/domain/app"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoveAppUseCaseTestSuite struct {
+	suite.Suite
+	mockAppRepo *appmocks.Repository
+	useCase     removeapp.UseCase
+}
+
+func (s *RemoveAppUseCaseTestSuite) SetupSubTest() {
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.useCase = removeapp.NewUseCase(s.mockAppRepo)
+}
+
+func (s *RemoveAppUseCaseTestSuite) TestExecute() {
+	s.Run("should delete app from repository", func() {
+		s.mockAppRepo.EXPECT().Delete(mock.Anything, "12345").Return(nil)
+
+		err := s.useCase.Execute(context.Background(), "12345")
+
+		s.NoError(err)
+	})
+
+	s.Run("should return error when app is not tracked", func() {
+		s.mockAppRepo.EXPECT().Delete(mock.Anything, "12345").Return(app.ErrNotFound)
+
+		err := s.useCase.Execute(context.Background(), "12345")
+
+		s.ErrorIs(err, app.ErrNotFound)
+	})
+}
+
+func TestRemoveAppUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveAppUseCaseTestSuite))
+}
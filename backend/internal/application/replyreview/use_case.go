@@ -0,0 +1,109 @@
+package replyreview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+var (
+	ErrEmptyReply   = fault.New(fault.KindValidation, "reply content is required")
+	ErrNotSupported = fault.New(fault.KindValidation, "replies are not supported for the review's source")
+	ErrReplyExists  = fault.New(fault.KindConflict, "review already has a reply")
+	ErrNoReply      = fault.New(fault.KindConflict, "review has no reply")
+)
+
+type Request struct {
+	AppID    string
+	ReviewID string
+	Action   Action
+	// Content is the reply text, unused when deleting.
+	Content string
+}
+
+type UseCase interface {
+	// Execute creates, updates or deletes the developer reply to a review in
+	// its store and records the outcome on the stored review.
+	Execute(ctx context.Context, request Request) (*review.Review, error)
+}
+
+type useCase struct {
+	reviewRepo review.Repository
+	responders map[string]review.Responder
+}
+
+// NewUseCase replies through the responder registered for a review's source;
+// reviews from other sources cannot be replied to.
+func NewUseCase(reviewRepo review.Repository, responders map[string]review.Responder) *useCase {
+	return &useCase{reviewRepo: reviewRepo, responders: responders}
+}
+
+func (u *useCase) Execute(ctx context.Context, request Request) (*review.Review, error) {
+	content := strings.TrimSpace(request.Content)
+	if request.Action != ActionDelete && content == "" {
+		return nil, ErrEmptyReply
+	}
+
+	r, err := review.FindByID(ctx, u.reviewRepo, request.AppID, request.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	responder, ok := u.responders[r.Source]
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	switch request.Action {
+	case ActionCreate:
+		if r.HasReply() {
+			return nil, ErrReplyExists
+		}
+	case ActionUpdate, ActionDelete:
+		if !r.HasReply() {
+			return nil, ErrNoReply
+		}
+	default:
+		return nil, fault.New(fault.KindValidation, fmt.Sprintf("unknown reply action %q", request.Action))
+	}
+
+	if request.Action == ActionDelete {
+		if err := responder.DeleteReply(ctx, r.Reply.ID); err != nil {
+			return nil, responderError(fmt.Errorf("failed to delete reply: %w", err))
+		}
+		r.SetReply(&review.Reply{State: review.ReplyDeleted, UpdatedAt: time.Now()})
+	} else {
+		reply, err := responder.PublishReply(ctx, r.ID, content)
+		if err != nil {
+			return nil, responderError(fmt.Errorf("failed to publish reply: %w", err))
+		}
+		r.SetReply(reply)
+	}
+
+	if err := u.reviewRepo.Save(ctx, r); err != nil {
+		return nil, fmt.Errorf("failed to save reply: %w", err)
+	}
+	return r, nil
+}
+
+// responderError keeps the kind the responder gave an error, such as a reply
+// the store rejected as invalid, and otherwise treats the store as
+// unavailable.
+func responderError(err error) error {
+	if fault.KindOf(err) != "" {
+		return err
+	}
+	return fault.Wrap(fault.KindUnavailable, err)
+}
@@ -0,0 +1,175 @@
+package replyreview_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/replyreview"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplyReviewUseCaseTestSuite struct {
+	suite.Suite
+	mockReviewRepo *reviewmocks.Repository
+	mockResponder  *reviewmocks.Responder
+	useCase        replyreview.UseCase
+}
+
+func (s *ReplyReviewUseCaseTestSuite) SetupSubTest() {
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.mockResponder = reviewmocks.NewResponder(s.T())
+	s.useCase = replyreview.NewUseCase(s.mockReviewRepo, map[string]review.Responder{
+		review.SourceAppStoreConnect: s.mockResponder,
+	})
+}
+
+// stored makes the review repository stream the given reviews.
+func (s *ReplyReviewUseCaseTestSuite) stored(reviews ...*review.Review) {
+	s.mockReviewRepo.EXPECT().StreamByAppIDBetween(mock.Anything, "app1", time.Time{}, time.Time{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _, _ time.Time, fn func(*review.Review) error) error {
+			for _, r := range reviews {
+				if err := fn(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (s *ReplyReviewUseCaseTestSuite) TestExecute() {
+	publishedAt := time.Date(2025, 1, 9, 8, 0, 0, 0, time.UTC)
+
+	s.Run("should publish a reply and store it on the review", func() {
+		stored := &review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect}
+		s.stored(&review.Review{ID: "r0", AppID: "app1"}, stored)
+		reply := &review.Reply{ID: "resp1", Content: "Thanks!", State: review.ReplyPending, UpdatedAt: publishedAt}
+		s.mockResponder.EXPECT().PublishReply(mock.Anything, "r1", "Thanks!").Return(reply, nil)
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, []*review.Review{stored}).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "  Thanks!\n",
+		})
+
+		s.Require().NoError(err)
+		s.Equal(reply, result.Reply)
+	})
+
+	s.Run("should keep the previous reply in the history when updating", func() {
+		previous := &review.Reply{ID: "resp1", Content: "Thanks", State: review.ReplyPublished, UpdatedAt: publishedAt}
+		stored := &review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect, Reply: previous}
+		s.stored(stored)
+		s.mockResponder.EXPECT().PublishReply(mock.Anything, "r1", "Thanks a lot!").
+			Return(&review.Reply{ID: "resp2", Content: "Thanks a lot!", State: review.ReplyPending, UpdatedAt: publishedAt.Add(time.Hour)}, nil)
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, []*review.Review{stored}).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionUpdate, Content: "Thanks a lot!",
+		})
+
+		s.Require().NoError(err)
+		s.Equal("Thanks a lot!", result.Reply.Content)
+		s.Equal([]review.ReplyRevision{previous.Revision()}, result.Reply.History)
+	})
+
+	s.Run("should delete the reply in the store and mark it deleted", func() {
+		previous := &review.Reply{ID: "resp1", Content: "Thanks", State: review.ReplyPublished, UpdatedAt: publishedAt}
+		stored := &review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect, Reply: previous}
+		s.stored(stored)
+		s.mockResponder.EXPECT().DeleteReply(mock.Anything, "resp1").Return(nil)
+		s.mockReviewRepo.EXPECT().Save(mock.Anything, []*review.Review{stored}).Return(nil)
+
+		result, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionDelete,
+		})
+
+		s.Require().NoError(err)
+		s.Equal(review.ReplyDeleted, result.Reply.State)
+		s.Equal("resp1", result.Reply.ID)
+		s.False(result.HasReply())
+		s.Len(result.Reply.History, 1)
+	})
+
+	s.Run("should reject a second reply", func() {
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect,
+			Reply: &review.Reply{Content: "Thanks", State: review.ReplyPublished}})
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Hi",
+		})
+
+		s.ErrorIs(err, replyreview.ErrReplyExists)
+	})
+
+	s.Run("should reject updating a review without a reply", func() {
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect})
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionUpdate, Content: "Hi",
+		})
+
+		s.ErrorIs(err, replyreview.ErrNoReply)
+	})
+
+	s.Run("should reject reviews from sources without a responder", func() {
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStore})
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Hi",
+		})
+
+		s.ErrorIs(err, replyreview.ErrNotSupported)
+	})
+
+	s.Run("should return not found for unknown reviews", func() {
+		s.stored()
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Hi",
+		})
+
+		s.ErrorIs(err, review.ErrNotFound)
+	})
+
+	s.Run("should require reply content", func() {
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: " ",
+		})
+
+		s.ErrorIs(err, replyreview.ErrEmptyReply)
+	})
+
+	s.Run("should not store the reply when the store rejects it", func() {
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect})
+		s.mockResponder.EXPECT().PublishReply(mock.Anything, "r1", "Hi").Return(nil, errors.New("status 409"))
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Hi",
+		})
+
+		s.EqualError(err, "failed to publish reply: status 409")
+		s.Equal(fault.KindUnavailable, fault.KindOf(err))
+	})
+
+	s.Run("should keep the kind the store gave a rejected reply", func() {
+		s.stored(&review.Review{ID: "r1", AppID: "app1", Source: review.SourceAppStoreConnect})
+		s.mockResponder.EXPECT().PublishReply(mock.Anything, "r1", "Hi").Return(nil, fault.Wrap(fault.KindValidation, errors.New("status 422")))
+
+		_, err := s.useCase.Execute(context.Background(), replyreview.Request{
+			AppID: "app1", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Hi",
+		})
+
+		s.EqualError(err, "failed to publish reply: status 422")
+		s.Equal(fault.KindValidation, fault.KindOf(err))
+	})
+}
+
+func TestReplyReviewUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplyReviewUseCaseTestSuite))
+}
@@ -0,0 +1,85 @@
+package replytemplates
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/replytemplate"
+)
+
+type UseCase interface {
+	List(ctx context.Context) ([]*replytemplate.Template, error)
+	Get(ctx context.Context, id string) (*replytemplate.Template, error)
+	Create(ctx context.Context, name, content string) (*replytemplate.Template, error)
+	Update(ctx context.Context, id, name, content string) (*replytemplate.Template, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type useCase struct {
+	templateRepo replytemplate.Repository
+	now          func() time.Time
+}
+
+func NewUseCase(templateRepo replytemplate.Repository) *useCase {
+	return &useCase{templateRepo: templateRepo, now: time.Now}
+}
+
+func (u *useCase) List(ctx context.Context) ([]*replytemplate.Template, error) {
+	return u.templateRepo.FindAll(ctx)
+}
+
+func (u *useCase) Get(ctx context.Context, id string) (*replytemplate.Template, error) {
+	return u.templateRepo.FindByID(ctx, id)
+}
+
+func (u *useCase) Create(ctx context.Context, name, content string) (*replytemplate.Template, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := replytemplate.NewTemplate(id, name, content, u.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.templateRepo.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save reply template: %w", err)
+	}
+	return template, nil
+}
+
+func (u *useCase) Update(ctx context.Context, id, name, content string) (*replytemplate.Template, error) {
+	if err := replytemplate.Validate(name, content); err != nil {
+		return nil, err
+	}
+
+	template, err := u.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	template.Name = strings.TrimSpace(name)
+	template.Content = content
+	template.UpdatedAt = u.now()
+
+	if err := u.templateRepo.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save reply template: %w", err)
+	}
+	return template, nil
+}
+
+func (u *useCase) Delete(ctx context.Context, id string) error {
+	return u.templateRepo.Delete(ctx, id)
+}
+
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate reply template ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,113 @@
+package replytemplates_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/replytemplates"
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	replytemplatemocks "appstorereviewsviewer/mocks/domain/replytemplate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplyTemplatesUseCaseTestSuite struct {
+	suite.Suite
+	mockTemplateRepo *replytemplatemocks.Repository
+	useCase          replytemplates.UseCase
+}
+
+func (s *ReplyTemplatesUseCaseTestSuite) SetupSubTest() {
+	s.mockTemplateRepo = replytemplatemocks.NewRepository(s.T())
+	s.useCase = replytemplates.NewUseCase(s.mockTemplateRepo)
+}
+
+func (s *ReplyTemplatesUseCaseTestSuite) TestCreate() {
+	s.Run("should save a new template with a generated ID", func() {
+		var saved *replytemplate.Template
+		s.mockTemplateRepo.EXPECT().Save(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, template *replytemplate.Template) error {
+				saved = template
+				return nil
+			})
+
+		template, err := s.useCase.Create(context.Background(), " Thanks ", "Thanks {{author}}!")
+
+		s.Require().NoError(err)
+		s.Same(saved, template)
+		s.Len(template.ID, 16)
+		s.Equal("Thanks", template.Name)
+		s.Equal("Thanks {{author}}!", template.Content)
+		s.False(template.CreatedAt.IsZero())
+		s.Equal(template.CreatedAt, template.UpdatedAt)
+	})
+
+	s.Run("should reject templates that do not parse", func() {
+		_, err := s.useCase.Create(context.Background(), "Broken", "Thanks {{author")
+
+		s.ErrorIs(err, replytemplate.ErrInvalid)
+	})
+
+	s.Run("should reject templates without a name", func() {
+		_, err := s.useCase.Create(context.Background(), " ", "Thanks")
+
+		s.ErrorIs(err, replytemplate.ErrInvalid)
+	})
+
+	s.Run("should reject unknown placeholders", func() {
+		_, err := s.useCase.Create(context.Background(), "Unknown", "Thanks {{nickname}}")
+
+		s.ErrorIs(err, replytemplate.ErrInvalid)
+	})
+
+	s.Run("should return error when saving fails", func() {
+		s.mockTemplateRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := s.useCase.Create(context.Background(), "Thanks", "Thanks")
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func (s *ReplyTemplatesUseCaseTestSuite) TestUpdate() {
+	s.Run("should replace the name and content of a template", func() {
+		stored := &replytemplate.Template{ID: "t1", Name: "Thanks", Content: "Thanks"}
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "t1").Return(stored, nil)
+		s.mockTemplateRepo.EXPECT().Save(mock.Anything, stored).Return(nil)
+
+		template, err := s.useCase.Update(context.Background(), "t1", "Thank you", "Thank you {{author}}")
+
+		s.Require().NoError(err)
+		s.Equal("Thank you", template.Name)
+		s.Equal("Thank you {{author}}", template.Content)
+		s.False(template.UpdatedAt.IsZero())
+	})
+
+	s.Run("should return not found for unknown templates", func() {
+		s.mockTemplateRepo.EXPECT().FindByID(mock.Anything, "missing").Return(nil, replytemplate.ErrNotFound)
+
+		_, err := s.useCase.Update(context.Background(), "missing", "Thanks", "Thanks")
+
+		s.ErrorIs(err, replytemplate.ErrNotFound)
+	})
+
+	s.Run("should validate before looking the template up", func() {
+		_, err := s.useCase.Update(context.Background(), "t1", "Thanks", "")
+
+		s.ErrorIs(err, replytemplate.ErrInvalid)
+	})
+}
+
+func (s *ReplyTemplatesUseCaseTestSuite) TestDelete() {
+	s.Run("should delete the template", func() {
+		s.mockTemplateRepo.EXPECT().Delete(mock.Anything, "t1").Return(nil)
+
+		s.NoError(s.useCase.Delete(context.Background(), "t1"))
+	})
+}
+
+func TestReplyTemplatesUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplyTemplatesUseCaseTestSuite))
+}
@@ -0,0 +1,78 @@
+package reviewstats
+
+import (
+	"context"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type AppStats struct {
+	AppID          string      `json:"appId"`
+	Total          int         `json:"total"`
+	Recent         int         `json:"recent"`
+	AverageScore   float64     `json:"averageScore"`
+	ScoreCounts    map[int]int `json:"scoreCounts"`
+	LatestReviewAt *time.Time  `json:"latestReviewAt"`
+}
+
+type UseCase interface {
+	Execute(ctx context.Context) ([]*AppStats, error)
+}
+
+type useCase struct {
+	appRepo    app.Repository
+	reviewRepo review.Repository
+	now        func() time.Time
+}
+
+func NewUseCase(appRepo app.Repository, reviewRepo review.Repository) *useCase {
+	return &useCase{
+		appRepo:    appRepo,
+		reviewRepo: reviewRepo,
+		now:        time.Now,
+	}
+}
+
+// Execute summarises the stored reviews of every tracked app. Reviews are
+// streamed so large histories do not need to fit in memory.
+func (s *useCase) Execute(ctx context.Context) ([]*AppStats, error) {
+	apps, err := s.appRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recentSince := s.now().Add(-time.Duration(review.RecentReviewHourThreshold) * time.Hour)
+
+	allStats := make([]*AppStats, 0, len(apps))
+	for _, app := range apps {
+		stats := &AppStats{AppID: app.ID, ScoreCounts: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}}
+		scoreSum := 0
+
+		err := s.reviewRepo.StreamByAppIDBetween(ctx, app.ID, time.Time{}, time.Time{}, func(review *review.Review) error {
+			stats.Total++
+			scoreSum += review.Score
+			stats.ScoreCounts[review.Score]++
+			if !review.SubmittedAt.Before(recentSince) {
+				stats.Recent++
+			}
+			if stats.LatestReviewAt == nil || review.SubmittedAt.After(*stats.LatestReviewAt) {
+				submittedAt := review.SubmittedAt
+				stats.LatestReviewAt = &submittedAt
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if stats.Total > 0 {
+			stats.AverageScore = float64(scoreSum) / float64(stats.Total)
+		}
+
+		allStats = append(allStats, stats)
+	}
+
+	return allStats, nil
+}
@@ -0,0 +1,96 @@
+package reviewstats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/reviewstats"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewStatsUseCaseTestSuite struct {
+	suite.Suite
+	mockAppRepo    *appmocks.Repository
+	mockReviewRepo *reviewmocks.Repository
+	useCase        reviewstats.UseCase
+}
+
+func (s *ReviewStatsUseCaseTestSuite) SetupSubTest() {
+	s.mockAppRepo = appmocks.NewRepository(s.T())
+	s.mockReviewRepo = reviewmocks.NewRepository(s.T())
+	s.useCase = reviewstats.NewUseCase(s.mockAppRepo, s.mockReviewRepo)
+}
+
+func (s *ReviewStatsUseCaseTestSuite) expectStream(appID string, reviews ...*review.Review) {
+	s.mockReviewRepo.EXPECT().
+		StreamByAppIDBetween(mock.Anything, appID, time.Time{}, time.Time{}, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _, _ time.Time, fn func(*review.Review) error) error {
+			for _, review := range reviews {
+				if err := fn(review); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (s *ReviewStatsUseCaseTestSuite) TestExecute() {
+	s.Run("should summarise stored reviews per app", func() {
+		now := time.Now()
+		latest := now.Add(-1 * time.Hour)
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}, {ID: "app2"}}, nil)
+		s.expectStream("app1",
+			&review.Review{ID: "r1", Score: 5, SubmittedAt: now.Add(-100 * time.Hour)},
+			&review.Review{ID: "r2", Score: 2, SubmittedAt: latest},
+			&review.Review{ID: "r3", Score: 5, SubmittedAt: now.Add(-2 * time.Hour)},
+		)
+		s.expectStream("app2")
+
+		stats, err := s.useCase.Execute(context.Background())
+
+		s.Require().NoError(err)
+		s.Require().Len(stats, 2)
+		s.Equal("app1", stats[0].AppID)
+		s.Equal(3, stats[0].Total)
+		s.Equal(2, stats[0].Recent)
+		s.Equal(4.0, stats[0].AverageScore)
+		s.Equal(map[int]int{1: 0, 2: 1, 3: 0, 4: 0, 5: 2}, stats[0].ScoreCounts)
+		s.Require().NotNil(stats[0].LatestReviewAt)
+		s.Equal(latest, *stats[0].LatestReviewAt)
+
+		s.Equal(0, stats[1].Total)
+		s.Equal(0.0, stats[1].AverageScore)
+		s.Nil(stats[1].LatestReviewAt)
+	})
+
+	s.Run("should return error when app repository fails", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return(nil, assert.AnError)
+
+		stats, err := s.useCase.Execute(context.Background())
+
+		s.Error(err)
+		s.Nil(stats)
+	})
+
+	s.Run("should return error when review repository fails", func() {
+		s.mockAppRepo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "app1"}}, nil)
+		s.mockReviewRepo.EXPECT().StreamByAppIDBetween(mock.Anything, "app1", time.Time{}, time.Time{}, mock.Anything).Return(assert.AnError)
+
+		stats, err := s.useCase.Execute(context.Background())
+
+		s.ErrorIs(err, assert.AnError)
+		s.Nil(stats)
+	})
+}
+
+func TestReviewStatsUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReviewStatsUseCaseTestSuite))
+}
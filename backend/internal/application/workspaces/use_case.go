@@ -0,0 +1,61 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+type UseCase interface {
+	List(ctx context.Context) ([]*workspace.Workspace, error)
+	// Get returns the workspace with the ID, or ErrNotFound.
+	Get(ctx context.Context, id string) (*workspace.Workspace, error)
+	Create(ctx context.Context, id, name string) (*workspace.Workspace, error)
+}
+
+type useCase struct {
+	workspaceRepo workspace.Repository
+}
+
+func NewUseCase(workspaceRepo workspace.Repository) *useCase {
+	return &useCase{workspaceRepo: workspaceRepo}
+}
+
+func (u *useCase) List(ctx context.Context) ([]*workspace.Workspace, error) {
+	return u.workspaceRepo.FindAll(ctx)
+}
+
+func (u *useCase) Get(ctx context.Context, id string) (*workspace.Workspace, error) {
+	workspaces, err := u.workspaceRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workspaces {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", workspace.ErrNotFound, id)
+}
+
+func (u *useCase) Create(ctx context.Context, id, name string) (*workspace.Workspace, error) {
+	w, err := workspace.NewWorkspace(id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.Get(ctx, id)
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s", workspace.ErrExists, id)
+	}
+	if !errors.Is(err, workspace.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := u.workspaceRepo.Save(ctx, w); err != nil {
+		return nil, fmt.Errorf("failed to save workspace: %w", err)
+	}
+	return w, nil
+}
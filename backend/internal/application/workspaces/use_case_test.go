@@ -0,0 +1,83 @@
+package workspaces_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/workspaces"
+	"appstorereviewsviewer/internal/domain/workspace"
+	workspacemocks "appstorereviewsviewer/mocks/domain/workspace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspacesUseCaseTestSuite struct {
+	suite.Suite
+	mockWorkspaceRepo *workspacemocks.Repository
+	useCase           workspaces.UseCase
+}
+
+func (s *WorkspacesUseCaseTestSuite) SetupSubTest() {
+	s.mockWorkspaceRepo = workspacemocks.NewRepository(s.T())
+	s.useCase = workspaces.NewUseCase(s.mockWorkspaceRepo)
+}
+
+func (s *WorkspacesUseCaseTestSuite) TestGet() {
+	s.Run("should return the workspace with the ID", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}, {ID: "team-a", Name: "Team A"}}, nil)
+
+		w, err := s.useCase.Get(context.Background(), "team-a")
+
+		s.Require().NoError(err)
+		s.Equal("Team A", w.Name)
+	})
+
+	s.Run("should return not found for unknown workspaces", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+
+		_, err := s.useCase.Get(context.Background(), "team-a")
+
+		s.ErrorIs(err, workspace.ErrNotFound)
+	})
+}
+
+func (s *WorkspacesUseCaseTestSuite) TestCreate() {
+	s.Run("should save a new workspace named after its ID by default", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+		s.mockWorkspaceRepo.EXPECT().Save(mock.Anything, &workspace.Workspace{ID: "team-a", Name: "team-a"}).Return(nil)
+
+		w, err := s.useCase.Create(context.Background(), "team-a", " ")
+
+		s.Require().NoError(err)
+		s.Equal("team-a", w.ID)
+	})
+
+	s.Run("should reject IDs that are not usable in paths", func() {
+		_, err := s.useCase.Create(context.Background(), "Team A", "")
+
+		s.ErrorIs(err, workspace.ErrInvalidID)
+	})
+
+	s.Run("should reject existing workspaces", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+
+		_, err := s.useCase.Create(context.Background(), "default", "")
+
+		s.ErrorIs(err, workspace.ErrExists)
+	})
+
+	s.Run("should return error when saving fails", func() {
+		s.mockWorkspaceRepo.EXPECT().FindAll(mock.Anything).Return(nil, nil)
+		s.mockWorkspaceRepo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := s.useCase.Create(context.Background(), "team-a", "Team A")
+
+		s.ErrorIs(err, assert.AnError)
+	})
+}
+
+func TestWorkspacesUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkspacesUseCaseTestSuite))
+}
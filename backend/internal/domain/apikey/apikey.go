@@ -0,0 +1,45 @@
+package apikey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/user"
+)
+
+var ErrNotFound = fault.New(fault.KindNotFound, "API key not found")
+
+// AllWorkspaces, as the only workspace of a key, lets it access every
+// workspace.
+const AllWorkspaces = "*"
+
+// Key is an API key. Only the hash of the secret is stored; the secret
+// itself is shown once when the key is created.
+type Key struct {
+	ID        string
+	Name      string
+	Role      user.Role
+	Hash      string
+	CreatedAt time.Time
+	// Workspaces limits the key to these workspaces, or is AllWorkspaces
+	// alone for every workspace. A key without workspaces may access none.
+	Workspaces []string
+}
+
+// UserWorkspaces returns the key's workspaces as user.User expects them: nil
+// for every workspace and an empty list for none.
+func (k *Key) UserWorkspaces() []string {
+	if len(k.Workspaces) == 1 && k.Workspaces[0] == AllWorkspaces {
+		return nil
+	}
+	return append([]string{}, k.Workspaces...)
+}
+
+// Hash returns the stored form of an API key secret. Secrets are random
+// and long, so a plain SHA-256 is enough to keep them from being recovered.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
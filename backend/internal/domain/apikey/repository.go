@@ -0,0 +1,10 @@
+package apikey
+
+import "context"
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Key, error)
+	FindByHash(ctx context.Context, hash string) (*Key, error)
+	Save(ctx context.Context, key *Key) error
+	Delete(ctx context.Context, id string) error
+}
@@ -1,15 +1,90 @@
 package app
 
-import "errors"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+var (
+	ErrNotFound          = fault.New(fault.KindNotFound, "app not found")
+	ErrInvalidExternalID = fault.New(fault.KindValidation, "invalid external id")
+	ErrInvalidID         = fault.New(fault.KindValidation, "invalid app id")
+)
+
+// idPattern keeps app IDs usable as URL path segments and file names inside
+// a workspace's data directory.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// packageNamePattern matches Android package names such as com.example.app.
+var packageNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
 
 type App struct {
 	ID string
+	// Name is the app's display name, optional.
+	Name string
+	// Sources lists where the app's reviews are read from. An app without
+	// sources is read from the App Store RSS feed under its own ID.
+	Sources []Source
 }
 
-func NewApp(id string) (*App, error) {
-	if id == "" {
-		return nil, errors.New("id is required")
+// Source names a registered review source and the app's ID in it: the
+// numeric App Store ID for the App Store, the package name for Google Play.
+type Source struct {
+	Name       string
+	ExternalID string
+}
+
+func NewApp(id string, sources ...Source) (*App, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[Source]bool, len(sources))
+	for i := range sources {
+		if sources[i].Name == "" {
+			return nil, fault.New(fault.KindValidation, "source name is required")
+		}
+		if sources[i].ExternalID == "" {
+			sources[i].ExternalID = id
+		}
+		if sources[i].Name == review.SourceGooglePlay && !packageNamePattern.MatchString(sources[i].ExternalID) {
+			return nil, fmt.Errorf("%w: %q is not a Google Play package name", ErrInvalidExternalID, sources[i].ExternalID)
+		}
+		if seen[sources[i]] {
+			return nil, fault.New(fault.KindValidation, fmt.Sprintf("duplicate source %s:%s", sources[i].Name, sources[i].ExternalID))
+		}
+		seen[sources[i]] = true
 	}
 
-	return &App{ID: id}, nil
+	return &App{ID: id, Sources: sources}, nil
+}
+
+// ValidateID reports whether id can name an app. IDs are limited to letters,
+// digits, dots, dashes and underscores and must not contain "..", so they
+// never leave the directory their reviews are stored in.
+func ValidateID(id string) error {
+	if !idPattern.MatchString(id) || strings.Contains(id, "..") {
+		return fmt.Errorf("%w %q, expected letters, digits, dots, dashes and underscores", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// DisplayName returns the app's name, or its ID when it has none.
+func (a *App) DisplayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.ID
+}
+
+// ReviewSources returns the app's sources, defaulting to the App Store feed.
+func (a *App) ReviewSources() []Source {
+	if len(a.Sources) == 0 {
+		return []Source{{Name: review.SourceAppStore, ExternalID: a.ID}}
+	}
+	return a.Sources
 }
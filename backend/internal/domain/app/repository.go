@@ -1,6 +1,27 @@
 package app
 
+import (
+	"context"
+	"fmt"
+)
+
 type Repository interface {
-	FindAll() ([]*App, error)
-	Save(app *App) error
+	FindAll(ctx context.Context) ([]*App, error)
+	Save(ctx context.Context, app *App) error
+	Delete(ctx context.Context, id string) error
+}
+
+// FindByID returns the tracked app with id, or ErrNotFound when there is
+// none.
+func FindByID(ctx context.Context, repo Repository, id string) (*App, error) {
+	apps, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read apps: %w", err)
+	}
+	for _, a := range apps {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
 }
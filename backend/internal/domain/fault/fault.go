@@ -0,0 +1,63 @@
+package fault
+
+import "errors"
+
+// Kind classifies domain errors by what went wrong rather than where, so
+// adapters such as the HTTP API can answer them consistently.
+type Kind string
+
+const (
+	// KindValidation means the input was rejected.
+	KindValidation Kind = "validation"
+	// KindNotFound means the addressed entity does not exist.
+	KindNotFound Kind = "not_found"
+	// KindConflict means the request clashes with the current state, such
+	// as creating something that already exists.
+	KindConflict Kind = "conflict"
+	// KindUnavailable means a dependency, such as a store API or the job
+	// queue, cannot serve the request right now; retrying later may work.
+	KindUnavailable Kind = "unavailable"
+)
+
+// Error is an error of a kind. Domain packages declare their sentinel errors
+// with New so that errors.Is keeps matching them.
+type Error struct {
+	kind    Kind
+	message string
+	cause   error
+}
+
+func New(kind Kind, message string) error {
+	return &Error{kind: kind, message: message}
+}
+
+// Wrap marks err as being of kind, keeping it matchable with errors.Is. It
+// returns nil when err is nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{kind: kind, message: err.Error(), cause: err}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// KindOf returns the kind of the first error of a kind in err's chain, or
+// the empty kind when there is none.
+func KindOf(err error) Kind {
+	var kinded *Error
+	if errors.As(err, &kinded) {
+		return kinded.kind
+	}
+	return ""
+}
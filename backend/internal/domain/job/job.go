@@ -0,0 +1,47 @@
+package job
+
+import (
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+var (
+	ErrNotFound  = fault.New(fault.KindNotFound, "job not found")
+	ErrQueueFull = fault.New(fault.KindUnavailable, "job queue is full")
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// KindBackfill fetches the reviews of an app that was just added.
+const KindBackfill = "backfill"
+
+// Job is a piece of work run in the background, such as the first fetch of a
+// new app's reviews.
+type Job struct {
+	ID        string
+	Kind      string
+	Workspace string
+	AppID     string
+	Status    Status
+	// NewReviews and UpdatedReviews count the reviews the job stored.
+	NewReviews     int
+	UpdatedReviews int
+	// Error says why the job failed.
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Done reports whether the job has finished, successfully or not.
+func (j *Job) Done() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
@@ -0,0 +1,9 @@
+package job
+
+import "context"
+
+type Repository interface {
+	// FindByID returns the job with the ID, or ErrNotFound.
+	FindByID(ctx context.Context, id string) (*Job, error)
+	Save(ctx context.Context, job *Job) error
+}
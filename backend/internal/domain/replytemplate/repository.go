@@ -0,0 +1,11 @@
+package replytemplate
+
+import "context"
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Template, error)
+	FindByID(ctx context.Context, id string) (*Template, error)
+	// Save creates the template or replaces the one with the same ID.
+	Save(ctx context.Context, template *Template) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,73 @@
+package replytemplate
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+var (
+	ErrNotFound = fault.New(fault.KindNotFound, "reply template not found")
+	ErrInvalid  = fault.New(fault.KindValidation, "invalid reply template")
+)
+
+// Template is a saved reply. Its content is a text/template rendered against
+// the review being answered, where {{author}}, {{appName}} and {{version}}
+// stand for the review's author, the app's display name and the app version
+// the review was written for; the review's fields are also available as
+// {{.Author}}, {{.Score}} and so on.
+type Template struct {
+	ID        string
+	Name      string
+	Content   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewTemplate(id, name, content string, now time.Time) (*Template, error) {
+	if err := Validate(name, content); err != nil {
+		return nil, err
+	}
+	return &Template{ID: id, Name: strings.TrimSpace(name), Content: content, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+// Validate checks that a template has a name and content that parses and
+// renders for an empty review.
+func Validate(name, content string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalid)
+	}
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("%w: content is required", ErrInvalid)
+	}
+	if _, err := (&Template{Content: content}).Render(&review.Review{}, ""); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Render fills the template in for a review of the app named appName.
+func (t *Template) Render(r *review.Review, appName string) (string, error) {
+	tmpl, err := parse(t.Content, r, appName)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, r); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return out.String(), nil
+}
+
+func parse(content string, r *review.Review, appName string) (*template.Template, error) {
+	return template.New("reply").Option("missingkey=error").Funcs(template.FuncMap{
+		"author":  func() string { return r.Author },
+		"appName": func() string { return appName },
+		"version": func() string { return r.Version },
+	}).Parse(content)
+}
@@ -0,0 +1,12 @@
+package review
+
+// FetchStats counts the requests made to a remote review source and how many
+// of them were answered by a conditional request without a new download.
+type FetchStats struct {
+	Requests        int64 `json:"requests"`
+	NotModified     int64 `json:"notModified"`
+	BytesDownloaded int64 `json:"bytesDownloaded"`
+	// BytesSaved is the size of the cached responses that did not have to be
+	// downloaded and parsed again.
+	BytesSaved int64 `json:"bytesSaved"`
+}
@@ -1,8 +1,43 @@
 package review
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
 
 type Repository interface {
-	FindByAppIDSince(appID string, since time.Time) ([]*Review, error)
-	Save(reviews ...*Review) error
+	FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*Review, error)
+	// StreamByAppIDBetween calls fn for every review submitted in [since, until)
+	// without loading them all at once. A zero until means no upper bound.
+	StreamByAppIDBetween(ctx context.Context, appID string, since, until time.Time, fn func(*Review) error) error
+	Save(ctx context.Context, reviews ...*Review) error
+	// Merge stores reviews fetched from their sources, merging each into the
+	// stored review with its ID by Review.Merge while no other write can
+	// change it. Only new and changed reviews are written, and how many of
+	// each is returned.
+	Merge(ctx context.Context, reviews ...*Review) (newReviews, updatedReviews int, err error)
+}
+
+var errFound = errors.New("review found")
+
+// FindByID streams the app's reviews until it finds the one with reviewID,
+// returning ErrNotFound when there is none.
+func FindByID(ctx context.Context, repo Repository, appID, reviewID string) (*Review, error) {
+	var found *Review
+	err := repo.StreamByAppIDBetween(ctx, appID, time.Time{}, time.Time{}, func(r *Review) error {
+		if r.ID != reviewID {
+			return nil
+		}
+		found = r
+		return errFound
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return nil, fmt.Errorf("failed to read reviews: %w", err)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, reviewID)
+	}
+	return found, nil
 }
@@ -0,0 +1,13 @@
+package review
+
+import "context"
+
+// Responder publishes developer replies to reviews in the store the reviews
+// were read from.
+type Responder interface {
+	// PublishReply creates the reply to a review, replacing any existing one,
+	// and returns it as recorded by the store.
+	PublishReply(ctx context.Context, reviewID, content string) (*Reply, error)
+	// DeleteReply removes a published reply by its store ID.
+	DeleteReply(ctx context.Context, replyID string) error
+}
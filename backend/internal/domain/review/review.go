@@ -1,6 +1,9 @@
 package review
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 const RecentReviewHourThreshold = 24 * 2
 
@@ -12,4 +15,96 @@ type Review struct {
 	Score       int
 	SubmittedAt time.Time
 	RetrievedAt time.Time
+	// Source is the name of the review source the review was read from.
+	Source string
+	// Version is the app version the review was written for, when the source
+	// reports it.
+	Version string
+	// Reply is the developer's public response, nil when there is none or
+	// the source does not report responses.
+	Reply *Reply
+}
+
+type ReplyState string
+
+const (
+	// ReplyPending is a reply the store accepted but has not published yet.
+	ReplyPending   ReplyState = "pending"
+	ReplyPublished ReplyState = "published"
+	ReplyDeleted   ReplyState = "deleted"
+)
+
+// Reply is a developer's response to a review as published in the store.
+type Reply struct {
+	// ID is the response's ID in the store, needed to delete it.
+	ID        string
+	Content   string
+	State     ReplyState
+	UpdatedAt time.Time
+	// History holds the earlier versions of the reply, oldest first.
+	History []ReplyRevision
+}
+
+// ReplyRevision is a past version of a reply.
+type ReplyRevision struct {
+	Content   string
+	State     ReplyState
+	UpdatedAt time.Time
+}
+
+// Equal reports whether both replies are absent or have the same content,
+// state and update time.
+func (r *Reply) Equal(other *Reply) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	return r.Content == other.Content && r.State == other.State && r.UpdatedAt.Equal(other.UpdatedAt)
+}
+
+// Revision returns the reply's current version.
+func (r *Reply) Revision() ReplyRevision {
+	return ReplyRevision{Content: r.Content, State: r.State, UpdatedAt: r.UpdatedAt}
+}
+
+// HasReply reports whether the review has a reply that was not deleted.
+func (r *Review) HasReply() bool {
+	return r.Reply != nil && r.Reply.State != ReplyDeleted
+}
+
+// Merge prepares r, as fetched from its source, to replace stored, the
+// review with its ID already stored, and reports whether that changes the
+// stored review. Sources without developer replies must not erase a stored
+// one, so r keeps the stored reply unless it brings one, and a changed reply
+// keeps the history of the stored one. A nil stored review is new.
+func (r *Review) Merge(stored *Review) bool {
+	if stored == nil {
+		return true
+	}
+
+	reply := r.Reply
+	r.Reply = stored.Reply
+	if reply != nil {
+		r.SetReply(reply)
+	}
+
+	return stored.Author != r.Author || stored.Content != r.Content ||
+		stored.Score != r.Score || !stored.SubmittedAt.Equal(r.SubmittedAt) ||
+		stored.Source != r.Source || stored.Version != r.Version ||
+		!stored.Reply.Equal(r.Reply)
+}
+
+// SetReply makes reply the review's current reply. A different current reply
+// moves to the history, and reply keeps the store ID of the current one when
+// it has none.
+func (r *Review) SetReply(reply *Reply) {
+	if current := r.Reply; current != nil {
+		if reply.ID == "" {
+			reply.ID = current.ID
+		}
+		reply.History = current.History
+		if !current.Equal(reply) {
+			reply.History = append(slices.Clip(current.History), current.Revision())
+		}
+	}
+	r.Reply = reply
 }
@@ -0,0 +1,67 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+// SourceAppStore names the App Store customer reviews RSS feed, the source of
+// apps that do not declare any.
+const SourceAppStore = "appstore"
+
+// SourceGooglePlay names the Google Play Developer API, where apps are
+// identified by their package name.
+const SourceGooglePlay = "googleplay"
+
+// SourceAppStoreConnect names the App Store Connect API, which unlike the
+// public feed returns the full review history and the developer's responses.
+// Apps are identified by the same App Store ID.
+const SourceAppStoreConnect = "appstoreconnect"
+
+var (
+	ErrUnknownSource = fault.New(fault.KindValidation, "unknown review source")
+	ErrNotFound      = fault.New(fault.KindNotFound, "review not found")
+)
+
+// Source is a place reviews are read from, such as a store's public feed or
+// API. appID is the app's identifier in that source.
+type Source interface {
+	FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*Review, error)
+}
+
+// SourceRegistry maps source names to their implementations.
+type SourceRegistry struct {
+	sources map[string]Source
+}
+
+func NewSourceRegistry() *SourceRegistry {
+	return &SourceRegistry{sources: make(map[string]Source)}
+}
+
+// Register adds source under name, replacing any source of the same name.
+func (r *SourceRegistry) Register(name string, source Source) {
+	r.sources[name] = source
+}
+
+// Get returns the source registered under name.
+func (r *SourceRegistry) Get(name string) (Source, error) {
+	source, ok := r.sources[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSource, name)
+	}
+	return source, nil
+}
+
+// Names returns the registered source names in alphabetical order.
+func (r *SourceRegistry) Names() []string {
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
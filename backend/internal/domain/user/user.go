@@ -0,0 +1,76 @@
+package user
+
+import (
+	"fmt"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+var ErrInvalidRole = fault.New(fault.KindValidation, "invalid role")
+
+// Role grants access to the API. Each role includes the permissions of the
+// roles before it: viewers read reviews, triagers also reply to them and
+// manage reply templates, and admins also manage apps, imports and API keys.
+type Role string
+
+const (
+	RoleViewer  Role = "viewer"
+	RoleTriager Role = "triager"
+	RoleAdmin   Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:  1,
+	RoleTriager: 2,
+	RoleAdmin:   3,
+}
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := roleRanks[role]; !ok {
+		return "", fmt.Errorf("%w %q, expected viewer, triager or admin", ErrInvalidRole, value)
+	}
+	return role, nil
+}
+
+// Allows reports whether the role includes the permissions of required.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// Highest returns the role with the most permissions, or "" when roles is
+// empty.
+func Highest(roles ...Role) Role {
+	var highest Role
+	for _, role := range roles {
+		if roleRanks[role] > roleRanks[highest] {
+			highest = role
+		}
+	}
+	return highest
+}
+
+// User is whoever a request was authenticated as, through an API key or a
+// token from the identity provider.
+type User struct {
+	ID   string
+	Name string
+	Role Role
+	// Workspaces lists the workspaces the user is a member of. Nil means
+	// every workspace.
+	Workspaces []string
+}
+
+// CanAccess reports whether the user is a member of the workspace.
+func (u *User) CanAccess(workspaceID string) bool {
+	if u.Workspaces == nil {
+		return true
+	}
+	for _, id := range u.Workspaces {
+		if id == workspaceID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package workspace
+
+import "context"
+
+type Repository interface {
+	// FindAll returns every workspace, always including the default one.
+	FindAll(ctx context.Context) ([]*Workspace, error)
+	Save(ctx context.Context, workspace *Workspace) error
+}
@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+// DefaultID is the workspace holding apps added before workspaces existed
+// and requests that do not name a workspace.
+const DefaultID = "default"
+
+var (
+	ErrNotFound  = fault.New(fault.KindNotFound, "workspace not found")
+	ErrExists    = fault.New(fault.KindConflict, "workspace already exists")
+	ErrInvalidID = fault.New(fault.KindValidation, "invalid workspace ID")
+)
+
+// idPattern keeps workspace IDs usable as URL path segments and directory
+// names.
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Workspace groups the apps, reviews and reply templates of one team. Data
+// of different workspaces is stored apart and users only reach the
+// workspaces they are members of.
+type Workspace struct {
+	ID   string
+	Name string
+}
+
+func NewWorkspace(id, name string) (*Workspace, error) {
+	if err := ValidateID(id); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = id
+	}
+	return &Workspace{ID: id, Name: name}, nil
+}
+
+func ValidateID(id string) error {
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("%w %q, expected lowercase letters, digits and dashes", ErrInvalidID, id)
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+// WithID returns a context addressing the workspace with the given ID.
+// Repositories partitioned by workspace read and write that workspace's data.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IDFromContext returns the workspace addressed by ctx, or DefaultID.
+func IDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultID
+}
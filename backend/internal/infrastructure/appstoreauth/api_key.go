@@ -0,0 +1,124 @@
+package appstoreauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const audience = "appstoreconnect-v1"
+
+// tokenLifetime is how long issued tokens are valid; App Store Connect
+// rejects tokens that live longer than 20 minutes.
+const tokenLifetime = 20 * time.Minute
+
+// refreshMargin renews tokens this long before they expire so a request
+// started with a token does not reach the API with an expired one.
+const refreshMargin = time.Minute
+
+// APIKey signs the ES256 JSON Web Tokens that authorize App Store Connect API
+// requests and reuses each token until shortly before it expires. It is safe
+// for concurrent use.
+type APIKey struct {
+	keyID    string
+	issuerID string
+	key      *ecdsa.PrivateKey
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// LoadAPIKey reads the .p8 private key downloaded from App Store Connect.
+func LoadAPIKey(path, keyID, issuerID string) (*APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read App Store Connect key: %w", err)
+	}
+	return NewAPIKey(data, keyID, issuerID)
+}
+
+// NewAPIKey parses a PEM encoded PKCS #8 P-256 private key.
+func NewAPIKey(p8 []byte, keyID, issuerID string) (*APIKey, error) {
+	if keyID == "" || issuerID == "" {
+		return nil, errors.New("key ID and issuer ID are required")
+	}
+
+	block, _ := pem.Decode(p8)
+	if block == nil {
+		return nil, errors.New("App Store Connect key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse App Store Connect key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok || key.Curve != elliptic.P256() {
+		return nil, errors.New("App Store Connect key is not a P-256 key")
+	}
+
+	return &APIKey{keyID: keyID, issuerID: issuerID, key: key, now: time.Now}, nil
+}
+
+// Token returns a signed token, issuing a new one when the cached token is
+// missing or about to expire.
+func (k *APIKey) Token(ctx context.Context) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if k.token != "" && now.Add(refreshMargin).Before(k.expires) {
+		return k.token, nil
+	}
+
+	token, err := k.sign(now)
+	if err != nil {
+		return "", err
+	}
+
+	k.token = token
+	k.expires = now.Add(tokenLifetime)
+	return k.token, nil
+}
+
+func (k *APIKey) sign(now time.Time) (string, error) {
+	headerJSON, err := json.Marshal(map[string]string{"alg": "ES256", "kid": k.keyID, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(map[string]any{
+		"iss": k.issuerID,
+		"iat": now.Unix(),
+		"exp": now.Add(tokenLifetime).Unix(),
+		"aud": audience,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	// JWS wants the fixed-size r || s form rather than ASN.1.
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,107 @@
+package appstoreauth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/appstoreauth"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyTestSuite struct {
+	suite.Suite
+	key *ecdsa.PrivateKey
+	p8  []byte
+}
+
+func (s *APIKeyTestSuite) SetupSuite() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	s.Require().NoError(err)
+	s.key = key
+	s.p8 = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func (s *APIKeyTestSuite) decodePart(part string, target any) {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	s.Require().NoError(err)
+	s.Require().NoError(json.Unmarshal(data, target))
+}
+
+func (s *APIKeyTestSuite) TestToken() {
+	s.Run("should sign an ES256 token for the key", func() {
+		apiKey, err := appstoreauth.NewAPIKey(s.p8, "KEY123", "issuer-1")
+		s.Require().NoError(err)
+
+		token, err := apiKey.Token(context.Background())
+		s.Require().NoError(err)
+
+		parts := strings.Split(token, ".")
+		s.Require().Len(parts, 3)
+
+		var header map[string]string
+		s.decodePart(parts[0], &header)
+		s.Equal(map[string]string{"alg": "ES256", "kid": "KEY123", "typ": "JWT"}, header)
+
+		var claims map[string]any
+		s.decodePart(parts[1], &claims)
+		s.Equal("issuer-1", claims["iss"])
+		s.Equal("appstoreconnect-v1", claims["aud"])
+		s.Equal(float64(20*60), claims["exp"].(float64)-claims["iat"].(float64))
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		s.Require().NoError(err)
+		s.Require().Len(signature, 64)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		r := new(big.Int).SetBytes(signature[:32])
+		sig := new(big.Int).SetBytes(signature[32:])
+		s.True(ecdsa.Verify(&s.key.PublicKey, digest[:], r, sig))
+	})
+
+	s.Run("should reuse the token until it nears expiry", func() {
+		apiKey, err := appstoreauth.NewAPIKey(s.p8, "KEY123", "issuer-1")
+		s.Require().NoError(err)
+
+		first, err := apiKey.Token(context.Background())
+		s.Require().NoError(err)
+		second, err := apiKey.Token(context.Background())
+		s.Require().NoError(err)
+
+		s.Equal(first, second)
+	})
+}
+
+func (s *APIKeyTestSuite) TestNewAPIKey() {
+	s.Run("should reject keys that are not P-256", func() {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		s.Require().NoError(err)
+		der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+		s.Require().NoError(err)
+
+		_, err = appstoreauth.NewAPIKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), "KEY123", "issuer-1")
+
+		s.EqualError(err, "App Store Connect key is not a P-256 key")
+	})
+
+	s.Run("should require the key and issuer IDs", func() {
+		_, err := appstoreauth.NewAPIKey(s.p8, "", "issuer-1")
+
+		s.EqualError(err, "key ID and issuer ID are required")
+	})
+}
+
+func TestAPIKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyTestSuite))
+}
@@ -0,0 +1,1000 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/user"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes every environment variable read by Load.
+const EnvPrefix = "APPSTOREREVIEWS_"
+
+// ConfigFileEnv names the environment variable holding the config file path
+// when the -config flag is not given.
+const ConfigFileEnv = EnvPrefix + "CONFIG"
+
+var territoryPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type Config struct {
+	DataDir string  `yaml:"dataDir"`
+	Server  Server  `yaml:"server"`
+	Reload  Reload  `yaml:"reload"`
+	ITunes  ITunes  `yaml:"itunes"`
+	Sources Sources `yaml:"sources"`
+	Auth    Auth    `yaml:"auth"`
+	CORS    CORS    `yaml:"cors"`
+}
+
+type Server struct {
+	Port int `yaml:"port"`
+	// BaseURL is the public URL of the API used for self links in feeds.
+	// When empty the links are derived from the incoming request.
+	BaseURL string `yaml:"baseURL"`
+	// ShutdownTimeout bounds how long in-flight requests and reloads may take
+	// to finish once the server is asked to stop.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// RateLimit is how many requests per second each API key, token user
+	// or, without one, remote address may make, in bursts of up to
+	// RateBurst. Zero disables rate limiting.
+	RateLimit float64 `yaml:"rateLimit"`
+	RateBurst int     `yaml:"rateBurst"`
+	// AddressRateLimit and AddressRateBurst limit each remote address
+	// before authentication, so that failed attempts are limited too. They
+	// should allow for several users sharing an address. Zero disables the
+	// limit.
+	AddressRateLimit float64 `yaml:"addressRateLimit"`
+	AddressRateBurst int     `yaml:"addressRateBurst"`
+	// MaxBodyBytes bounds request bodies; review imports are bounded by
+	// MaxImportBytes instead.
+	MaxBodyBytes      int           `yaml:"maxBodyBytes"`
+	MaxImportBytes    int           `yaml:"maxImportBytes"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	// WriteTimeout bounds a whole request including its response, so it
+	// must leave room for adding an app and large exports.
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	IdleTimeout  time.Duration `yaml:"idleTimeout"`
+}
+
+type Reload struct {
+	Interval time.Duration `yaml:"interval"`
+	// Timeout bounds a single reload run across all apps.
+	Timeout     time.Duration `yaml:"timeout"`
+	Concurrency int           `yaml:"concurrency"`
+	// BreakerThreshold is the number of consecutive failures after which an
+	// app is skipped for BreakerCooldown. The cooldown doubles each time a
+	// trial fetch fails, up to BreakerMaxCooldown.
+	BreakerThreshold   int           `yaml:"breakerThreshold"`
+	BreakerCooldown    time.Duration `yaml:"breakerCooldown"`
+	BreakerMaxCooldown time.Duration `yaml:"breakerMaxCooldown"`
+}
+
+type ITunes struct {
+	// BaseURL is the scheme and host of the App Store RSS feed, e.g. a
+	// mirror or a local stub.
+	BaseURL string        `yaml:"baseURL"`
+	Timeout time.Duration `yaml:"timeout"`
+	// RateLimit caps requests per second to the feed host across all
+	// concurrent fetches; Burst is how many may be sent at once.
+	RateLimit float64 `yaml:"rateLimit"`
+	Burst     int     `yaml:"burst"`
+	// MaxRetries is how often a failed request is retried, waiting a jittered
+	// exponential backoff between RetryBaseDelay and RetryMaxDelay.
+	MaxRetries     int           `yaml:"maxRetries"`
+	RetryBaseDelay time.Duration `yaml:"retryBaseDelay"`
+	RetryMaxDelay  time.Duration `yaml:"retryMaxDelay"`
+}
+
+type Sources struct {
+	// JSONURL enables the generic JSON review source. {id} in the URL stands
+	// for the app's ID in that source.
+	JSONURL     string        `yaml:"jsonURL"`
+	JSONTimeout time.Duration `yaml:"jsonTimeout"`
+	// GooglePlayCredentials is the path of a Google Cloud service account key
+	// with access to the Play Console; setting it enables the Google Play
+	// source.
+	GooglePlayCredentials string        `yaml:"googlePlayCredentials"`
+	GooglePlayTimeout     time.Duration `yaml:"googlePlayTimeout"`
+	// AppStoreConnectKeyFile is the .p8 API key downloaded from App Store
+	// Connect; setting it with the key and issuer IDs enables the App Store
+	// Connect source.
+	AppStoreConnectKeyFile  string `yaml:"appStoreConnectKeyFile"`
+	AppStoreConnectKeyID    string `yaml:"appStoreConnectKeyID"`
+	AppStoreConnectIssuerID string `yaml:"appStoreConnectIssuerID"`
+	// AppStoreConnectTerritories is a comma-separated list of ISO 3166-1
+	// alpha-3 territory codes to read reviews from; empty reads all.
+	AppStoreConnectTerritories string        `yaml:"appStoreConnectTerritories"`
+	AppStoreConnectTimeout     time.Duration `yaml:"appStoreConnectTimeout"`
+	// AppStoreConnectBaseURL replaces the public API, e.g. with a local stub
+	// while testing replies.
+	AppStoreConnectBaseURL string `yaml:"appStoreConnectBaseURL"`
+}
+
+type Auth struct {
+	// Enabled requires an API key on every request. Keys are created with
+	// "reviewsctl keys create" or by an admin through the API.
+	Enabled bool `yaml:"enabled"`
+	// OIDCIssuer enables tokens from an OpenID Connect provider besides API
+	// keys. Tokens must be issued for OIDCAudience.
+	OIDCIssuer   string `yaml:"oidcIssuer"`
+	OIDCAudience string `yaml:"oidcAudience"`
+	// OIDCJWKSURL overrides the key set URL discovered from the issuer.
+	OIDCJWKSURL string `yaml:"oidcJWKSURL"`
+	// OIDCRolesClaim names the claim holding the user's roles or groups,
+	// with dots reaching into nested objects.
+	OIDCRolesClaim string `yaml:"oidcRolesClaim"`
+	// OIDCRoleMapping maps claim values to roles as comma-separated
+	// value=role pairs, e.g. reviews-admins=admin,support=triager.
+	OIDCRoleMapping string `yaml:"oidcRoleMapping"`
+	// OIDCDefaultRole is given to users without a mapped role; when empty
+	// they are rejected.
+	OIDCDefaultRole string `yaml:"oidcDefaultRole"`
+	// OIDCWorkspacesClaim names the claim listing the workspaces a user is a
+	// member of. When empty, token users reach every workspace.
+	OIDCWorkspacesClaim string        `yaml:"oidcWorkspacesClaim"`
+	OIDCClockSkew       time.Duration `yaml:"oidcClockSkew"`
+}
+
+type CORS struct {
+	// AllowedOrigins is a comma-separated list of origins browsers may call
+	// the API from, e.g. https://reviews.example.com. An origin of the form
+	// https://*.example.com allows every subdomain, and * any origin. It is
+	// empty by default, so only pages served from the API's own origin may
+	// call it.
+	AllowedOrigins string `yaml:"allowedOrigins"`
+	// AllowCredentials lets browsers send cookies and Authorization headers
+	// with cross-origin requests; it cannot be combined with the * origin.
+	AllowCredentials bool   `yaml:"allowCredentials"`
+	AllowedMethods   string `yaml:"allowedMethods"`
+	AllowedHeaders   string `yaml:"allowedHeaders"`
+	// MaxAge is how long browsers may cache a preflight response. Zero
+	// leaves it to the browser's default.
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+// Origins returns the configured allowed origins.
+func (c CORS) Origins() []string {
+	return splitList(c.AllowedOrigins)
+}
+
+// Methods returns the configured allowed methods.
+func (c CORS) Methods() []string {
+	return splitList(c.AllowedMethods)
+}
+
+// Headers returns the configured allowed request headers.
+func (c CORS) Headers() []string {
+	return splitList(c.AllowedHeaders)
+}
+
+// RoleMapping returns the parsed OIDC role mapping.
+func (a Auth) RoleMapping() (map[string]user.Role, error) {
+	mapping := map[string]user.Role{}
+	for _, pair := range strings.Split(a.OIDCRoleMapping, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		value, roleName, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("invalid role mapping %q, expected value=role", pair)
+		}
+		role, err := user.ParseRole(strings.TrimSpace(roleName))
+		if err != nil {
+			return nil, err
+		}
+		mapping[strings.TrimSpace(value)] = role
+	}
+	return mapping, nil
+}
+
+// Territories returns the configured App Store Connect territories.
+func (s Sources) Territories() []string {
+	return splitList(s.AppStoreConnectTerritories)
+}
+
+// splitList splits a comma-separated setting, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func Default() *Config {
+	return &Config{
+		DataDir: "data",
+		Server: Server{
+			Port:              8080,
+			ShutdownTimeout:   15 * time.Second,
+			RateLimit:         10,
+			RateBurst:         20,
+			AddressRateLimit:  50,
+			AddressRateBurst:  100,
+			MaxBodyBytes:      1 << 20,
+			MaxImportBytes:    32 << 20,
+			ReadHeaderTimeout: 10 * time.Second,
+			WriteTimeout:      5 * time.Minute,
+			IdleTimeout:       2 * time.Minute,
+		},
+		Reload: Reload{
+			Interval:           time.Minute,
+			Timeout:            50 * time.Second,
+			Concurrency:        4,
+			BreakerThreshold:   3,
+			BreakerCooldown:    5 * time.Minute,
+			BreakerMaxCooldown: time.Hour,
+		},
+		ITunes: ITunes{
+			BaseURL:        "https://itunes.apple.com",
+			Timeout:        30 * time.Second,
+			RateLimit:      2,
+			Burst:          4,
+			MaxRetries:     3,
+			RetryBaseDelay: 500 * time.Millisecond,
+			RetryMaxDelay:  10 * time.Second,
+		},
+		Sources: Sources{
+			JSONTimeout:            30 * time.Second,
+			GooglePlayTimeout:      30 * time.Second,
+			AppStoreConnectTimeout: 30 * time.Second,
+		},
+		Auth: Auth{
+			OIDCRolesClaim: "roles",
+			OIDCClockSkew:  time.Minute,
+		},
+		CORS: CORS{
+			AllowedMethods: "GET, HEAD, POST, PUT, DELETE",
+			AllowedHeaders: "Content-Type, Authorization, X-Request-ID",
+			MaxAge:         10 * time.Minute,
+		},
+	}
+}
+
+// setting describes one configuration value and how it is named in config
+// files, environment variables and command-line flags.
+type setting struct {
+	key   string
+	flag  string
+	usage string
+	get   func(c *Config) string
+	set   func(c *Config, value string) error
+}
+
+func (s setting) env() string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(s.flag))
+}
+
+var settings = []setting{
+	{
+		key:   "dataDir",
+		flag:  "data-dir",
+		usage: "directory holding the app and review files",
+		get:   func(c *Config) string { return c.DataDir },
+		set:   func(c *Config, value string) error { c.DataDir = value; return nil },
+	},
+	{
+		key:   "server.port",
+		flag:  "port",
+		usage: "port the HTTP API listens on",
+		get:   func(c *Config) string { return strconv.Itoa(c.Server.Port) },
+		set:   func(c *Config, value string) error { return setInt(&c.Server.Port, value) },
+	},
+	{
+		key:   "server.baseURL",
+		flag:  "base-url",
+		usage: "public base URL of the API used for feed links, e.g. https://reviews.example.com",
+		get:   func(c *Config) string { return c.Server.BaseURL },
+		set:   func(c *Config, value string) error { c.Server.BaseURL = value; return nil },
+	},
+	{
+		key:   "server.shutdownTimeout",
+		flag:  "shutdown-timeout",
+		usage: "how long to wait for in-flight requests and reloads on shutdown",
+		get:   func(c *Config) string { return c.Server.ShutdownTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Server.ShutdownTimeout, value) },
+	},
+	{
+		key:   "server.rateLimit",
+		flag:  "rate-limit",
+		usage: "requests per second each API key, token user or address may make, 0 to disable",
+		get:   func(c *Config) string { return strconv.FormatFloat(c.Server.RateLimit, 'f', -1, 64) },
+		set:   func(c *Config, value string) error { return setFloat(&c.Server.RateLimit, value) },
+	},
+	{
+		key:   "server.rateBurst",
+		flag:  "rate-burst",
+		usage: "requests a client may make at once before the rate limit applies",
+		get:   func(c *Config) string { return strconv.Itoa(c.Server.RateBurst) },
+		set:   func(c *Config, value string) error { return setInt(&c.Server.RateBurst, value) },
+	},
+	{
+		key:   "server.addressRateLimit",
+		flag:  "address-rate-limit",
+		usage: "requests per second each remote address may make before authentication, 0 to disable",
+		get:   func(c *Config) string { return strconv.FormatFloat(c.Server.AddressRateLimit, 'f', -1, 64) },
+		set:   func(c *Config, value string) error { return setFloat(&c.Server.AddressRateLimit, value) },
+	},
+	{
+		key:   "server.addressRateBurst",
+		flag:  "address-rate-burst",
+		usage: "requests an address may make at once before the address rate limit applies",
+		get:   func(c *Config) string { return strconv.Itoa(c.Server.AddressRateBurst) },
+		set:   func(c *Config, value string) error { return setInt(&c.Server.AddressRateBurst, value) },
+	},
+	{
+		key:   "server.maxBodyBytes",
+		flag:  "max-body-bytes",
+		usage: "largest request body accepted, in bytes",
+		get:   func(c *Config) string { return strconv.Itoa(c.Server.MaxBodyBytes) },
+		set:   func(c *Config, value string) error { return setInt(&c.Server.MaxBodyBytes, value) },
+	},
+	{
+		key:   "server.maxImportBytes",
+		flag:  "max-import-bytes",
+		usage: "largest review import upload accepted, in bytes",
+		get:   func(c *Config) string { return strconv.Itoa(c.Server.MaxImportBytes) },
+		set:   func(c *Config, value string) error { return setInt(&c.Server.MaxImportBytes, value) },
+	},
+	{
+		key:   "server.readHeaderTimeout",
+		flag:  "read-header-timeout",
+		usage: "how long a client may take to send request headers",
+		get:   func(c *Config) string { return c.Server.ReadHeaderTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Server.ReadHeaderTimeout, value) },
+	},
+	{
+		key:   "server.writeTimeout",
+		flag:  "write-timeout",
+		usage: "how long a request may take from reading its headers to writing the response",
+		get:   func(c *Config) string { return c.Server.WriteTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Server.WriteTimeout, value) },
+	},
+	{
+		key:   "server.idleTimeout",
+		flag:  "idle-timeout",
+		usage: "how long idle keep-alive connections are kept open",
+		get:   func(c *Config) string { return c.Server.IdleTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Server.IdleTimeout, value) },
+	},
+	{
+		key:   "reload.interval",
+		flag:  "reload-interval",
+		usage: "how often reviews are fetched for tracked apps",
+		get:   func(c *Config) string { return c.Reload.Interval.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Reload.Interval, value) },
+	},
+	{
+		key:   "reload.timeout",
+		flag:  "reload-timeout",
+		usage: "deadline for fetching reviews of all tracked apps in one run",
+		get:   func(c *Config) string { return c.Reload.Timeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Reload.Timeout, value) },
+	},
+	{
+		key:   "reload.concurrency",
+		flag:  "reload-concurrency",
+		usage: "number of apps fetched at the same time",
+		get:   func(c *Config) string { return strconv.Itoa(c.Reload.Concurrency) },
+		set:   func(c *Config, value string) error { return setInt(&c.Reload.Concurrency, value) },
+	},
+	{
+		key:   "reload.breakerThreshold",
+		flag:  "reload-breaker-threshold",
+		usage: "consecutive failures after which an app is skipped",
+		get:   func(c *Config) string { return strconv.Itoa(c.Reload.BreakerThreshold) },
+		set:   func(c *Config, value string) error { return setInt(&c.Reload.BreakerThreshold, value) },
+	},
+	{
+		key:   "reload.breakerCooldown",
+		flag:  "reload-breaker-cooldown",
+		usage: "how long a failing app is skipped before it is tried again",
+		get:   func(c *Config) string { return c.Reload.BreakerCooldown.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Reload.BreakerCooldown, value) },
+	},
+	{
+		key:   "reload.breakerMaxCooldown",
+		flag:  "reload-breaker-max-cooldown",
+		usage: "upper bound for the cooldown of an app that keeps failing",
+		get:   func(c *Config) string { return c.Reload.BreakerMaxCooldown.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Reload.BreakerMaxCooldown, value) },
+	},
+	{
+		key:   "itunes.baseURL",
+		flag:  "itunes-base-url",
+		usage: "scheme and host of the App Store RSS feed",
+		get:   func(c *Config) string { return c.ITunes.BaseURL },
+		set:   func(c *Config, value string) error { c.ITunes.BaseURL = value; return nil },
+	},
+	{
+		key:   "itunes.timeout",
+		flag:  "itunes-timeout",
+		usage: "timeout for requests to the App Store RSS feed",
+		get:   func(c *Config) string { return c.ITunes.Timeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.ITunes.Timeout, value) },
+	},
+	{
+		key:   "itunes.rateLimit",
+		flag:  "itunes-rate-limit",
+		usage: "maximum requests per second to the App Store RSS feed",
+		get:   func(c *Config) string { return strconv.FormatFloat(c.ITunes.RateLimit, 'f', -1, 64) },
+		set:   func(c *Config, value string) error { return setFloat(&c.ITunes.RateLimit, value) },
+	},
+	{
+		key:   "itunes.burst",
+		flag:  "itunes-burst",
+		usage: "number of requests to the App Store RSS feed allowed at once",
+		get:   func(c *Config) string { return strconv.Itoa(c.ITunes.Burst) },
+		set:   func(c *Config, value string) error { return setInt(&c.ITunes.Burst, value) },
+	},
+	{
+		key:   "itunes.maxRetries",
+		flag:  "itunes-max-retries",
+		usage: "how often a failed request to the App Store RSS feed is retried",
+		get:   func(c *Config) string { return strconv.Itoa(c.ITunes.MaxRetries) },
+		set:   func(c *Config, value string) error { return setInt(&c.ITunes.MaxRetries, value) },
+	},
+	{
+		key:   "itunes.retryBaseDelay",
+		flag:  "itunes-retry-base-delay",
+		usage: "initial backoff before retrying a failed request",
+		get:   func(c *Config) string { return c.ITunes.RetryBaseDelay.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.ITunes.RetryBaseDelay, value) },
+	},
+	{
+		key:   "itunes.retryMaxDelay",
+		flag:  "itunes-retry-max-delay",
+		usage: "longest backoff between retries, including Retry-After from the feed",
+		get:   func(c *Config) string { return c.ITunes.RetryMaxDelay.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.ITunes.RetryMaxDelay, value) },
+	},
+	{
+		key:   "sources.jsonURL",
+		flag:  "json-source-url",
+		usage: "URL of the generic JSON review source, with {id} for the app's ID, e.g. https://reviews.example.com/apps/{id}",
+		get:   func(c *Config) string { return c.Sources.JSONURL },
+		set:   func(c *Config, value string) error { c.Sources.JSONURL = value; return nil },
+	},
+	{
+		key:   "sources.jsonTimeout",
+		flag:  "json-source-timeout",
+		usage: "timeout for requests to the generic JSON review source",
+		get:   func(c *Config) string { return c.Sources.JSONTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Sources.JSONTimeout, value) },
+	},
+	{
+		key:   "sources.googlePlayCredentials",
+		flag:  "google-play-credentials",
+		usage: "path of the service account key used to read Google Play reviews",
+		get:   func(c *Config) string { return c.Sources.GooglePlayCredentials },
+		set:   func(c *Config, value string) error { c.Sources.GooglePlayCredentials = value; return nil },
+	},
+	{
+		key:   "sources.googlePlayTimeout",
+		flag:  "google-play-timeout",
+		usage: "timeout for requests to the Google Play Developer API",
+		get:   func(c *Config) string { return c.Sources.GooglePlayTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Sources.GooglePlayTimeout, value) },
+	},
+	{
+		key:   "sources.appStoreConnectKeyFile",
+		flag:  "app-store-connect-key-file",
+		usage: "path of the .p8 App Store Connect API key used to read reviews and developer responses",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectKeyFile },
+		set:   func(c *Config, value string) error { c.Sources.AppStoreConnectKeyFile = value; return nil },
+	},
+	{
+		key:   "sources.appStoreConnectKeyID",
+		flag:  "app-store-connect-key-id",
+		usage: "ID of the App Store Connect API key",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectKeyID },
+		set:   func(c *Config, value string) error { c.Sources.AppStoreConnectKeyID = value; return nil },
+	},
+	{
+		key:   "sources.appStoreConnectIssuerID",
+		flag:  "app-store-connect-issuer-id",
+		usage: "issuer ID of the App Store Connect API key",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectIssuerID },
+		set:   func(c *Config, value string) error { c.Sources.AppStoreConnectIssuerID = value; return nil },
+	},
+	{
+		key:   "sources.appStoreConnectTerritories",
+		flag:  "app-store-connect-territories",
+		usage: "comma-separated territories to read App Store Connect reviews from, e.g. USA,GBR (default all)",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectTerritories },
+		set:   func(c *Config, value string) error { c.Sources.AppStoreConnectTerritories = value; return nil },
+	},
+	{
+		key:   "sources.appStoreConnectTimeout",
+		flag:  "app-store-connect-timeout",
+		usage: "timeout for requests to the App Store Connect API",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectTimeout.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Sources.AppStoreConnectTimeout, value) },
+	},
+	{
+		key:   "sources.appStoreConnectBaseURL",
+		flag:  "app-store-connect-base-url",
+		usage: "base URL of the App Store Connect API, e.g. a local stub (default https://api.appstoreconnect.apple.com)",
+		get:   func(c *Config) string { return c.Sources.AppStoreConnectBaseURL },
+		set:   func(c *Config, value string) error { c.Sources.AppStoreConnectBaseURL = value; return nil },
+	},
+	{
+		key:   "auth.enabled",
+		flag:  "auth-enabled",
+		usage: "require an API key on every request",
+		get:   func(c *Config) string { return strconv.FormatBool(c.Auth.Enabled) },
+		set:   func(c *Config, value string) error { return setBool(&c.Auth.Enabled, value) },
+	},
+	{
+		key:   "auth.oidcIssuer",
+		flag:  "oidc-issuer",
+		usage: "OpenID Connect issuer whose tokens are accepted besides API keys, e.g. https://login.example.com",
+		get:   func(c *Config) string { return c.Auth.OIDCIssuer },
+		set:   func(c *Config, value string) error { c.Auth.OIDCIssuer = value; return nil },
+	},
+	{
+		key:   "auth.oidcAudience",
+		flag:  "oidc-audience",
+		usage: "audience OpenID Connect tokens must be issued for",
+		get:   func(c *Config) string { return c.Auth.OIDCAudience },
+		set:   func(c *Config, value string) error { c.Auth.OIDCAudience = value; return nil },
+	},
+	{
+		key:   "auth.oidcJWKSURL",
+		flag:  "oidc-jwks-url",
+		usage: "URL of the issuer's signing keys (default discovered from the issuer)",
+		get:   func(c *Config) string { return c.Auth.OIDCJWKSURL },
+		set:   func(c *Config, value string) error { c.Auth.OIDCJWKSURL = value; return nil },
+	},
+	{
+		key:   "auth.oidcRolesClaim",
+		flag:  "oidc-roles-claim",
+		usage: "token claim holding the user's roles or groups, e.g. realm_access.roles",
+		get:   func(c *Config) string { return c.Auth.OIDCRolesClaim },
+		set:   func(c *Config, value string) error { c.Auth.OIDCRolesClaim = value; return nil },
+	},
+	{
+		key:   "auth.oidcRoleMapping",
+		flag:  "oidc-role-mapping",
+		usage: "comma-separated value=role pairs mapping claim values to roles, e.g. reviews-admins=admin",
+		get:   func(c *Config) string { return c.Auth.OIDCRoleMapping },
+		set:   func(c *Config, value string) error { c.Auth.OIDCRoleMapping = value; return nil },
+	},
+	{
+		key:   "auth.oidcDefaultRole",
+		flag:  "oidc-default-role",
+		usage: "role of token users without a mapped role (default rejected)",
+		get:   func(c *Config) string { return c.Auth.OIDCDefaultRole },
+		set:   func(c *Config, value string) error { c.Auth.OIDCDefaultRole = value; return nil },
+	},
+	{
+		key:   "auth.oidcWorkspacesClaim",
+		flag:  "oidc-workspaces-claim",
+		usage: "token claim listing the workspaces a user is a member of (default every workspace)",
+		get:   func(c *Config) string { return c.Auth.OIDCWorkspacesClaim },
+		set:   func(c *Config, value string) error { c.Auth.OIDCWorkspacesClaim = value; return nil },
+	},
+	{
+		key:   "auth.oidcClockSkew",
+		flag:  "oidc-clock-skew",
+		usage: "tolerance for clock differences when checking token expiry",
+		get:   func(c *Config) string { return c.Auth.OIDCClockSkew.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.Auth.OIDCClockSkew, value) },
+	},
+	{
+		key:   "cors.allowedOrigins",
+		flag:  "cors-allowed-origins",
+		usage: "comma-separated origins browsers may call the API from, * for any (default same origin only)",
+		get:   func(c *Config) string { return c.CORS.AllowedOrigins },
+		set:   func(c *Config, value string) error { c.CORS.AllowedOrigins = value; return nil },
+	},
+	{
+		key:   "cors.allowCredentials",
+		flag:  "cors-allow-credentials",
+		usage: "let browsers send credentials with cross-origin requests",
+		get:   func(c *Config) string { return strconv.FormatBool(c.CORS.AllowCredentials) },
+		set:   func(c *Config, value string) error { return setBool(&c.CORS.AllowCredentials, value) },
+	},
+	{
+		key:   "cors.allowedMethods",
+		flag:  "cors-allowed-methods",
+		usage: "comma-separated methods allowed in cross-origin requests",
+		get:   func(c *Config) string { return c.CORS.AllowedMethods },
+		set:   func(c *Config, value string) error { c.CORS.AllowedMethods = value; return nil },
+	},
+	{
+		key:   "cors.allowedHeaders",
+		flag:  "cors-allowed-headers",
+		usage: "comma-separated request headers allowed in cross-origin requests",
+		get:   func(c *Config) string { return c.CORS.AllowedHeaders },
+		set:   func(c *Config, value string) error { c.CORS.AllowedHeaders = value; return nil },
+	},
+	{
+		key:   "cors.maxAge",
+		flag:  "cors-max-age",
+		usage: "how long browsers may cache preflight responses",
+		get:   func(c *Config) string { return c.CORS.MaxAge.String() },
+		set:   func(c *Config, value string) error { return setDuration(&c.CORS.MaxAge, value) },
+	},
+}
+
+func setInt(target *int, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func setFloat(target *float64, value string) error {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func setBool(target *bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func setDuration(target *time.Duration, value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", value)
+	}
+	*target = parsed
+	return nil
+}
+
+// flagValue records a flag so it can be applied after the config file and
+// environment, while still rejecting malformed values during parsing.
+type flagValue struct {
+	setting setting
+	values  map[string]string
+}
+
+func (v *flagValue) String() string {
+	if v.values == nil {
+		return ""
+	}
+	return v.values[v.setting.key]
+}
+
+func (v *flagValue) Set(value string) error {
+	if err := v.setting.set(Default(), value); err != nil {
+		return err
+	}
+	v.values[v.setting.key] = value
+	return nil
+}
+
+// Load builds the configuration from defaults, an optional YAML or TOML file,
+// environment variables and command-line flags, each overriding the previous
+// one. printConfig reports whether --print-config was given.
+func Load(args []string, lookupEnv func(string) (string, bool), output io.Writer) (config *Config, printConfig bool, err error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(output)
+	configFile := flags.String("config", "", "path to a YAML or TOML config file (also "+ConfigFileEnv+")")
+	flags.BoolVar(&printConfig, "print-config", false, "print the effective configuration and exit")
+
+	flagValues := map[string]string{}
+	defaults := Default()
+	for _, setting := range settings {
+		usage := fmt.Sprintf("%s (default %s, env %s)", setting.usage, defaultText(setting.get(defaults)), setting.env())
+		flags.Var(&flagValue{setting: setting, values: flagValues}, setting.flag, usage)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, false, err
+	}
+	if flags.NArg() > 0 {
+		return nil, false, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	config = Default()
+
+	if *configFile == "" {
+		*configFile, _ = lookupEnv(ConfigFileEnv)
+	}
+	if *configFile != "" {
+		if err := loadFile(config, *configFile); err != nil {
+			return nil, false, err
+		}
+	}
+
+	for _, setting := range settings {
+		if value, ok := lookupEnv(setting.env()); ok {
+			if err := setting.set(config, value); err != nil {
+				return nil, false, fmt.Errorf("%s: %w", setting.env(), err)
+			}
+		}
+	}
+
+	for _, setting := range settings {
+		if value, ok := flagValues[setting.key]; ok {
+			if err := setting.set(config, value); err != nil {
+				return nil, false, fmt.Errorf("-%s: %w", setting.flag, err)
+			}
+		}
+	}
+
+	config.Server.BaseURL = strings.TrimRight(config.Server.BaseURL, "/")
+	config.ITunes.BaseURL = strings.TrimRight(config.ITunes.BaseURL, "/")
+
+	if err := config.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	return config, printConfig, nil
+}
+
+func defaultText(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func loadFile(config *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		values, err := parseTOML(file)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		if err := applyValues(config, values); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file %s, expected .yaml, .yml or .toml", path)
+	}
+
+	return nil
+}
+
+func applyValues(config *Config, values map[string]string) error {
+	known := map[string]setting{}
+	for _, setting := range settings {
+		known[setting.key] = setting
+	}
+
+	for key, value := range values {
+		setting, ok := known[key]
+		if !ok {
+			return fmt.Errorf("unknown key %q", key)
+		}
+		if err := setting.set(config, value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.DataDir == "" {
+		errs = append(errs, errors.New("dataDir must not be empty"))
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port must be between 1 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.BaseURL != "" && !isAbsoluteHTTPURL(c.Server.BaseURL) {
+		errs = append(errs, fmt.Errorf("server.baseURL must be an absolute http or https URL, got %q", c.Server.BaseURL))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.shutdownTimeout must be positive, got %s", c.Server.ShutdownTimeout))
+	}
+	if c.Server.RateLimit < 0 {
+		errs = append(errs, fmt.Errorf("server.rateLimit must not be negative, got %g", c.Server.RateLimit))
+	}
+	if c.Server.RateBurst < 1 {
+		errs = append(errs, fmt.Errorf("server.rateBurst must be at least 1, got %d", c.Server.RateBurst))
+	}
+	if c.Server.AddressRateLimit < 0 {
+		errs = append(errs, fmt.Errorf("server.addressRateLimit must not be negative, got %g", c.Server.AddressRateLimit))
+	}
+	if c.Server.AddressRateBurst < 1 {
+		errs = append(errs, fmt.Errorf("server.addressRateBurst must be at least 1, got %d", c.Server.AddressRateBurst))
+	}
+	if c.Server.MaxBodyBytes < 1 {
+		errs = append(errs, fmt.Errorf("server.maxBodyBytes must be positive, got %d", c.Server.MaxBodyBytes))
+	}
+	if c.Server.MaxImportBytes < 1 {
+		errs = append(errs, fmt.Errorf("server.maxImportBytes must be positive, got %d", c.Server.MaxImportBytes))
+	}
+	if c.Server.ReadHeaderTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.readHeaderTimeout must be positive, got %s", c.Server.ReadHeaderTimeout))
+	}
+	if c.Server.WriteTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.writeTimeout must be positive, got %s", c.Server.WriteTimeout))
+	}
+	if c.Server.IdleTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("server.idleTimeout must be positive, got %s", c.Server.IdleTimeout))
+	}
+	if c.Reload.Interval < time.Second {
+		errs = append(errs, fmt.Errorf("reload.interval must be at least 1s, got %s", c.Reload.Interval))
+	}
+	if c.Reload.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("reload.timeout must be positive, got %s", c.Reload.Timeout))
+	}
+	if c.Reload.Concurrency < 1 {
+		errs = append(errs, fmt.Errorf("reload.concurrency must be at least 1, got %d", c.Reload.Concurrency))
+	}
+	if c.Reload.BreakerThreshold < 1 {
+		errs = append(errs, fmt.Errorf("reload.breakerThreshold must be at least 1, got %d", c.Reload.BreakerThreshold))
+	}
+	if c.Reload.BreakerCooldown <= 0 {
+		errs = append(errs, fmt.Errorf("reload.breakerCooldown must be positive, got %s", c.Reload.BreakerCooldown))
+	}
+	if c.Reload.BreakerMaxCooldown < c.Reload.BreakerCooldown {
+		errs = append(
+			errs,
+			fmt.Errorf("reload.breakerMaxCooldown must not be shorter than reload.breakerCooldown, got %s", c.Reload.BreakerMaxCooldown),
+		)
+	}
+	if !isAbsoluteHTTPURL(c.ITunes.BaseURL) {
+		errs = append(errs, fmt.Errorf("itunes.baseURL must be an absolute http or https URL, got %q", c.ITunes.BaseURL))
+	}
+	if c.ITunes.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("itunes.timeout must be positive, got %s", c.ITunes.Timeout))
+	}
+	if c.ITunes.RateLimit <= 0 {
+		errs = append(errs, fmt.Errorf("itunes.rateLimit must be positive, got %g", c.ITunes.RateLimit))
+	}
+	if c.ITunes.Burst < 1 {
+		errs = append(errs, fmt.Errorf("itunes.burst must be at least 1, got %d", c.ITunes.Burst))
+	}
+	if c.ITunes.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("itunes.maxRetries must not be negative, got %d", c.ITunes.MaxRetries))
+	}
+	if c.ITunes.RetryBaseDelay < 0 {
+		errs = append(errs, fmt.Errorf("itunes.retryBaseDelay must not be negative, got %s", c.ITunes.RetryBaseDelay))
+	}
+	if c.ITunes.RetryMaxDelay < c.ITunes.RetryBaseDelay {
+		errs = append(
+			errs,
+			fmt.Errorf("itunes.retryMaxDelay must not be shorter than itunes.retryBaseDelay, got %s", c.ITunes.RetryMaxDelay),
+		)
+	}
+	if c.Sources.JSONTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("sources.jsonTimeout must be positive, got %s", c.Sources.JSONTimeout))
+	}
+	if c.Sources.GooglePlayTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("sources.googlePlayTimeout must be positive, got %s", c.Sources.GooglePlayTimeout))
+	}
+	if c.Sources.AppStoreConnectTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("sources.appStoreConnectTimeout must be positive, got %s", c.Sources.AppStoreConnectTimeout))
+	}
+	if c.Sources.AppStoreConnectKeyFile != "" && (c.Sources.AppStoreConnectKeyID == "" || c.Sources.AppStoreConnectIssuerID == "") {
+		errs = append(
+			errs,
+			errors.New("sources.appStoreConnectKeyID and sources.appStoreConnectIssuerID are required with sources.appStoreConnectKeyFile"),
+		)
+	}
+	if c.Sources.AppStoreConnectBaseURL != "" && !isAbsoluteHTTPURL(c.Sources.AppStoreConnectBaseURL) {
+		errs = append(
+			errs,
+			fmt.Errorf("sources.appStoreConnectBaseURL must be an absolute http or https URL, got %q", c.Sources.AppStoreConnectBaseURL),
+		)
+	}
+	for _, territory := range c.Sources.Territories() {
+		if !territoryPattern.MatchString(territory) {
+			errs = append(
+				errs,
+				fmt.Errorf("sources.appStoreConnectTerritories must list three-letter territory codes such as USA, got %q", territory),
+			)
+		}
+	}
+	if c.Auth.OIDCIssuer != "" {
+		issuer, err := url.Parse(c.Auth.OIDCIssuer)
+		if err != nil || (issuer.Scheme != "http" && issuer.Scheme != "https") || issuer.Host == "" {
+			errs = append(errs, fmt.Errorf("auth.oidcIssuer must be an absolute http or https URL, got %q", c.Auth.OIDCIssuer))
+		}
+		if c.Auth.OIDCAudience == "" {
+			errs = append(errs, errors.New("auth.oidcAudience is required with auth.oidcIssuer"))
+		}
+	}
+	if _, err := c.Auth.RoleMapping(); err != nil {
+		errs = append(errs, fmt.Errorf("auth.oidcRoleMapping: %w", err))
+	}
+	if c.Auth.OIDCDefaultRole != "" {
+		if _, err := user.ParseRole(c.Auth.OIDCDefaultRole); err != nil {
+			errs = append(errs, fmt.Errorf("auth.oidcDefaultRole: %w", err))
+		}
+	}
+	if c.Auth.OIDCClockSkew <= 0 {
+		errs = append(errs, fmt.Errorf("auth.oidcClockSkew must be positive, got %s", c.Auth.OIDCClockSkew))
+	}
+	for _, origin := range c.CORS.Origins() {
+		if !validOrigin(origin) {
+			errs = append(
+				errs,
+				fmt.Errorf(
+					"cors.allowedOrigins must list http or https origins without a path, *, "+
+						"or wildcard subdomains such as https://*.example.com, got %q",
+					origin,
+				),
+			)
+		}
+		if origin == "*" && c.CORS.AllowCredentials {
+			errs = append(errs, errors.New("cors.allowedOrigins must list origins instead of * with cors.allowCredentials"))
+		}
+	}
+	if len(c.CORS.Methods()) == 0 {
+		errs = append(errs, errors.New("cors.allowedMethods must not be empty"))
+	}
+	if c.CORS.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("cors.maxAge must not be negative, got %s", c.CORS.MaxAge))
+	}
+	if c.Sources.JSONURL != "" && (!isAbsoluteHTTPURL(c.Sources.JSONURL) || !strings.Contains(c.Sources.JSONURL, "{id}")) {
+		errs = append(errs, fmt.Errorf("sources.jsonURL must be an absolute http or https URL containing {id}, got %q", c.Sources.JSONURL))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// isAbsoluteHTTPURL reports whether value is an http or https URL with a
+// host.
+func isAbsoluteHTTPURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// validOrigin reports whether origin is *, a scheme and host with an
+// optional port, or such an origin whose host starts with a "*." wildcard.
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	parsed, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" &&
+		parsed.Path == "" && parsed.RawQuery == "" && parsed.Fragment == "" && parsed.User == nil
+}
+
+// Print writes the configuration as YAML so it can be used as a config file.
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,307 @@
+package config_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/infrastructure/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+	dir string
+	env map[string]string
+}
+
+func (s *ConfigTestSuite) SetupSubTest() {
+	s.dir = s.T().TempDir()
+	s.env = map[string]string{}
+}
+
+func (s *ConfigTestSuite) lookupEnv(key string) (string, bool) {
+	value, ok := s.env[key]
+	return value, ok
+}
+
+func (s *ConfigTestSuite) writeFile(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func (s *ConfigTestSuite) load(args ...string) (*config.Config, error) {
+	cfg, _, err := config.Load(args, s.lookupEnv, io.Discard)
+	return cfg, err
+}
+
+func (s *ConfigTestSuite) TestLoad() {
+	s.Run("should use defaults when nothing is configured", func() {
+		cfg, err := s.load()
+
+		s.Require().NoError(err)
+		s.Equal(config.Default(), cfg)
+	})
+
+	s.Run("should read a YAML config file", func() {
+		path := s.writeFile("config.yaml", `
+dataDir: /var/lib/reviews
+server:
+  port: 9090
+  baseURL: https://reviews.example.com/
+reload:
+  interval: 5m
+`)
+
+		cfg, err := s.load("-config", path)
+
+		s.Require().NoError(err)
+		s.Equal("/var/lib/reviews", cfg.DataDir)
+		s.Equal(9090, cfg.Server.Port)
+		s.Equal("https://reviews.example.com", cfg.Server.BaseURL)
+		s.Equal(5*time.Minute, cfg.Reload.Interval)
+		s.Equal(30*time.Second, cfg.ITunes.Timeout)
+	})
+
+	s.Run("should read a TOML config file named by the environment", func() {
+		s.env[config.ConfigFileEnv] = s.writeFile("config.toml", `
+# Reviews viewer
+dataDir = "/srv/reviews" # trailing comment
+
+[server]
+port = 9_091
+
+[itunes]
+timeout = '10s'
+`)
+
+		cfg, err := s.load()
+
+		s.Require().NoError(err)
+		s.Equal("/srv/reviews", cfg.DataDir)
+		s.Equal(9091, cfg.Server.Port)
+		s.Equal(10*time.Second, cfg.ITunes.Timeout)
+	})
+
+	s.Run("should read TOML arrays of strings as lists", func() {
+		path := s.writeFile("config.toml", `
+[cors]
+allowedOrigins = ["https://reviews.example.com", "https://*.example.org"]
+`)
+
+		cfg, err := s.load("-config", path)
+
+		s.Require().NoError(err)
+		s.Equal([]string{"https://reviews.example.com", "https://*.example.org"}, cfg.CORS.Origins())
+	})
+
+	s.Run("should reject malformed TOML and unsupported TOML values", func() {
+		malformedPath := s.writeFile("malformed.toml", "[server\nport = 9090\n")
+		datePath := s.writeFile("date.toml", "dataDir = 2024-01-01\n")
+		arrayPath := s.writeFile("array.toml", "[cors]\nallowedOrigins = [1, 2]\n")
+
+		_, malformedErr := s.load("-config", malformedPath)
+		_, dateErr := s.load("-config", datePath)
+		_, arrayErr := s.load("-config", arrayPath)
+
+		s.ErrorContains(malformedErr, "failed to parse config file")
+		s.ErrorContains(dateErr, "dataDir: unsupported value of type time.Time")
+		s.ErrorContains(arrayErr, "cors.allowedOrigins: unsupported array of int64, expected strings")
+	})
+
+	s.Run("should let environment override the file and flags override both", func() {
+		path := s.writeFile("config.yaml", "server:\n  port: 9090\nreload:\n  interval: 5m\n")
+		s.env["APPSTOREREVIEWS_PORT"] = "9191"
+		s.env["APPSTOREREVIEWS_RELOAD_INTERVAL"] = "2m"
+
+		cfg, err := s.load("-config", path, "-port", "9292")
+
+		s.Require().NoError(err)
+		s.Equal(9292, cfg.Server.Port)
+		s.Equal(2*time.Minute, cfg.Reload.Interval)
+	})
+
+	s.Run("should report print-config", func() {
+		_, printConfig, err := config.Load([]string{"--print-config"}, s.lookupEnv, io.Discard)
+
+		s.Require().NoError(err)
+		s.True(printConfig)
+	})
+
+	s.Run("should reject unknown keys in config files", func() {
+		yamlPath := s.writeFile("config.yaml", "server:\n  host: example.com\n")
+		tomlPath := s.writeFile("config.toml", "[server]\nhost = \"example.com\"\n")
+
+		_, yamlErr := s.load("-config", yamlPath)
+		_, tomlErr := s.load("-config", tomlPath)
+
+		s.ErrorContains(yamlErr, "field host not found")
+		s.ErrorContains(tomlErr, `unknown key "server.host"`)
+	})
+
+	s.Run("should reject unsupported config file types", func() {
+		path := s.writeFile("config.json", "{}")
+
+		_, err := s.load("-config", path)
+
+		s.ErrorContains(err, "unsupported config file")
+	})
+
+	s.Run("should reject malformed environment values", func() {
+		s.env["APPSTOREREVIEWS_ITUNES_TIMEOUT"] = "soon"
+
+		_, err := s.load()
+
+		s.ErrorContains(err, `APPSTOREREVIEWS_ITUNES_TIMEOUT: invalid duration "soon"`)
+	})
+
+	s.Run("should reject malformed flags", func() {
+		_, err := s.load("-port", "http")
+
+		s.ErrorContains(err, `invalid integer "http"`)
+	})
+
+	s.Run("should report every invalid value", func() {
+		_, err := s.load("-port", "70000", "-reload-interval", "10ms", "-base-url", "reviews.example.com")
+
+		s.Require().Error(err)
+		s.Contains(err.Error(), "server.port must be between 1 and 65535")
+		s.Contains(err.Error(), "reload.interval must be at least 1s")
+		s.Contains(err.Error(), "server.baseURL must be an absolute http or https URL")
+	})
+
+	s.Run("should read the request limits and timeouts", func() {
+		s.env["APPSTOREREVIEWS_RATE_LIMIT"] = "0"
+		s.env["APPSTOREREVIEWS_WRITE_TIMEOUT"] = "10m"
+
+		cfg, err := s.load("-max-body-bytes", "2048", "-address-rate-limit", "5")
+		s.Require().NoError(err)
+		s.Zero(cfg.Server.RateLimit)
+		s.Equal(5.0, cfg.Server.AddressRateLimit)
+		s.Equal(2048, cfg.Server.MaxBodyBytes)
+		s.Equal(10*time.Minute, cfg.Server.WriteTimeout)
+
+		_, err = s.load("-rate-limit", "-1", "-rate-burst", "0", "-address-rate-burst", "0", "-idle-timeout", "0s")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "server.rateLimit must not be negative")
+		s.Contains(err.Error(), "server.rateBurst must be at least 1")
+		s.Contains(err.Error(), "server.addressRateBurst must be at least 1")
+		s.Contains(err.Error(), "server.idleTimeout must be positive")
+	})
+
+	s.Run("should read the App Store feed base URL", func() {
+		s.env["APPSTOREREVIEWS_ITUNES_BASE_URL"] = "http://localhost:9000/"
+
+		cfg, err := s.load()
+		s.Require().NoError(err)
+		s.Equal("http://localhost:9000", cfg.ITunes.BaseURL)
+
+		_, err = s.load("-itunes-base-url", "itunes.example.com")
+		s.ErrorContains(err, `itunes.baseURL must be an absolute http or https URL, got "itunes.example.com"`)
+	})
+
+	s.Run("should require the app placeholder in the JSON source URL", func() {
+		cfg, err := s.load("-json-source-url", "https://reviews.example.com/apps/{id}/reviews")
+		s.Require().NoError(err)
+		s.Equal("https://reviews.example.com/apps/{id}/reviews", cfg.Sources.JSONURL)
+
+		_, err = s.load("-json-source-url", "https://reviews.example.com/apps")
+		s.ErrorContains(err, "sources.jsonURL must be an absolute http or https URL containing {id}")
+	})
+
+	s.Run("should check the App Store Connect key settings and territories", func() {
+		cfg, err := s.load("-app-store-connect-territories", "USA, GBR")
+		s.Require().NoError(err)
+		s.Equal([]string{"USA", "GBR"}, cfg.Sources.Territories())
+
+		_, err = s.load("-app-store-connect-key-file", "key.p8", "-app-store-connect-territories", "us")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "sources.appStoreConnectKeyID and sources.appStoreConnectIssuerID are required")
+		s.Contains(err.Error(), `got "us"`)
+	})
+
+	s.Run("should read whether authentication is enabled", func() {
+		s.env["APPSTOREREVIEWS_AUTH_ENABLED"] = "true"
+
+		cfg, err := s.load()
+		s.Require().NoError(err)
+		s.True(cfg.Auth.Enabled)
+
+		_, err = s.load("-auth-enabled", "maybe")
+		s.ErrorContains(err, `invalid boolean "maybe"`)
+	})
+
+	s.Run("should allow no cross-origin requests by default", func() {
+		cfg, err := s.load()
+
+		s.Require().NoError(err)
+		s.Empty(cfg.CORS.Origins())
+	})
+
+	s.Run("should read the CORS policy", func() {
+		s.env["APPSTOREREVIEWS_CORS_ALLOWED_ORIGINS"] = "https://reviews.example.com, https://*.example.org"
+
+		cfg, err := s.load("-cors-allow-credentials=true", "-cors-max-age", "1h")
+		s.Require().NoError(err)
+		s.Equal([]string{"https://reviews.example.com", "https://*.example.org"}, cfg.CORS.Origins())
+		s.True(cfg.CORS.AllowCredentials)
+		s.Equal([]string{"GET", "HEAD", "POST", "PUT", "DELETE"}, cfg.CORS.Methods())
+		s.Equal(time.Hour, cfg.CORS.MaxAge)
+
+		s.env["APPSTOREREVIEWS_CORS_ALLOWED_ORIGINS"] = "*, reviews.example.com, https://example.com/app"
+		_, err = s.load("-cors-allow-credentials=true", "-cors-allowed-methods", "", "-cors-max-age", "-1s")
+		s.Require().Error(err)
+		s.Contains(err.Error(), `cors.allowedOrigins must list http or https origins without a path, *, or wildcard subdomains such as https://*.example.com, got "reviews.example.com"`)
+		s.Contains(err.Error(), `got "https://example.com/app"`)
+		s.Contains(err.Error(), "cors.allowedOrigins must list origins instead of * with cors.allowCredentials")
+		s.Contains(err.Error(), "cors.allowedMethods must not be empty")
+		s.Contains(err.Error(), "cors.maxAge must not be negative")
+	})
+
+	s.Run("should check the OpenID Connect settings", func() {
+		cfg, err := s.load("-oidc-issuer", "https://login.example.com", "-oidc-audience", "reviews",
+			"-oidc-role-mapping", "reviews-admins=admin, support=triager")
+		s.Require().NoError(err)
+		mapping, err := cfg.Auth.RoleMapping()
+		s.Require().NoError(err)
+		s.Equal(map[string]user.Role{"reviews-admins": user.RoleAdmin, "support": user.RoleTriager}, mapping)
+		s.Empty(cfg.Auth.OIDCWorkspacesClaim)
+
+		s.env["APPSTOREREVIEWS_OIDC_WORKSPACES_CLAIM"] = "reviews.workspaces"
+		cfg, err = s.load()
+		s.Require().NoError(err)
+		s.Equal("reviews.workspaces", cfg.Auth.OIDCWorkspacesClaim)
+
+		_, err = s.load("-oidc-issuer", "login.example.com", "-oidc-role-mapping", "support=owner", "-oidc-default-role", "guest")
+		s.Require().Error(err)
+		s.Contains(err.Error(), "auth.oidcIssuer must be an absolute http or https URL")
+		s.Contains(err.Error(), "auth.oidcAudience is required")
+		s.Contains(err.Error(), `auth.oidcRoleMapping: invalid role "owner"`)
+		s.Contains(err.Error(), `auth.oidcDefaultRole: invalid role "guest"`)
+	})
+}
+
+func (s *ConfigTestSuite) TestPrint() {
+	s.Run("should print a config that loads back unchanged", func() {
+		cfg := config.Default()
+		cfg.Server.BaseURL = "https://reviews.example.com"
+
+		var out strings.Builder
+		s.Require().NoError(cfg.Print(&out))
+		s.Contains(out.String(), "interval: 1m0s")
+
+		loaded, err := s.load("-config", s.writeFile("printed.yaml", out.String()))
+
+		s.Require().NoError(err)
+		s.Equal(cfg, loaded)
+	})
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML reads a TOML config file. Tables are flattened into dotted keys
+// and values are returned as strings, so that they are parsed by the same
+// settings as environment variables and flags. Arrays of strings become
+// comma-separated lists; any other arrays and dates are rejected.
+func parseTOML(r io.Reader) (map[string]string, error) {
+	var document map[string]any
+	if _, err := toml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	if err := flattenTOML(values, "", document); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func flattenTOML(values map[string]string, prefix string, table map[string]any) error {
+	keys := make([]string, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, name := range keys {
+		key, value := name, table[name]
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			if err := flattenTOML(values, key, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		text, err := tomlString(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		values[key] = text
+	}
+
+	return nil
+}
+
+func tomlString(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case []any:
+		items := make([]string, len(value))
+		for i, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("unsupported array of %T, expected strings", item)
+			}
+			items[i] = text
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
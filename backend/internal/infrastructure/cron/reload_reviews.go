@@ -1,23 +1,35 @@
 package cron
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/application/workspaces"
+	"appstorereviewsviewer/internal/domain/workspace"
 )
 
+// ReloadReviews reloads the reviews of every workspace's apps on an interval.
 type ReloadReviews struct {
-	useCase   reloadreviews.UseCase
-	ticker    *time.Ticker
-	stopChan  chan struct{}
-	isRunning bool
+	useCase    reloadreviews.UseCase
+	workspaces workspaces.UseCase
+	interval   time.Duration
+	ticker     *time.Ticker
+	stopChan   chan struct{}
+	done       chan struct{}
+	cancel     context.CancelFunc
+	isRunning  bool
 }
 
-func NewReloadReviews(useCase reloadreviews.UseCase) *ReloadReviews {
+func NewReloadReviews(useCase reloadreviews.UseCase, workspaces workspaces.UseCase, interval time.Duration) *ReloadReviews {
 	return &ReloadReviews{
-		useCase:  useCase,
-		stopChan: make(chan struct{}),
+		useCase:    useCase,
+		workspaces: workspaces,
+		interval:   interval,
+		stopChan:   make(chan struct{}),
+		done:       make(chan struct{}),
 	}
 }
 
@@ -27,37 +39,88 @@ func (s *ReloadReviews) Start() {
 	}
 
 	s.isRunning = true
-	s.ticker = time.NewTicker(time.Minute)
+	s.ticker = time.NewTicker(s.interval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
 
 	go func() {
+		defer close(s.done)
+		defer cancel()
+		defer s.ticker.Stop()
 		for {
 			select {
 			case <-s.ticker.C:
-				s.executeReload()
+				// A tick may be pending when Stop is called; stopping wins.
+				select {
+				case <-s.stopChan:
+					return
+				default:
+				}
+				s.executeReload(ctx)
 			case <-s.stopChan:
-				s.ticker.Stop()
 				return
 			}
 		}
 	}()
 
-	slog.Info("ReloadReviews started - will run every minute")
+	slog.Info("ReloadReviews started", "interval", s.interval)
 }
 
-func (s *ReloadReviews) Stop() {
+// Stop prevents further reloads and waits for one that is in progress to
+// finish, so its writes are complete before the process exits. When ctx is
+// done first, the reload in progress is cancelled instead.
+func (s *ReloadReviews) Stop(ctx context.Context) error {
 	if !s.isRunning {
-		return
+		return nil
 	}
 
 	s.isRunning = false
 	close(s.stopChan)
-	slog.Info("ReloadReviews stopped")
+
+	select {
+	case <-s.done:
+		slog.Info("ReloadReviews stopped")
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return fmt.Errorf("reload cancelled before it finished: %w", ctx.Err())
+	}
+}
+
+// executeReload reloads one workspace after another, so the reload timeout
+// applies to each workspace rather than to all of them together.
+func (s *ReloadReviews) executeReload(ctx context.Context) {
+	all, err := s.workspaces.List(ctx)
+	if err != nil {
+		slog.Error("failed to list workspaces to reload", "error", err)
+		return
+	}
+
+	for _, w := range all {
+		if ctx.Err() != nil {
+			return
+		}
+		s.reloadWorkspace(workspace.WithID(ctx, w.ID), w.ID)
+	}
 }
 
-func (s *ReloadReviews) executeReload() {
-	if err := s.useCase.Execute(); err != nil {
-		slog.Error("failed to execute reload reviews", "error", err)
-	} else {
-		slog.Info("reload reviews completed successfully")
+func (s *ReloadReviews) reloadWorkspace(ctx context.Context, workspaceID string) {
+	summary, err := s.useCase.Execute(ctx)
+	if err != nil {
+		slog.Error("failed to execute reload reviews", "workspace", workspaceID, "error", err)
 	}
+	if summary == nil {
+		return
+	}
+
+	slog.Info("reload reviews completed",
+		"workspace", workspaceID,
+		"appsOk", summary.AppsOK,
+		"appsFailed", summary.AppsFailed,
+		"appsSkipped", summary.AppsSkipped,
+		"newReviews", summary.NewReviews,
+		"updatedReviews", summary.UpdatedReviews,
+		"duration", summary.Duration,
+	)
 }
@@ -0,0 +1,133 @@
+package cron_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/cron"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	reloadreviewsmocks "appstorereviewsviewer/mocks/application/reloadreviews"
+	workspacesmocks "appstorereviewsviewer/mocks/application/workspaces"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReloadReviewsTestSuite struct {
+	suite.Suite
+	mockReloadReviewsUseCase *reloadreviewsmocks.UseCase
+	mockWorkspacesUseCase    *workspacesmocks.UseCase
+}
+
+func (s *ReloadReviewsTestSuite) SetupSubTest() {
+	s.mockReloadReviewsUseCase = reloadreviewsmocks.NewUseCase(s.T())
+	s.mockWorkspacesUseCase = workspacesmocks.NewUseCase(s.T())
+}
+
+func (s *ReloadReviewsTestSuite) TestStart() {
+	s.Run("should reload every workspace in turn", func() {
+		s.mockWorkspacesUseCase.EXPECT().List(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}, {ID: "team-a"}}, nil)
+		reloaded := make(chan string, 2)
+		s.mockReloadReviewsUseCase.EXPECT().Execute(mock.Anything).RunAndReturn(func(ctx context.Context, _ ...string) (*reloadreviews.Summary, error) {
+			select {
+			case reloaded <- workspace.IDFromContext(ctx):
+			default:
+			}
+			return &reloadreviews.Summary{}, nil
+		})
+
+		reloadReviews := cron.NewReloadReviews(s.mockReloadReviewsUseCase, s.mockWorkspacesUseCase, 10*time.Millisecond)
+		reloadReviews.Start()
+		first, second := <-reloaded, <-reloaded
+		s.Require().NoError(reloadReviews.Stop(context.Background()))
+
+		s.Equal("default", first)
+		s.Equal("team-a", second)
+	})
+}
+
+func (s *ReloadReviewsTestSuite) TestStop() {
+	s.Run("should wait for an in-progress reload and leave no partial files", func() {
+		s.mockWorkspacesUseCase.EXPECT().List(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+		dataDir := s.T().TempDir()
+		repo, err := persistencereview.NewFileRepository(dataDir)
+		s.Require().NoError(err)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		s.mockReloadReviewsUseCase.EXPECT().Execute(mock.Anything).RunAndReturn(func(context.Context, ...string) (*reloadreviews.Summary, error) {
+			close(started)
+			<-release
+			return &reloadreviews.Summary{AppsOK: 1, NewReviews: 1}, repo.Save(context.Background(), &review.Review{
+				ID:          "review1",
+				AppID:       "12345",
+				Score:       5,
+				SubmittedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			})
+		}).Once()
+
+		reloadReviews := cron.NewReloadReviews(s.mockReloadReviewsUseCase, s.mockWorkspacesUseCase, 10*time.Millisecond)
+		reloadReviews.Start()
+		<-started
+
+		stopped := make(chan error)
+		go func() { stopped <- reloadReviews.Stop(context.Background()) }()
+
+		select {
+		case <-stopped:
+			s.Fail("Stop should wait for the reload in progress")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		s.Require().NoError(<-stopped)
+
+		entries, err := os.ReadDir(dataDir)
+		s.Require().NoError(err)
+		s.Require().Len(entries, 1)
+		s.Equal("12345_reviews.json", entries[0].Name())
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+		s.FileExists(filepath.Join(dataDir, "12345_reviews.json"))
+	})
+
+	s.Run("should give up waiting when the context expires", func() {
+		s.mockWorkspacesUseCase.EXPECT().List(mock.Anything).Return([]*workspace.Workspace{{ID: "default"}}, nil)
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		s.mockReloadReviewsUseCase.EXPECT().Execute(mock.Anything).RunAndReturn(func(context.Context, ...string) (*reloadreviews.Summary, error) {
+			close(started)
+			<-release
+			return &reloadreviews.Summary{}, nil
+		}).Once()
+
+		reloadReviews := cron.NewReloadReviews(s.mockReloadReviewsUseCase, s.mockWorkspacesUseCase, 10*time.Millisecond)
+		reloadReviews.Start()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := reloadReviews.Stop(ctx)
+
+		s.ErrorIs(err, context.DeadlineExceeded)
+	})
+
+	s.Run("should return immediately when not started", func() {
+		reloadReviews := cron.NewReloadReviews(s.mockReloadReviewsUseCase, s.mockWorkspacesUseCase, time.Minute)
+
+		s.NoError(reloadReviews.Stop(context.Background()))
+	})
+}
+
+func TestReloadReviewsTestSuite(t *testing.T) {
+	suite.Run(t, new(ReloadReviewsTestSuite))
+}
@@ -0,0 +1,220 @@
+package googleauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScopeAndroidPublisher grants access to the Google Play Developer API.
+const ScopeAndroidPublisher = "https://www.googleapis.com/auth/androidpublisher"
+
+const defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+// tokenLifetime is how long requested tokens are valid; Google caps it at an
+// hour.
+const tokenLifetime = time.Hour
+
+// refreshMargin renews tokens this long before they expire so a request
+// started with a token does not reach the API with an expired one.
+const refreshMargin = time.Minute
+
+// ServiceAccount exchanges a signed JWT for OAuth access tokens following
+// Google's service account flow and caches them until shortly before they
+// expire. It is safe for concurrent use.
+type ServiceAccount struct {
+	email    string
+	keyID    string
+	key      *rsa.PrivateKey
+	tokenURI string
+	scope    string
+	client   *http.Client
+	now      func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// LoadServiceAccount reads a service account key file as downloaded from the
+// Google Cloud console.
+func LoadServiceAccount(path, scope string, timeout time.Duration) (*ServiceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account key: %w", err)
+	}
+	return NewServiceAccount(data, scope, timeout)
+}
+
+// NewServiceAccount parses the JSON of a service account key.
+func NewServiceAccount(keyJSON []byte, scope string, timeout time.Duration) (*ServiceAccount, error) {
+	var keyFile serviceAccountKey
+	if err := json.Unmarshal(keyJSON, &keyFile); err != nil {
+		return nil, fmt.Errorf("failed to parse service account key: %w", err)
+	}
+	if keyFile.Type != "service_account" {
+		return nil, fmt.Errorf("unsupported credentials type %q, expected service_account", keyFile.Type)
+	}
+	if keyFile.ClientEmail == "" {
+		return nil, errors.New("service account key has no client_email")
+	}
+
+	key, err := parsePrivateKey(keyFile.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURI := keyFile.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+
+	return &ServiceAccount{
+		email:    keyFile.ClientEmail,
+		keyID:    keyFile.PrivateKeyID,
+		key:      key,
+		tokenURI: tokenURI,
+		scope:    scope,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+	}, nil
+}
+
+func parsePrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("service account private key is not PEM encoded")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		if key, pkcs1Err := x509.ParsePKCS1PrivateKey(block.Bytes); pkcs1Err == nil {
+			return key, nil
+		}
+		return nil, fmt.Errorf("failed to parse service account private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("service account private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// Token returns a valid access token, requesting a new one when the cached
+// token is missing or about to expire.
+func (a *ServiceAccount) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Add(refreshMargin).Before(a.expires) {
+		return a.token, nil
+	}
+
+	token, expiresIn, err := a.requestToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = token
+	a.expires = a.now().Add(expiresIn)
+	return a.token, nil
+}
+
+func (a *ServiceAccount) requestToken(ctx context.Context) (string, time.Duration, error) {
+	assertion, err := a.assertion()
+	if err != nil {
+		return "", 0, err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to request access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", 0, fmt.Errorf("token endpoint returned status: %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", 0, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return "", 0, errors.New("token response has no access_token")
+	}
+
+	return body.AccessToken, time.Duration(body.ExpiresIn) * time.Second, nil
+}
+
+// assertion builds the RS256 signed JWT identifying the service account.
+func (a *ServiceAccount) assertion() (string, error) {
+	now := a.now()
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if a.keyID != "" {
+		header["kid"] = a.keyID
+	}
+	claims := map[string]any{
+		"iss":   a.email,
+		"scope": a.scope,
+		"aud":   a.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenLifetime).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token request: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,140 @@
+package googleauth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/infrastructure/googleauth"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceAccountTestSuite struct {
+	suite.Suite
+	key *rsa.PrivateKey
+}
+
+func (s *ServiceAccountTestSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+}
+
+func (s *ServiceAccountTestSuite) keyJSON(tokenURI string) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(s.key)
+	s.Require().NoError(err)
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "reviews@example.iam.gserviceaccount.com",
+		"private_key_id": "key-1",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      tokenURI,
+	})
+	s.Require().NoError(err)
+	return data
+}
+
+// newTokenServer checks the signed assertion and issues tokens valid for
+// expiresIn seconds, counting the requests it receives.
+func (s *ServiceAccountTestSuite) newTokenServer(expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		s.Require().NoError(r.ParseForm())
+		s.Equal("urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		s.Require().Len(parts, 3)
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		s.Require().NoError(err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		s.NoError(rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature))
+
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		s.Require().NoError(err)
+		var claims map[string]any
+		s.Require().NoError(json.Unmarshal(claimsJSON, &claims))
+		s.Equal("reviews@example.iam.gserviceaccount.com", claims["iss"])
+		s.Equal(googleauth.ScopeAndroidPublisher, claims["scope"])
+		s.Equal("http://"+r.Host+"/token", claims["aud"])
+
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": %d, "token_type": "Bearer"}`, n, expiresIn)
+	}))
+	s.T().Cleanup(server.Close)
+	return server, &requests
+}
+
+func (s *ServiceAccountTestSuite) TestToken() {
+	s.Run("should exchange a signed assertion for an access token and cache it", func() {
+		server, requests := s.newTokenServer(3600)
+		account, err := googleauth.NewServiceAccount(s.keyJSON(server.URL+"/token"), googleauth.ScopeAndroidPublisher, time.Second)
+		s.Require().NoError(err)
+
+		first, err := account.Token(context.Background())
+		s.Require().NoError(err)
+		second, err := account.Token(context.Background())
+		s.Require().NoError(err)
+
+		s.Equal("token-1", first)
+		s.Equal("token-1", second)
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should request a new token when the cached one is about to expire", func() {
+		server, requests := s.newTokenServer(30)
+		account, err := googleauth.NewServiceAccount(s.keyJSON(server.URL+"/token"), googleauth.ScopeAndroidPublisher, time.Second)
+		s.Require().NoError(err)
+
+		_, err = account.Token(context.Background())
+		s.Require().NoError(err)
+		token, err := account.Token(context.Background())
+		s.Require().NoError(err)
+
+		s.Equal("token-2", token)
+		s.Equal(int32(2), requests.Load())
+	})
+
+	s.Run("should return error when the token endpoint fails", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		s.T().Cleanup(server.Close)
+		account, err := googleauth.NewServiceAccount(s.keyJSON(server.URL), googleauth.ScopeAndroidPublisher, time.Second)
+		s.Require().NoError(err)
+
+		_, err = account.Token(context.Background())
+
+		s.EqualError(err, "token endpoint returned status: 401")
+	})
+}
+
+func (s *ServiceAccountTestSuite) TestNewServiceAccount() {
+	s.Run("should reject credentials that are not a service account key", func() {
+		_, err := googleauth.NewServiceAccount([]byte(`{"type": "authorized_user"}`), googleauth.ScopeAndroidPublisher, time.Second)
+
+		s.EqualError(err, `unsupported credentials type "authorized_user", expected service_account`)
+	})
+
+	s.Run("should reject a key that is not PEM encoded", func() {
+		_, err := googleauth.NewServiceAccount([]byte(`{"type": "service_account", "client_email": "a@b", "private_key": "nope"}`), googleauth.ScopeAndroidPublisher, time.Second)
+
+		s.EqualError(err, "service account private key is not PEM encoded")
+	})
+}
+
+func TestServiceAccountTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountTestSuite))
+}
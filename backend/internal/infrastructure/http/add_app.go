@@ -3,38 +3,51 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
+
+	"appstorereviewsviewer/internal/domain/app"
 )
 
 type AddAppRequest struct {
 	AppID string `json:"appId"`
+	// Name is an optional display name, used in reply templates.
+	Name string `json:"name,omitempty"`
+	// Sources lists where reviews are read from; empty means the App Store.
+	Sources []AddAppSourceRequest `json:"sources,omitempty"`
 }
 
-func (h *Handlers) AddApp(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+type AddAppSourceRequest struct {
+	Name string `json:"name"`
+	// ExternalID is the app's ID in the source, defaulting to AppID.
+	ExternalID string `json:"externalId,omitempty"`
+}
 
+func (h *Handlers) AddApp(w http.ResponseWriter, r *http.Request) {
 	var request AddAppRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeBodyError(w, r, err, "Invalid request body")
 		return
 	}
 
 	if request.AppID == "" {
-		http.Error(w, "AppID is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "AppID is required")
 		return
 	}
 
-	err := h.addAppUseCase.Execute(request.AppID)
+	var sources []app.Source
+	for _, source := range request.Sources {
+		sources = append(sources, app.Source{Name: source.Name, ExternalID: source.ExternalID})
+	}
+
+	backfill, err := h.addAppUseCase.Execute(r.Context(), request.AppID, request.Name, sources)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-
-	w.WriteHeader(http.StatusCreated)
+	// The app's reviews are fetched in the background; the job tells the
+	// client when they are in, or that it failed because the queue was
+	// full and the next scheduled reload fetches them.
+	w.Header().Set("Location", strings.TrimSuffix(r.URL.Path, "/app")+"/jobs/"+backfill.ID)
+	writeJSON(w, http.StatusAccepted, newJobResponse(backfill))
 }
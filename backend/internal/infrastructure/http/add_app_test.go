@@ -3,14 +3,23 @@ package http_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/review"
 	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
 	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
 	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -18,41 +27,87 @@ type AddAppHandlerTestSuite struct {
 	suite.Suite
 	mockAddAppUseCase           *addappmocks.UseCase
 	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
 	handlers                    *infrahttp.Handlers
 }
 
 func (s *AddAppHandlerTestSuite) SetupSubTest() {
 	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
 	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
-	s.handlers = infrahttp.NewHandlers(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase)
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}, "")
 }
 
 func (s *AddAppHandlerTestSuite) TestAddApp() {
-	s.Run("should add app successfully when valid request provided", func() {
+	s.Run("should add app and accept the backfill job when valid request provided", func() {
 		requestBody := infrahttp.AddAppRequest{AppID: "12345"}
 		jsonBody, _ := json.Marshal(requestBody)
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", bytes.NewBuffer(jsonBody))
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workspaces/team-a/app", bytes.NewBuffer(jsonBody))
 		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
-		s.mockAddAppUseCase.EXPECT().Execute("12345").Return(nil)
+		createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source(nil)).
+			Return(&job.Job{ID: "j1", Kind: job.KindBackfill, AppID: "12345", Status: job.StatusQueued, CreatedAt: createdAt}, nil)
 
 		s.handlers.AddApp(rr, req)
 
-		s.Equal(http.StatusCreated, rr.Code)
+		s.Equal(http.StatusAccepted, rr.Code)
+		s.Equal("/api/v1/workspaces/team-a/jobs/j1", rr.Header().Get("Location"))
+		s.JSONEq(`{"id":"j1","kind":"backfill","appId":"12345","status":"queued","reviewsFetched":0,"newReviews":0,"updatedReviews":0,"createdAt":"2026-01-02T03:04:05Z"}`, rr.Body.String())
 		s.Equal("application/json", rr.Header().Get("Content-Type"))
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
 	})
 
-	s.Run("should return method not allowed when non-POST method used", func() {
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app", nil)
+	s.Run("should pass the name and declared sources to the use case", func() {
+		body := `{"appId":"12345","name":"Example","sources":[{"name":"appstore"},{"name":"googleplay","externalId":"com.example.app"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", bytes.NewBufferString(body))
 		rr := httptest.NewRecorder()
 
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "Example", []app.Source{
+			{Name: "appstore"},
+			{Name: "googleplay", ExternalID: "com.example.app"},
+		}).Return(&job.Job{ID: "j1"}, nil)
+
 		s.handlers.AddApp(rr, req)
 
-		s.Equal(http.StatusMethodNotAllowed, rr.Code)
-		s.Contains(rr.Body.String(), "Method not allowed")
+		s.Equal(http.StatusAccepted, rr.Code)
+	})
+
+	s.Run("should return bad request when a source is not registered", func() {
+		body := `{"appId":"12345","sources":[{"name":"unknown"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source{{Name: "unknown"}}).
+			Return(nil, fmt.Errorf("%w %q", review.ErrUnknownSource, "unknown"))
+
+		s.handlers.AddApp(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Equal(`unknown review source "unknown"`, problemOf(s.T(), rr).Detail)
+	})
+
+	s.Run("should return bad request when a source ID is invalid", func() {
+		body := `{"appId":"12345","sources":[{"name":"googleplay","externalId":"not a package"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source{{Name: "googleplay", ExternalID: "not a package"}}).
+			Return(nil, fmt.Errorf("%w: %q is not a Google Play package name", app.ErrInvalidExternalID, "not a package"))
+
+		s.handlers.AddApp(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
 	})
 
 	s.Run("should return bad request when invalid JSON provided", func() {
@@ -86,12 +141,27 @@ func (s *AddAppHandlerTestSuite) TestAddApp() {
 		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
-		s.mockAddAppUseCase.EXPECT().Execute("12345").Return(assert.AnError)
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source(nil)).Return(nil, assert.AnError)
 
 		s.handlers.AddApp(rr, req)
 
 		s.Equal(http.StatusInternalServerError, rr.Code)
-		s.Contains(rr.Body.String(), "assert.AnError general error for testing")
+		s.NotContains(rr.Body.String(), assert.AnError.Error())
+	})
+
+	s.Run("should accept the app with a failed job when the job queue is full", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", bytes.NewBufferString(`{"appId":"12345"}`))
+		rr := httptest.NewRecorder()
+
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source(nil)).
+			Return(&job.Job{ID: "j1", AppID: "12345", Status: job.StatusFailed, Error: job.ErrQueueFull.Error()}, nil)
+
+		s.handlers.AddApp(rr, req)
+
+		s.Equal(http.StatusAccepted, rr.Code)
+		s.Equal("/api/v1/jobs/j1", rr.Header().Get("Location"))
+		s.Contains(rr.Body.String(), `"status":"failed"`)
+		s.Contains(rr.Body.String(), job.ErrQueueFull.Error())
 	})
 }
 
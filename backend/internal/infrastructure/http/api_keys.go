@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/user"
+)
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+	// Workspaces limits the key to these workspaces; ["*"] lets it access
+	// every workspace.
+	Workspaces []string `json:"workspaces"`
+}
+
+type APIKeyResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"createdAt"`
+	// Workspaces is ["*"] for keys that may access every workspace.
+	Workspaces []string `json:"workspaces"`
+	// Key is the secret, only returned when the key is created.
+	Key string `json:"key,omitempty"`
+}
+
+type APIKeysResponse struct {
+	Keys []APIKeyResponse `json:"keys"`
+}
+
+func newAPIKeyResponse(key *apikey.Key) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Role:       string(key.Role),
+		CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+		Workspaces: key.Workspaces,
+	}
+}
+
+// ListAPIKeys lists API keys without their secrets.
+func (h *Handlers) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeysUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := APIKeysResponse{Keys: make([]APIKeyResponse, len(keys))}
+	for i, key := range keys {
+		response.Keys[i] = newAPIKeyResponse(key)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateAPIKey creates an API key and answers with its secret, which is not
+// shown again.
+func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var body CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeBodyError(w, r, err, "Invalid request body")
+		return
+	}
+
+	role, err := user.ParseRole(body.Role)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	key, secret, err := h.apiKeysUseCase.Create(r.Context(), body.Name, role, body.Workspaces)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := newAPIKeyResponse(key)
+	response.Key = secret
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// DeleteAPIKey revokes an API key.
+func (h *Handlers) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.apiKeysUseCase.Delete(r.Context(), r.PathValue("keyId")); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
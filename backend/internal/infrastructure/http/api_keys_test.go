@@ -0,0 +1,116 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/domain/workspace"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	apikeysmocks "appstorereviewsviewer/mocks/application/apikeys"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeysHandlerTestSuite struct {
+	suite.Suite
+	mockAPIKeysUseCase *apikeysmocks.UseCase
+	handlers           *infrahttp.Handlers
+}
+
+func (s *APIKeysHandlerTestSuite) SetupSubTest() {
+	s.mockAPIKeysUseCase = apikeysmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{APIKeys: s.mockAPIKeysUseCase}, "")
+}
+
+func (s *APIKeysHandlerTestSuite) TestAPIKeys() {
+	key := &apikey.Key{ID: "k1", Name: "CI", Role: user.RoleTriager, Hash: "hash", CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Workspaces: []string{apikey.AllWorkspaces}}
+
+	s.Run("should list keys without their hashes", func() {
+		s.mockAPIKeysUseCase.EXPECT().List(mock.Anything).Return([]*apikey.Key{key}, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ListAPIKeys(rr, httptest.NewRequest(http.MethodGet, "/api/v1/api-keys", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"keys":[{"id":"k1","name":"CI","role":"triager","createdAt":"2025-01-01T12:00:00Z","workspaces":["*"]}]}`, rr.Body.String())
+	})
+
+	s.Run("should create a key and return its secret once", func() {
+		s.mockAPIKeysUseCase.EXPECT().Create(mock.Anything, "CI", user.RoleTriager, []string{"*"}).Return(key, "arv_secret", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateAPIKey(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", strings.NewReader(`{"name":"CI","role":"triager","workspaces":["*"]}`)))
+
+		s.Equal(http.StatusCreated, rr.Code)
+		var response infrahttp.APIKeyResponse
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+		s.Equal("arv_secret", response.Key)
+		s.Equal("k1", response.ID)
+	})
+
+	s.Run("should limit a key to workspaces", func() {
+		limited := &apikey.Key{ID: "k2", Name: "Team A", Role: user.RoleViewer, Workspaces: []string{"team-a"}}
+		s.mockAPIKeysUseCase.EXPECT().Create(mock.Anything, "Team A", user.RoleViewer, []string{"team-a"}).Return(limited, "arv_secret", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateAPIKey(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+			strings.NewReader(`{"name":"Team A","role":"viewer","workspaces":["team-a"]}`)))
+
+		s.Equal(http.StatusCreated, rr.Code)
+		s.Contains(rr.Body.String(), `"workspaces":["team-a"]`)
+	})
+
+	s.Run("should reject unknown workspaces", func() {
+		s.mockAPIKeysUseCase.EXPECT().Create(mock.Anything, "CI", user.RoleViewer, []string{"team-b"}).
+			Return(nil, "", fault.Wrap(fault.KindValidation, fmt.Errorf("%w: team-b", workspace.ErrNotFound)))
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateAPIKey(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+			strings.NewReader(`{"name":"CI","role":"viewer","workspaces":["team-b"]}`)))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	s.Run("should reject unknown roles", func() {
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateAPIKey(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys", strings.NewReader(`{"name":"CI","role":"owner"}`)))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(problemOf(s.T(), rr).Detail, `invalid role "owner"`)
+	})
+
+	s.Run("should revoke a key", func() {
+		s.mockAPIKeysUseCase.EXPECT().Delete(mock.Anything, "k1").Return(nil)
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/k1", nil)
+		req.SetPathValue("keyId", "k1")
+		rr := httptest.NewRecorder()
+
+		s.handlers.DeleteAPIKey(rr, req)
+
+		s.Equal(http.StatusNoContent, rr.Code)
+	})
+
+	s.Run("should return not found when revoking unknown keys", func() {
+		s.mockAPIKeysUseCase.EXPECT().Delete(mock.Anything, "k2").Return(fmt.Errorf("%w: k2", apikey.ErrNotFound))
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/k2", nil)
+		req.SetPathValue("keyId", "k2")
+		rr := httptest.NewRecorder()
+
+		s.handlers.DeleteAPIKey(rr, req)
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestAPIKeysHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeysHandlerTestSuite))
+}
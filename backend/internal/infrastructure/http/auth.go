@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/infrastructure/oidc"
+)
+
+// TokenVerifier validates bearer tokens issued by the identity provider.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*user.User, error)
+}
+
+type userContextKey struct{}
+
+// UserFromContext returns the user a request was authenticated as.
+func UserFromContext(ctx context.Context) (*user.User, bool) {
+	u, ok := ctx.Value(userContextKey{}).(*user.User)
+	return u, ok
+}
+
+// Auth checks the credential sent as "Authorization: Bearer <credential>"
+// against the role a route requires. The credential is either an API key or,
+// when tokens is set, a JWT from the identity provider. When disabled every
+// request is let through.
+type Auth struct {
+	keys    apikeys.UseCase
+	tokens  TokenVerifier
+	enabled bool
+}
+
+func NewAuth(keys apikeys.UseCase, tokens TokenVerifier, enabled bool) *Auth {
+	return &Auth{keys: keys, tokens: tokens, enabled: enabled}
+}
+
+// Require guards next so that GET and HEAD requests need the read role and
+// all other methods the write role.
+func (a *Auth) Require(read, write user.Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		credential, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			writeProblem(w, r, http.StatusUnauthorized, "API key or token required")
+			return
+		}
+
+		u, err := a.authenticate(r.Context(), credential)
+		if errors.Is(err, apikeys.ErrInvalidKey) || errors.Is(err, oidc.ErrInvalidToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token"`)
+			writeProblem(w, r, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		required := write
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			required = read
+		}
+		if !u.Role.Allows(required) {
+			writeProblem(w, r, http.StatusForbidden, "role "+string(u.Role)+" may not access this resource, "+string(required)+" required")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, u)))
+	})
+}
+
+func (a *Auth) authenticate(ctx context.Context, credential string) (*user.User, error) {
+	if a.tokens != nil && oidc.LooksLikeToken(credential) {
+		return a.tokens.Verify(ctx, credential)
+	}
+
+	key, err := a.keys.Authenticate(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+	return &user.User{ID: "apikey:" + key.ID, Name: key.Name, Role: key.Role, Workspaces: key.UserWorkspaces()}, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
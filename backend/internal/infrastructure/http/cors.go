@@ -1,18 +1,128 @@
 package http
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CorsPolicy decides which browser origins may call the API and what they
+// may send.
+type CorsPolicy struct {
+	// AllowedOrigins lists origins such as https://reviews.example.com.
+	// https://*.example.com allows every subdomain of example.com, and *
+	// any origin.
+	AllowedOrigins []string
+	// AllowCredentials lets browsers send cookies and Authorization headers
+	// and read the responses. Allowed origins are then echoed instead of *.
+	AllowCredentials bool
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	// MaxAge is how long browsers may cache a preflight response; zero
+	// leaves it to the browser.
+	MaxAge time.Duration
+}
+
+// allowsOrigin reports whether origin is allowed, matching case-insensitively.
+func (p CorsPolicy) allowsOrigin(origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, allowed := range p.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		scheme, domain, ok := strings.Cut(allowed, "://*.")
+		if !ok {
+			continue
+		}
+		host, found := strings.CutPrefix(origin, scheme+"://")
+		if found && strings.HasSuffix(host, "."+domain) && len(host) > len(domain)+1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CorsPolicy) allowsAnyOrigin() bool {
+	return slices.Contains(p.AllowedOrigins, "*")
+}
+
+// allowsPreflight reports whether the method and headers a preflight asks
+// for are allowed. Simple methods and headers need no permission.
+func (p CorsPolicy) allowsPreflight(r *http.Request) bool {
+	method := r.Header.Get("Access-Control-Request-Method")
+	if method != http.MethodGet && method != http.MethodHead && method != http.MethodPost &&
+		!slices.ContainsFunc(p.AllowedMethods, func(allowed string) bool { return strings.EqualFold(allowed, method) }) {
+		return false
+	}
+	for _, header := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !slices.ContainsFunc(p.AllowedHeaders, func(allowed string) bool { return strings.EqualFold(allowed, header) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// CorsMiddleware applies policy to cross-origin requests. Allowed origins
+// get the CORS headers; others get none, so browsers hide the response from
+// them, and their preflight requests are rejected with a 403 problem.
+// Requests without an Origin header pass through untouched.
+func CorsMiddleware(policy CorsPolicy, next http.Handler) http.Handler {
+	methods := strings.Join(policy.AllowedMethods, ", ")
+	headers := strings.Join(policy.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(policy.MaxAge.Seconds()))
 
-func CorsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		// Responses differ by origin, so caches must keep them apart.
+		w.Header().Add("Vary", "Origin")
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
 			return
 		}
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
 
-		next.ServeHTTP(w, r)
+		if !policy.allowsOrigin(origin) {
+			if preflight {
+				writeProblem(w, r, http.StatusForbidden, "origin "+origin+" is not allowed")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if policy.allowsAnyOrigin() && !policy.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if policy.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			w.Header().Set("Access-Control-Expose-Headers", "Location, Retry-After, "+RequestIDHeader)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !policy.allowsPreflight(r) {
+			writeProblem(w, r, http.StatusForbidden, "method or headers are not allowed for origin "+origin)
+			return
+		}
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+		if headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		if policy.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", maxAge)
+		}
+		w.WriteHeader(http.StatusNoContent)
 	})
 }
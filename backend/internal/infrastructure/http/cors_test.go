@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
 	"github.com/stretchr/testify/suite"
@@ -11,123 +12,149 @@ import (
 
 type CorsMiddlewareTestSuite struct {
 	suite.Suite
+	policy infrahttp.CorsPolicy
+	called bool
 }
 
-func (s *CorsMiddlewareTestSuite) TestCorsMiddleware() {
-	s.Run("should add CORS headers to response", func() {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte("test response"))
-		})
+func (s *CorsMiddlewareTestSuite) SetupSubTest() {
+	s.policy = infrahttp.CorsPolicy{
+		AllowedOrigins: []string{"https://reviews.example.com", "https://*.example.org"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:         10 * time.Minute,
+	}
+	s.called = false
+}
+
+func (s *CorsMiddlewareTestSuite) serve(method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.called = true
+		w.Header().Set("Custom-Header", "custom-value")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("test response"))
+	})
 
-		corsHandler := infrahttp.CorsMiddleware(handler)
-		req := httptest.NewRequest(http.MethodGet, "/test", nil)
-		rr := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/test", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	infrahttp.CorsMiddleware(s.policy, handler).ServeHTTP(rr, req)
+	return rr
+}
 
-		corsHandler.ServeHTTP(rr, req)
+func (s *CorsMiddlewareTestSuite) TestCorsMiddleware() {
+	s.Run("should add CORS headers for an allowed origin", func() {
+		rr := s.serve(http.MethodPost, "https://reviews.example.com", nil)
 
-		s.Equal(http.StatusOK, rr.Code)
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
-		s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+		s.True(s.called)
+		s.Equal(http.StatusCreated, rr.Code)
+		s.Equal("https://reviews.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Equal("Location, Retry-After, X-Request-ID", rr.Header().Get("Access-Control-Expose-Headers"))
+		s.Equal("Origin", rr.Header().Get("Vary"))
+		s.Empty(rr.Header().Get("Access-Control-Allow-Credentials"))
+		s.Equal("custom-value", rr.Header().Get("Custom-Header"))
 		s.Equal("test response", rr.Body.String())
 	})
 
-	s.Run("should handle OPTIONS request and return OK", func() {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			s.Fail("Handler should not be called for OPTIONS request")
-		})
+	s.Run("should allow subdomains of a wildcard origin", func() {
+		for _, origin := range []string{"https://app.example.org", "https://eu.app.example.org"} {
+			rr := s.serve(http.MethodGet, origin, nil)
 
-		corsHandler := infrahttp.CorsMiddleware(handler)
-		req := httptest.NewRequest(http.MethodOptions, "/test", nil)
-		rr := httptest.NewRecorder()
+			s.Equal(origin, rr.Header().Get("Access-Control-Allow-Origin"))
+		}
+	})
 
-		corsHandler.ServeHTTP(rr, req)
+	s.Run("should not match the wildcard's own domain or other schemes", func() {
+		for _, origin := range []string{"https://example.org", "http://app.example.org", "https://app.example.org.evil.com", "https://evilexample.org"} {
+			rr := s.serve(http.MethodGet, origin, nil)
 
-		s.Equal(http.StatusOK, rr.Code)
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
-		s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
-		s.Empty(rr.Body.String())
+			s.Empty(rr.Header().Get("Access-Control-Allow-Origin"), origin)
+		}
 	})
 
-	s.Run("should preserve existing headers from wrapped handler", func() {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Custom-Header", "custom-value")
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusCreated)
-			_, _ = w.Write([]byte(`{"message": "created"}`))
-		})
+	s.Run("should serve disallowed origins without CORS headers", func() {
+		rr := s.serve(http.MethodGet, "https://evil.com", nil)
 
-		corsHandler := infrahttp.CorsMiddleware(handler)
-		req := httptest.NewRequest(http.MethodPost, "/test", nil)
-		rr := httptest.NewRecorder()
+		s.True(s.called)
+		s.Equal(http.StatusCreated, rr.Code)
+		s.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Empty(rr.Header().Get("Access-Control-Expose-Headers"))
+		s.Equal("Origin", rr.Header().Get("Vary"))
+	})
 
-		corsHandler.ServeHTTP(rr, req)
+	s.Run("should pass requests without an origin through", func() {
+		rr := s.serve(http.MethodOptions, "", nil)
 
-		s.Equal(http.StatusCreated, rr.Code)
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
-		s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
-		s.Equal("custom-value", rr.Header().Get("Custom-Header"))
-		s.Equal("application/json", rr.Header().Get("Content-Type"))
-		s.Equal(`{"message": "created"}`, rr.Body.String())
+		s.True(s.called)
+		s.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
 	})
 
-	s.Run("should work with different HTTP methods", func() {
-		methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch}
+	s.Run("should answer preflight requests of allowed origins", func() {
+		rr := s.serve(http.MethodOptions, "https://reviews.example.com", map[string]string{
+			"Access-Control-Request-Method":  "DELETE",
+			"Access-Control-Request-Headers": "authorization, content-type",
+		})
 
-		for _, method := range methods {
-			s.Run("method "+method, func() {
-				handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					w.WriteHeader(http.StatusOK)
-				})
+		s.False(s.called)
+		s.Equal(http.StatusNoContent, rr.Code)
+		s.Equal("https://reviews.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Equal("GET, POST, PUT, DELETE", rr.Header().Get("Access-Control-Allow-Methods"))
+		s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+		s.Equal("600", rr.Header().Get("Access-Control-Max-Age"))
+		s.Equal([]string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, rr.Header().Values("Vary"))
+		s.Empty(rr.Body.String())
+	})
 
-				corsHandler := infrahttp.CorsMiddleware(handler)
-				req := httptest.NewRequest(method, "/test", nil)
-				rr := httptest.NewRecorder()
+	s.Run("should reject preflight requests of disallowed origins", func() {
+		rr := s.serve(http.MethodOptions, "https://evil.com", map[string]string{
+			"Access-Control-Request-Method": "DELETE",
+		})
 
-				corsHandler.ServeHTTP(rr, req)
+		s.False(s.called)
+		s.Equal(http.StatusForbidden, rr.Code)
+		s.Empty(rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Empty(rr.Header().Get("Access-Control-Allow-Methods"))
+		s.Equal("origin https://evil.com is not allowed", problemOf(s.T(), rr).Detail)
+	})
 
-				s.Equal(http.StatusOK, rr.Code)
-				s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-				s.Equal("GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
-				s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
-			})
+	s.Run("should reject preflight requests for methods or headers outside the policy", func() {
+		for _, headers := range []map[string]string{
+			{"Access-Control-Request-Method": "PATCH"},
+			{"Access-Control-Request-Method": "PUT", "Access-Control-Request-Headers": "X-Debug"},
+		} {
+			rr := s.serve(http.MethodOptions, "https://reviews.example.com", headers)
+
+			s.False(s.called)
+			s.Equal(http.StatusForbidden, rr.Code)
+			s.Empty(rr.Header().Get("Access-Control-Allow-Methods"))
+			s.Equal("method or headers are not allowed for origin https://reviews.example.com", problemOf(s.T(), rr).Detail)
 		}
 	})
 
-	s.Run("should handle handler that panics gracefully", func() {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			panic("test panic")
-		})
-
-		corsHandler := infrahttp.CorsMiddleware(handler)
-		req := httptest.NewRequest(http.MethodGet, "/test", nil)
-		rr := httptest.NewRecorder()
+	s.Run("should answer any origin with * unless credentials are allowed", func() {
+		s.policy.AllowedOrigins = []string{"*"}
+		s.policy.MaxAge = 0
 
-		s.Panics(func() {
-			corsHandler.ServeHTTP(rr, req)
+		rr := s.serve(http.MethodOptions, "https://anywhere.com", map[string]string{
+			"Access-Control-Request-Method": "GET",
 		})
 
+		s.Equal(http.StatusNoContent, rr.Code)
 		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("GET, POST, PUT, DELETE, OPTIONS", rr.Header().Get("Access-Control-Allow-Methods"))
-		s.Equal("Content-Type, Authorization", rr.Header().Get("Access-Control-Allow-Headers"))
+		s.Empty(rr.Header().Get("Access-Control-Max-Age"))
 	})
 
-	s.Run("should handle root path request", func() {
-		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-		})
-
-		corsHandler := infrahttp.CorsMiddleware(handler)
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		rr := httptest.NewRecorder()
+	s.Run("should echo the origin and allow credentials when configured", func() {
+		s.policy.AllowCredentials = true
 
-		corsHandler.ServeHTTP(rr, req)
+		rr := s.serve(http.MethodGet, "https://app.example.org", nil)
 
-		s.Equal(http.StatusOK, rr.Code)
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Equal("https://app.example.org", rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Equal("true", rr.Header().Get("Access-Control-Allow-Credentials"))
 	})
 }
 
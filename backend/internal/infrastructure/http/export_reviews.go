@@ -0,0 +1,81 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"appstorereviewsviewer/internal/application/exportreviews"
+	"appstorereviewsviewer/internal/domain/app"
+)
+
+var exportContentTypes = map[exportreviews.Format]string{
+	exportreviews.FormatCSV:   "text/csv; charset=utf-8",
+	exportreviews.FormatJSONL: "application/x-ndjson",
+	exportreviews.FormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func (h *Handlers) ExportReviews(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("id")
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+	format, err := exportreviews.ParseFormat(query.Get("format"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	options := exportreviews.Options{Format: format}
+	if options.Since, err = parseTimeParam(query.Get("since")); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "invalid since")
+		return
+	}
+	if options.Until, err = parseTimeParam(query.Get("until")); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "invalid until")
+		return
+	}
+	if value := query.Get("bom"); value != "" {
+		if options.BOM, err = strconv.ParseBool(value); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "invalid bom")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", `attachment; filename="`+appID+`_reviews.`+string(format)+`"`)
+
+	// The body is streamed, so once the first byte is written the status can no
+	// longer change and failures can only be logged.
+	body := &exportWriter{ResponseWriter: w}
+	if err := h.exportReviewsUseCase.Execute(r.Context(), body, appID, options); err != nil {
+		if !body.started {
+			w.Header().Del("Content-Disposition")
+			writeError(w, r, err)
+			return
+		}
+		slog.Error("failed to export reviews", "app", appID, "format", format, "error", err)
+	}
+}
+
+// exportWriter records whether the export has started writing the body.
+type exportWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(p)
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,132 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/exportreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
+	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportReviewsHandlerTestSuite struct {
+	suite.Suite
+	mockAddAppUseCase           *addappmocks.UseCase
+	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
+	handlers                    *infrahttp.Handlers
+}
+
+func (s *ExportReviewsHandlerTestSuite) SetupSubTest() {
+	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
+	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}, "")
+}
+
+func (s *ExportReviewsHandlerTestSuite) newRequest(method, target string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+func (s *ExportReviewsHandlerTestSuite) TestExportReviews() {
+	s.Run("should stream export with attachment headers", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews/export?format=csv&bom=true&since=2025-01-01T00:00:00Z&until=2025-02-01T00:00:00Z")
+		rr := httptest.NewRecorder()
+
+		expectedOptions := exportreviews.Options{
+			Format: exportreviews.FormatCSV,
+			Since:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Until:  time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			BOM:    true,
+		}
+		s.mockExportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", expectedOptions).
+			RunAndReturn(func(_ context.Context, w io.Writer, _ string, _ exportreviews.Options) error {
+				_, err := io.WriteString(w, "id,appId\n")
+				return err
+			})
+
+		s.handlers.ExportReviews(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		s.Equal(`attachment; filename="12345_reviews.csv"`, rr.Header().Get("Content-Disposition"))
+		s.Equal("id,appId\n", rr.Body.String())
+	})
+
+	s.Run("should use the spreadsheet content type for xlsx", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews/export?format=xlsx")
+		rr := httptest.NewRecorder()
+
+		s.mockExportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", exportreviews.Options{Format: exportreviews.FormatXLSX}).
+			Return(nil)
+
+		s.handlers.ExportReviews(rr, req)
+
+		s.Equal("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rr.Header().Get("Content-Type"))
+	})
+
+	s.Run("should return not found for untracked apps", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews/export?format=csv")
+		rr := httptest.NewRecorder()
+
+		s.mockExportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", exportreviews.Options{Format: exportreviews.FormatCSV}).
+			Return(fmt.Errorf("%w: 12345", app.ErrNotFound))
+
+		s.handlers.ExportReviews(rr, req)
+
+		s.Equal(http.StatusNotFound, rr.Code)
+		s.Equal("application/problem+json", rr.Header().Get("Content-Type"))
+		s.Empty(rr.Header().Get("Content-Disposition"))
+	})
+
+	s.Run("should return bad request when format is unsupported", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews/export?format=pdf")
+		rr := httptest.NewRecorder()
+
+		s.handlers.ExportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "unsupported export format")
+	})
+
+	s.Run("should return bad request when until is invalid", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews/export?format=jsonl&until=yesterday")
+		rr := httptest.NewRecorder()
+
+		s.handlers.ExportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "invalid until")
+	})
+}
+
+func TestExportReviewsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportReviewsHandlerTestSuite))
+}
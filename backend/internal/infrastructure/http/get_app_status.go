@@ -0,0 +1,37 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+type AppStatusResponse struct {
+	Apps  []reloadreviews.AppHealth `json:"apps"`
+	Fetch review.FetchStats         `json:"fetch"`
+}
+
+func (h *Handlers) GetAppStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.appStatusUseCase.Execute(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := AppStatusResponse{Apps: []reloadreviews.AppHealth{}}
+	if status != nil {
+		response.Fetch = status.Fetch
+		if status.Apps != nil {
+			response.Apps = status.Apps
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("failed to write response", "path", r.URL.Path, "error", err)
+	}
+}
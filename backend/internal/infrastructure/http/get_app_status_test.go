@@ -0,0 +1,101 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/appstatus"
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/review"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	appstatusmocks "appstorereviewsviewer/mocks/application/appstatus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetAppStatusHandlerTestSuite struct {
+	suite.Suite
+	mockAppStatusUseCase *appstatusmocks.UseCase
+	handlers             *infrahttp.Handlers
+}
+
+func (s *GetAppStatusHandlerTestSuite) SetupSubTest() {
+	s.mockAppStatusUseCase = appstatusmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{AppStatus: s.mockAppStatusUseCase}, "")
+}
+
+func (s *GetAppStatusHandlerTestSuite) TestGetAppStatus() {
+	s.Run("should return the breaker state of every app and the fetch counters", func() {
+		retryAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		s.mockAppStatusUseCase.EXPECT().Execute(mock.Anything).Return(&appstatus.Status{
+			Apps: []reloadreviews.AppHealth{
+				{AppID: "app1", State: reloadreviews.BreakerClosed},
+				{AppID: "app2", State: reloadreviews.BreakerOpen, ConsecutiveFailures: 3, LastError: "boom", RetryAt: &retryAt},
+			},
+			Fetch: review.FetchStats{Requests: 4, NotModified: 3, BytesDownloaded: 1000, BytesSaved: 3000},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetAppStatus(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/json", rr.Header().Get("Content-Type"))
+
+		var response infrahttp.AppStatusResponse
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+		s.Require().Len(response.Apps, 2)
+		s.Equal(reloadreviews.BreakerClosed, response.Apps[0].State)
+		s.Nil(response.Apps[0].RetryAt)
+		s.Equal(reloadreviews.BreakerOpen, response.Apps[1].State)
+		s.Equal(3, response.Apps[1].ConsecutiveFailures)
+		s.Equal("boom", response.Apps[1].LastError)
+		s.Require().NotNil(response.Apps[1].RetryAt)
+		s.True(retryAt.Equal(*response.Apps[1].RetryAt))
+		s.Equal(review.FetchStats{Requests: 4, NotModified: 3, BytesDownloaded: 1000, BytesSaved: 3000}, response.Fetch)
+	})
+
+	s.Run("should return an empty list when use case returns nil", func() {
+		s.mockAppStatusUseCase.EXPECT().Execute(mock.Anything).Return(nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetAppStatus(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"apps":[],"fetch":{"requests":0,"notModified":0,"bytesDownloaded":0,"bytesSaved":0}}`, rr.Body.String())
+	})
+
+	s.Run("should return internal server error when use case fails", func() {
+		s.mockAppStatusUseCase.EXPECT().Execute(mock.Anything).Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetAppStatus(rr, req)
+
+		s.Equal(http.StatusInternalServerError, rr.Code)
+	})
+
+	s.Run("should return gateway timeout when the request deadline passes", func() {
+		s.mockAppStatusUseCase.EXPECT().Execute(mock.Anything).Return(nil, context.DeadlineExceeded)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetAppStatus(rr, req)
+
+		s.Equal(http.StatusGatewayTimeout, rr.Code)
+	})
+}
+
+func TestGetAppStatusHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GetAppStatusHandlerTestSuite))
+}
@@ -2,10 +2,11 @@ package http
 
 import (
 	"encoding/json"
+	"log/slog"
 	"net/http"
-	"regexp"
 	"time"
 
+	"appstorereviewsviewer/internal/domain/app"
 	"appstorereviewsviewer/internal/domain/review"
 )
 
@@ -16,6 +17,54 @@ type ReviewResponse struct {
 	Author      string `json:"author"`
 	SubmittedAt string `json:"submittedAt"`
 	AppID       string `json:"appId"`
+	Source      string `json:"source,omitempty"`
+	// ReplyStatus is the state of the developer reply, or "none".
+	ReplyStatus string         `json:"replyStatus"`
+	Reply       *ReplyResponse `json:"reply,omitempty"`
+}
+
+type ReplyResponse struct {
+	Content   string                  `json:"content"`
+	State     string                  `json:"state"`
+	UpdatedAt string                  `json:"updatedAt"`
+	History   []ReplyRevisionResponse `json:"history,omitempty"`
+}
+
+type ReplyRevisionResponse struct {
+	Content   string `json:"content"`
+	State     string `json:"state"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+const replyStatusNone = "none"
+
+func newReviewResponse(review *review.Review) ReviewResponse {
+	response := ReviewResponse{
+		ID:          review.ID,
+		Content:     review.Content,
+		Score:       review.Score,
+		Author:      review.Author,
+		SubmittedAt: review.SubmittedAt.Format(time.RFC3339),
+		AppID:       review.AppID,
+		Source:      review.Source,
+		ReplyStatus: replyStatusNone,
+	}
+	if reply := review.Reply; reply != nil {
+		response.ReplyStatus = string(reply.State)
+		response.Reply = &ReplyResponse{
+			Content:   reply.Content,
+			State:     string(reply.State),
+			UpdatedAt: reply.UpdatedAt.Format(time.RFC3339),
+		}
+		for _, revision := range reply.History {
+			response.Reply.History = append(response.Reply.History, ReplyRevisionResponse{
+				Content:   revision.Content,
+				State:     string(revision.State),
+				UpdatedAt: revision.UpdatedAt.Format(time.RFC3339),
+			})
+		}
+	}
+	return response
 }
 
 type ReviewsResponse struct {
@@ -23,15 +72,15 @@ type ReviewsResponse struct {
 }
 
 func (h *Handlers) GetRecentReviews(w http.ResponseWriter, r *http.Request) {
-	appID := extractAppIDFromPath(r.URL.Path)
-	if appID == "" {
-		http.Error(w, "Invalid app ID", http.StatusBadRequest)
+	appID := r.PathValue("id")
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	reviews, err := h.getRecentReviewsUseCase.Execute(appID)
+	reviews, err := h.getRecentReviewsUseCase.Execute(r.Context(), appID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -40,19 +89,10 @@ func (h *Handlers) GetRecentReviews(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 
 	responseReviews := make([]ReviewResponse, len(reviews))
 	for i, review := range reviews {
-		responseReviews[i] = ReviewResponse{
-			ID:          review.ID,
-			Content:     review.Content,
-			Score:       review.Score,
-			Author:      review.Author,
-			SubmittedAt: review.SubmittedAt.Format(time.RFC3339),
-			AppID:       review.AppID,
-		}
+		responseReviews[i] = newReviewResponse(review)
 	}
 
 	response := ReviewsResponse{
@@ -60,16 +100,6 @@ func (h *Handlers) GetRecentReviews(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-}
-
-func extractAppIDFromPath(urlPath string) string {
-	re := regexp.MustCompile(`^/api/v1/app/([^/]+)/reviews/recent$`)
-	matches := re.FindStringSubmatch(urlPath)
-	if len(matches) == 2 {
-		return matches[1]
+		slog.Error("failed to write response", "path", r.URL.Path, "error", err)
 	}
-	return ""
 }
@@ -1,6 +1,7 @@
 package http_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,8 +11,12 @@ import (
 	"appstorereviewsviewer/internal/domain/review"
 	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
 	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
 	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -19,13 +24,31 @@ type GetRecentReviewsHandlerTestSuite struct {
 	suite.Suite
 	mockAddAppUseCase           *addappmocks.UseCase
 	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
 	handlers                    *infrahttp.Handlers
 }
 
 func (s *GetRecentReviewsHandlerTestSuite) SetupSubTest() {
 	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
 	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
-	s.handlers = infrahttp.NewHandlers(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase)
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}, "")
+}
+
+func (s *GetRecentReviewsHandlerTestSuite) newRequest(appID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+	req.SetPathValue("id", appID)
+	return req
 }
 
 func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
@@ -53,17 +76,15 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 			},
 		}
 
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return(expectedReviews, nil)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(expectedReviews, nil)
 
 		s.handlers.GetRecentReviews(rr, req)
 
 		s.Equal(http.StatusOK, rr.Code)
 		s.Equal("application/json", rr.Header().Get("Content-Type"))
-		s.Equal("*", rr.Header().Get("Access-Control-Allow-Origin"))
-		s.Equal("Content-Type", rr.Header().Get("Access-Control-Allow-Headers"))
 
 		var response infrahttp.ReviewsResponse
 		err := json.Unmarshal(rr.Body.Bytes(), &response)
@@ -84,10 +105,10 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 
 	s.Run("should return empty array when no reviews found", func() {
 		appID := "12345"
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return([]*review.Review{}, nil)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return([]*review.Review{}, nil)
 
 		s.handlers.GetRecentReviews(rr, req)
 
@@ -102,10 +123,10 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 
 	s.Run("should return empty array when use case returns nil", func() {
 		appID := "12345"
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return(nil, nil)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(nil, nil)
 
 		s.handlers.GetRecentReviews(rr, req)
 
@@ -119,36 +140,47 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 	})
 
 	s.Run("should return bad request when invalid app ID in URL", func() {
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app//reviews/recent", nil)
+		req := s.newRequest("")
 		rr := httptest.NewRecorder()
 
 		s.handlers.GetRecentReviews(rr, req)
 
 		s.Equal(http.StatusBadRequest, rr.Code)
-		s.Contains(rr.Body.String(), "Invalid app ID")
+		s.Contains(rr.Body.String(), "invalid app id")
 	})
 
-	s.Run("should return bad request when URL pattern does not match", func() {
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/invalid/path", nil)
+	s.Run("should return bad request when the app ID leaves the workspace directory", func() {
+		req := s.newRequest("../b/123")
 		rr := httptest.NewRecorder()
 
 		s.handlers.GetRecentReviews(rr, req)
 
 		s.Equal(http.StatusBadRequest, rr.Code)
-		s.Contains(rr.Body.String(), "Invalid app ID")
 	})
 
 	s.Run("should return internal server error when use case fails", func() {
 		appID := "12345"
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return(nil, assert.AnError)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(nil, assert.AnError)
 
 		s.handlers.GetRecentReviews(rr, req)
 
 		s.Equal(http.StatusInternalServerError, rr.Code)
-		s.Contains(rr.Body.String(), "assert.AnError general error for testing")
+		s.NotContains(rr.Body.String(), assert.AnError.Error())
+	})
+
+	s.Run("should return gateway timeout when the request deadline passes", func() {
+		appID := "12345"
+		req := s.newRequest(appID)
+		rr := httptest.NewRecorder()
+
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(nil, context.DeadlineExceeded)
+
+		s.handlers.GetRecentReviews(rr, req)
+
+		s.Equal(http.StatusGatewayTimeout, rr.Code)
 	})
 
 	s.Run("should format submitted time correctly", func() {
@@ -166,10 +198,10 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 			},
 		}
 
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return(expectedReviews, nil)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(expectedReviews, nil)
 
 		s.handlers.GetRecentReviews(rr, req)
 
@@ -180,14 +212,41 @@ func (s *GetRecentReviewsHandlerTestSuite) TestGetRecentReviews() {
 		s.NoError(err)
 		s.Len(response.Reviews, 1)
 		s.Equal("2025-01-01T12:00:00Z", response.Reviews[0].SubmittedAt)
+		s.Equal("none", response.Reviews[0].ReplyStatus)
+	})
+
+	s.Run("should include the developer reply", func() {
+		appID := "12345"
+		expectedReviews := []*review.Review{
+			{
+				ID:          "review1",
+				AppID:       appID,
+				Score:       5,
+				SubmittedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+				Reply:       &review.Reply{Content: "Thanks!", State: review.ReplyPublished, UpdatedAt: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+			},
+		}
+
+		req := s.newRequest(appID)
+		rr := httptest.NewRecorder()
+
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return(expectedReviews, nil)
+
+		s.handlers.GetRecentReviews(rr, req)
+
+		var response infrahttp.ReviewsResponse
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+		s.Require().Len(response.Reviews, 1)
+		s.Equal("published", response.Reviews[0].ReplyStatus)
+		s.Equal(&infrahttp.ReplyResponse{Content: "Thanks!", State: "published", UpdatedAt: "2025-01-02T09:00:00Z"}, response.Reviews[0].Reply)
 	})
 
 	s.Run("should handle special characters in app ID", func() {
 		appID := "app-123_test"
-		req := httptest.NewRequest(http.MethodGet, "/api/v1/app/"+appID+"/reviews/recent", nil)
+		req := s.newRequest(appID)
 		rr := httptest.NewRecorder()
 
-		s.mockGetRecentReviewsUseCase.EXPECT().Execute(appID).Return([]*review.Review{}, nil)
+		s.mockGetRecentReviewsUseCase.EXPECT().Execute(mock.Anything, appID).Return([]*review.Review{}, nil)
 
 		s.handlers.GetRecentReviews(rr, req)
 
@@ -0,0 +1,253 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+const defaultFeedLimit = 100
+
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type AtomEntry struct {
+	ID      string     `xml:"id"`
+	Title   string     `xml:"title"`
+	Updated string     `xml:"updated"`
+	Author  AtomAuthor `xml:"author"`
+	Content AtomText   `xml:"content"`
+}
+
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type RSSFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	GUID        RSSGUID `xml:"guid"`
+	Title       string  `xml:"title"`
+	Description string  `xml:"description"`
+	Creator     string  `xml:"dc:creator"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (h *Handlers) GetReviewsAtom(w http.ResponseWriter, r *http.Request) {
+	h.serveReviewsFeed(w, r, "application/atom+xml; charset=utf-8", renderAtomFeed)
+}
+
+func (h *Handlers) GetReviewsRSS(w http.ResponseWriter, r *http.Request) {
+	h.serveReviewsFeed(w, r, "application/rss+xml; charset=utf-8", renderRSSFeed)
+}
+
+type feedRenderer func(appID, selfURL string, updated time.Time, reviews []*review.Review) any
+
+func (h *Handlers) serveReviewsFeed(w http.ResponseWriter, r *http.Request, contentType string, render feedRenderer) {
+	appID := r.PathValue("id")
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	filter, err := parseFeedFilter(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviews, err := h.listReviewsUseCase.Execute(r.Context(), appID, filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	updated := feedUpdatedAt(reviews)
+
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	if err := xml.NewEncoder(&body).Encode(render(appID, h.requestURL(r), updated, reviews)); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	sum := sha256.Sum256(body.Bytes())
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+
+	http.ServeContent(w, r, "", updated, bytes.NewReader(body.Bytes()))
+}
+
+func parseFeedFilter(r *http.Request) (listreviews.Filter, error) {
+	filter := listreviews.Filter{Limit: defaultFeedLimit}
+	query := r.URL.Query()
+
+	var err error
+	if filter.MinScore, err = parseScore(query.Get("minScore")); err != nil {
+		return filter, errors.New("invalid minScore")
+	}
+	if filter.MaxScore, err = parseScore(query.Get("maxScore")); err != nil {
+		return filter, errors.New("invalid maxScore")
+	}
+
+	if value := query.Get("since"); value != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, value); err != nil {
+			return filter, errors.New("invalid since")
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil || filter.Limit <= 0 {
+			return filter, errors.New("invalid limit")
+		}
+	}
+
+	return filter, nil
+}
+
+func parseScore(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	score, err := strconv.Atoi(value)
+	if err != nil || score < 1 || score > 5 {
+		return 0, fmt.Errorf("invalid score %q", value)
+	}
+
+	return score, nil
+}
+
+// feedUpdatedAt returns the most recent submission time so the feed timestamp
+// only moves when its content does. Empty feeds use the Unix epoch.
+func feedUpdatedAt(reviews []*review.Review) time.Time {
+	updated := time.Unix(0, 0).UTC()
+	for _, review := range reviews {
+		if review.SubmittedAt.After(updated) {
+			updated = review.SubmittedAt
+		}
+	}
+	return updated.UTC().Truncate(time.Second)
+}
+
+func (h *Handlers) requestURL(r *http.Request) string {
+	if h.baseURL != "" {
+		return h.baseURL + r.URL.RequestURI()
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+func feedID(appID string) string {
+	return "urn:appstorereviewsviewer:app:" + appID
+}
+
+func entryID(review *review.Review) string {
+	return "urn:appstorereviewsviewer:app:" + review.AppID + ":review:" + review.ID
+}
+
+func entryTitle(review *review.Review) string {
+	stars := min(max(review.Score, 0), 5)
+	return fmt.Sprintf("%s%s %s", strings.Repeat("★", stars), strings.Repeat("☆", 5-stars), review.Author)
+}
+
+func renderAtomFeed(appID, selfURL string, updated time.Time, reviews []*review.Review) any {
+	feed := AtomFeed{
+		ID:      feedID(appID),
+		Title:   "Reviews for app " + appID,
+		Updated: updated.Format(time.RFC3339),
+		Link:    []AtomLink{{Rel: "self", Href: selfURL}},
+		Entries: make([]AtomEntry, len(reviews)),
+	}
+
+	for i, review := range reviews {
+		feed.Entries[i] = AtomEntry{
+			ID:      entryID(review),
+			Title:   entryTitle(review),
+			Updated: review.SubmittedAt.UTC().Format(time.RFC3339),
+			Author:  AtomAuthor{Name: review.Author},
+			Content: AtomText{Type: "text", Body: review.Content},
+		}
+	}
+
+	return feed
+}
+
+func renderRSSFeed(appID, selfURL string, updated time.Time, reviews []*review.Review) any {
+	feed := RSSFeed{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: RSSChannel{
+			Title:         "Reviews for app " + appID,
+			Link:          selfURL,
+			Description:   "App Store reviews stored for app " + appID,
+			LastBuildDate: updated.Format(time.RFC1123Z),
+			Items:         make([]RSSItem, len(reviews)),
+		},
+	}
+
+	for i, review := range reviews {
+		feed.Channel.Items[i] = RSSItem{
+			GUID:        RSSGUID{Value: entryID(review)},
+			Title:       entryTitle(review),
+			Description: review.Content,
+			Creator:     review.Author,
+			PubDate:     review.SubmittedAt.UTC().Format(time.RFC1123Z),
+		}
+	}
+
+	return feed
+}
@@ -0,0 +1,216 @@
+package http_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/domain/review"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
+	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetReviewsFeedHandlerTestSuite struct {
+	suite.Suite
+	mockAddAppUseCase           *addappmocks.UseCase
+	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
+	handlers                    *infrahttp.Handlers
+}
+
+func (s *GetReviewsFeedHandlerTestSuite) SetupSubTest() {
+	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
+	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}, "")
+}
+
+func (s *GetReviewsFeedHandlerTestSuite) newRequest(method, target, appID string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	req.SetPathValue("id", appID)
+	return req
+}
+
+func (s *GetReviewsFeedHandlerTestSuite) storedReviews() []*review.Review {
+	return []*review.Review{
+		{
+			ID:          "review2",
+			AppID:       "12345",
+			Author:      "Jane Smith",
+			Content:     "Crashes on launch\nafter the update",
+			Score:       1,
+			SubmittedAt: time.Date(2025, 1, 2, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:          "review1",
+			AppID:       "12345",
+			Author:      "John Doe",
+			Content:     "Great app!",
+			Score:       5,
+			SubmittedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (s *GetReviewsFeedHandlerTestSuite) TestGetReviewsAtom() {
+	s.Run("should render stored reviews as an Atom feed", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom?maxScore=5", "12345")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().
+			Execute(mock.Anything, "12345", listreviews.Filter{MaxScore: 5, Limit: 100}).
+			Return(s.storedReviews(), nil)
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/atom+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+		s.NotEmpty(rr.Header().Get("ETag"))
+		s.Equal("Thu, 02 Jan 2025 09:30:00 GMT", rr.Header().Get("Last-Modified"))
+
+		var feed infrahttp.AtomFeed
+		s.Require().NoError(xml.Unmarshal(rr.Body.Bytes(), &feed))
+		s.Equal("http://www.w3.org/2005/Atom", feed.XMLName.Space)
+		s.Equal("urn:appstorereviewsviewer:app:12345", feed.ID)
+		s.Equal("2025-01-02T09:30:00Z", feed.Updated)
+		s.Require().Len(feed.Entries, 2)
+		s.Equal("urn:appstorereviewsviewer:app:12345:review:review2", feed.Entries[0].ID)
+		s.Equal("Jane Smith", feed.Entries[0].Author.Name)
+		s.Equal("Crashes on launch\nafter the update", feed.Entries[0].Content.Body)
+		s.Equal("★☆☆☆☆ Jane Smith", feed.Entries[0].Title)
+	})
+
+	s.Run("should link to the configured base URL", func() {
+		handlers := infrahttp.NewHandlers(infrahttp.UseCases{
+			GetRecentReviews: s.mockGetRecentReviewsUseCase,
+			AddApp:           s.mockAddAppUseCase,
+			ListReviews:      s.mockListReviewsUseCase,
+			ExportReviews:    s.mockExportReviewsUseCase,
+			ImportReviews:    s.mockImportReviewsUseCase,
+		}, "https://reviews.example.com")
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom?limit=5", "12345")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().Execute(mock.Anything, "12345", listreviews.Filter{Limit: 5}).Return(nil, nil)
+
+		handlers.GetReviewsAtom(rr, req)
+
+		var feed infrahttp.AtomFeed
+		s.Require().NoError(xml.Unmarshal(rr.Body.Bytes(), &feed))
+		s.Require().Len(feed.Link, 1)
+		s.Equal("https://reviews.example.com/api/v1/app/12345/reviews.atom?limit=5", feed.Link[0].Href)
+	})
+
+	s.Run("should use the epoch as updated time for an empty feed", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom", "12345")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().Execute(mock.Anything, "12345", listreviews.Filter{Limit: 100}).Return(nil, nil)
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+
+		var feed infrahttp.AtomFeed
+		s.Require().NoError(xml.Unmarshal(rr.Body.Bytes(), &feed))
+		s.Equal("1970-01-01T00:00:00Z", feed.Updated)
+		s.Empty(feed.Entries)
+	})
+
+	s.Run("should return not modified when ETag matches", func() {
+		first := httptest.NewRecorder()
+		s.mockListReviewsUseCase.EXPECT().Execute(mock.Anything, "12345", listreviews.Filter{Limit: 100}).Return(s.storedReviews(), nil).Twice()
+		s.handlers.GetReviewsAtom(first, s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom", "12345"))
+
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom", "12345")
+		req.Header.Set("If-None-Match", first.Header().Get("ETag"))
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusNotModified, rr.Code)
+		s.Empty(rr.Body.String())
+	})
+
+	s.Run("should return not modified when feed has not changed since", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom", "12345")
+		req.Header.Set("If-Modified-Since", "Thu, 02 Jan 2025 09:30:00 GMT")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().Execute(mock.Anything, "12345", listreviews.Filter{Limit: 100}).Return(s.storedReviews(), nil)
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusNotModified, rr.Code)
+	})
+
+	s.Run("should return bad request when maxScore is invalid", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom?maxScore=9", "12345")
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "invalid maxScore")
+	})
+
+	s.Run("should return internal server error when use case fails", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.atom", "12345")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().Execute(mock.Anything, "12345", listreviews.Filter{Limit: 100}).Return(nil, assert.AnError)
+
+		s.handlers.GetReviewsAtom(rr, req)
+
+		s.Equal(http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func (s *GetReviewsFeedHandlerTestSuite) TestGetReviewsRSS() {
+	s.Run("should render stored reviews as an RSS feed", func() {
+		req := s.newRequest(http.MethodGet, "/api/v1/app/12345/reviews.rss?since=2025-01-01T00:00:00Z&limit=10", "12345")
+		rr := httptest.NewRecorder()
+
+		s.mockListReviewsUseCase.EXPECT().
+			Execute(mock.Anything, "12345", listreviews.Filter{Since: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Limit: 10}).
+			Return(s.storedReviews(), nil)
+
+		s.handlers.GetReviewsRSS(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/rss+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+
+		var feed infrahttp.RSSFeed
+		s.Require().NoError(xml.Unmarshal(rr.Body.Bytes(), &feed))
+		s.Equal("2.0", feed.Version)
+		s.Equal("Thu, 02 Jan 2025 09:30:00 +0000", feed.Channel.LastBuildDate)
+		s.Require().Len(feed.Channel.Items, 2)
+		s.Equal("urn:appstorereviewsviewer:app:12345:review:review1", feed.Channel.Items[1].GUID.Value)
+		s.False(feed.Channel.Items[1].GUID.IsPermaLink)
+		s.Equal("Wed, 01 Jan 2025 12:00:00 +0000", feed.Channel.Items[1].PubDate)
+	})
+}
+
+func TestGetReviewsFeedHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GetReviewsFeedHandlerTestSuite))
+}
@@ -2,17 +2,71 @@ package http
 
 import (
 	"appstorereviewsviewer/internal/application/addapp"
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/application/appstatus"
+	"appstorereviewsviewer/internal/application/exportreviews"
 	"appstorereviewsviewer/internal/application/getrecentreviews"
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/application/jobs"
+	"appstorereviewsviewer/internal/application/listreviews"
+	"appstorereviewsviewer/internal/application/previewreplytemplate"
+	"appstorereviewsviewer/internal/application/removeapp"
+	"appstorereviewsviewer/internal/application/replyreview"
+	"appstorereviewsviewer/internal/application/replytemplates"
+	"appstorereviewsviewer/internal/application/workspaces"
 )
 
+// UseCases bundles the application use cases served over HTTP.
+type UseCases struct {
+	GetRecentReviews getrecentreviews.UseCase
+	AddApp           addapp.UseCase
+	ListReviews      listreviews.UseCase
+	ExportReviews    exportreviews.UseCase
+	ImportReviews    importreviews.UseCase
+	AppStatus        appstatus.UseCase
+	ReplyReview      replyreview.UseCase
+	ReplyTemplates   replytemplates.UseCase
+	// PreviewReplyTemplate renders saved reply templates for a review.
+	PreviewReplyTemplate previewreplytemplate.UseCase
+	RemoveApp            removeapp.UseCase
+	APIKeys              apikeys.UseCase
+	Workspaces           workspaces.UseCase
+	// Jobs reports on background jobs, such as fetching a new app's reviews.
+	Jobs jobs.UseCase
+}
+
 type Handlers struct {
-	getRecentReviewsUseCase getrecentreviews.UseCase
-	addAppUseCase           addapp.UseCase
+	getRecentReviewsUseCase     getrecentreviews.UseCase
+	addAppUseCase               addapp.UseCase
+	listReviewsUseCase          listreviews.UseCase
+	exportReviewsUseCase        exportreviews.UseCase
+	importReviewsUseCase        importreviews.UseCase
+	appStatusUseCase            appstatus.UseCase
+	replyReviewUseCase          replyreview.UseCase
+	replyTemplatesUseCase       replytemplates.UseCase
+	previewReplyTemplateUseCase previewreplytemplate.UseCase
+	removeAppUseCase            removeapp.UseCase
+	apiKeysUseCase              apikeys.UseCase
+	workspacesUseCase           workspaces.UseCase
+	jobsUseCase                 jobs.UseCase
+	baseURL                     string
 }
 
-func NewHandlers(getRecentReviewsUseCase getrecentreviews.UseCase, addAppUseCase addapp.UseCase) *Handlers {
+func NewHandlers(useCases UseCases, baseURL string) *Handlers {
 	return &Handlers{
-		getRecentReviewsUseCase: getRecentReviewsUseCase,
-		addAppUseCase:           addAppUseCase,
+		getRecentReviewsUseCase:     useCases.GetRecentReviews,
+		addAppUseCase:               useCases.AddApp,
+		listReviewsUseCase:          useCases.ListReviews,
+		exportReviewsUseCase:        useCases.ExportReviews,
+		importReviewsUseCase:        useCases.ImportReviews,
+		appStatusUseCase:            useCases.AppStatus,
+		replyReviewUseCase:          useCases.ReplyReview,
+		replyTemplatesUseCase:       useCases.ReplyTemplates,
+		previewReplyTemplateUseCase: useCases.PreviewReplyTemplate,
+		removeAppUseCase:            useCases.RemoveApp,
+		apiKeysUseCase:              useCases.APIKeys,
+		workspacesUseCase:           useCases.Workspaces,
+		jobsUseCase:                 useCases.Jobs,
+		baseURL:                     baseURL,
 	}
 }
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/domain/app"
+)
+
+const maxImportMemory = 32 << 20
+
+var importFormatsByExtension = map[string]importreviews.Format{
+	".csv":    importreviews.FormatCSV,
+	".jsonl":  importreviews.FormatJSONL,
+	".ndjson": importreviews.FormatJSONL,
+}
+
+func (h *Handlers) ImportReviews(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("id")
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxImportMemory); err != nil {
+		writeBodyError(w, r, err, "Invalid multipart body")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	options := importreviews.Options{}
+
+	format := r.FormValue("format")
+	if format == "" {
+		options.Format = importFormatsByExtension[strings.ToLower(filepath.Ext(header.Filename))]
+	} else if options.Format, err = importreviews.ParseFormat(format); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if options.Format == "" {
+		writeProblem(w, r, http.StatusBadRequest, "format is required when it cannot be inferred from the file name")
+		return
+	}
+
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &options.Mapping); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "mapping must be a JSON object of field to column name")
+			return
+		}
+	}
+
+	if dryRun := r.FormValue("dryRun"); dryRun != "" {
+		if options.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, "invalid dryRun")
+			return
+		}
+	}
+
+	result, err := h.importReviewsUseCase.Execute(r.Context(), file, appID, options)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Error("failed to write response", "path", r.URL.Path, "error", err)
+	}
+}
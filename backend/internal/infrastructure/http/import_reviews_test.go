@@ -0,0 +1,185 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/fault"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
+	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportReviewsHandlerTestSuite struct {
+	suite.Suite
+	mockAddAppUseCase           *addappmocks.UseCase
+	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
+	handlers                    *infrahttp.Handlers
+}
+
+func (s *ImportReviewsHandlerTestSuite) SetupSubTest() {
+	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
+	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}, "")
+}
+
+func (s *ImportReviewsHandlerTestSuite) newRequest(fileName, content string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		s.Require().NoError(writer.WriteField(name, value))
+	}
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		s.Require().NoError(err)
+		_, err = io.WriteString(part, content)
+		s.Require().NoError(err)
+	}
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/app/12345/reviews/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+func (s *ImportReviewsHandlerTestSuite) TestImportReviews() {
+	s.Run("should import uploaded file and return the report", func() {
+		req := s.newRequest("history.csv", "id,score\n", map[string]string{
+			"mapping": `{"score":"Stars"}`,
+			"dryRun":  "true",
+		})
+		rr := httptest.NewRecorder()
+
+		expectedOptions := importreviews.Options{
+			Format:  importreviews.FormatCSV,
+			Mapping: map[string]string{"score": "Stars"},
+			DryRun:  true,
+		}
+		s.mockImportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", expectedOptions).
+			RunAndReturn(func(_ context.Context, r io.Reader, _ string, _ importreviews.Options) (*importreviews.Result, error) {
+				content, err := io.ReadAll(r)
+				s.Require().NoError(err)
+				s.Equal("id,score\n", string(content))
+				return &importreviews.Result{
+					DryRun:   true,
+					Total:    2,
+					Imported: 1,
+					Invalid:  1,
+					Errors:   []importreviews.RowError{{Row: 3, Message: "id is required"}},
+				}, nil
+			})
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/json", rr.Header().Get("Content-Type"))
+
+		var result importreviews.Result
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &result))
+		s.True(result.DryRun)
+		s.Equal(1, result.Imported)
+		s.Equal([]importreviews.RowError{{Row: 3, Message: "id is required"}}, result.Errors)
+	})
+
+	s.Run("should use explicit format over the file extension", func() {
+		req := s.newRequest("export.txt", "{}\n", map[string]string{"format": "jsonl"})
+		rr := httptest.NewRecorder()
+
+		s.mockImportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", importreviews.Options{Format: importreviews.FormatJSONL}).
+			Return(&importreviews.Result{Errors: []importreviews.RowError{}}, nil)
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+	})
+
+	s.Run("should return bad request when format cannot be inferred", func() {
+		req := s.newRequest("export.txt", "", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "format is required")
+	})
+
+	s.Run("should return bad request when file is missing", func() {
+		req := s.newRequest("", "", map[string]string{"format": "csv"})
+		rr := httptest.NewRecorder()
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "file is required")
+	})
+
+	s.Run("should return bad request when mapping is not a JSON object", func() {
+		req := s.newRequest("history.csv", "", map[string]string{"mapping": "score=Stars"})
+		rr := httptest.NewRecorder()
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	s.Run("should return bad request when the file cannot be imported", func() {
+		req := s.newRequest("history.csv", "", nil)
+		rr := httptest.NewRecorder()
+
+		s.mockImportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", importreviews.Options{Format: importreviews.FormatCSV}).
+			Return(nil, fault.New(fault.KindValidation, "missing required CSV column \"content\""))
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Equal(`missing required CSV column "content"`, problemOf(s.T(), rr).Detail)
+	})
+
+	s.Run("should return not found for untracked apps", func() {
+		req := s.newRequest("history.csv", "id,content\nr1,Hi\n", nil)
+		rr := httptest.NewRecorder()
+
+		s.mockImportReviewsUseCase.EXPECT().
+			Execute(mock.Anything, mock.Anything, "12345", importreviews.Options{Format: importreviews.FormatCSV}).
+			Return(nil, fmt.Errorf("%w: 12345", app.ErrNotFound))
+
+		s.handlers.ImportReviews(rr, req)
+
+		s.Equal(http.StatusNotFound, rr.Code)
+		s.Equal("app not found: 12345", problemOf(s.T(), rr).Detail)
+	})
+}
+
+func TestImportReviewsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportReviewsHandlerTestSuite))
+}
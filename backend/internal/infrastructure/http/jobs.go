@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/job"
+)
+
+type JobResponse struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	AppID  string `json:"appId"`
+	Status string `json:"status"`
+	// ReviewsFetched counts the new and changed reviews the job stored.
+	ReviewsFetched int        `json:"reviewsFetched"`
+	NewReviews     int        `json:"newReviews"`
+	UpdatedReviews int        `json:"updatedReviews"`
+	Error          string     `json:"error,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	StartedAt      *time.Time `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+}
+
+func newJobResponse(j *job.Job) JobResponse {
+	response := JobResponse{
+		ID:             j.ID,
+		Kind:           j.Kind,
+		AppID:          j.AppID,
+		Status:         string(j.Status),
+		ReviewsFetched: j.NewReviews + j.UpdatedReviews,
+		NewReviews:     j.NewReviews,
+		UpdatedReviews: j.UpdatedReviews,
+		Error:          j.Error,
+		CreatedAt:      j.CreatedAt,
+	}
+	if !j.StartedAt.IsZero() {
+		response.StartedAt = &j.StartedAt
+	}
+	if !j.FinishedAt.IsZero() {
+		response.FinishedAt = &j.FinishedAt
+	}
+	return response
+}
+
+// GetJob reports the progress of a background job of the workspace.
+func (h *Handlers) GetJob(w http.ResponseWriter, r *http.Request) {
+	j, err := h.jobsUseCase.Get(r.Context(), r.PathValue("jobId"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newJobResponse(j))
+}
@@ -0,0 +1,86 @@
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/job"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	jobsmocks "appstorereviewsviewer/mocks/application/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobsHandlerTestSuite struct {
+	suite.Suite
+	mockJobsUseCase *jobsmocks.UseCase
+	handlers        *infrahttp.Handlers
+}
+
+func (s *JobsHandlerTestSuite) SetupSubTest() {
+	s.mockJobsUseCase = jobsmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{Jobs: s.mockJobsUseCase}, "")
+}
+
+func (s *JobsHandlerTestSuite) get(id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs/"+id, nil)
+	req.SetPathValue("jobId", id)
+	rr := httptest.NewRecorder()
+	s.handlers.GetJob(rr, req)
+	return rr
+}
+
+func (s *JobsHandlerTestSuite) TestGetJob() {
+	s.Run("should report the progress and outcome of the job", func() {
+		created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		s.mockJobsUseCase.EXPECT().Get(mock.Anything, "j1").Return(&job.Job{
+			ID:             "j1",
+			Kind:           job.KindBackfill,
+			AppID:          "12345",
+			Status:         job.StatusFailed,
+			NewReviews:     2,
+			UpdatedReviews: 1,
+			Error:          "feed unavailable",
+			CreatedAt:      created,
+			StartedAt:      created.Add(time.Second),
+			FinishedAt:     created.Add(2 * time.Second),
+		}, nil)
+
+		rr := s.get("j1")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{
+			"id": "j1",
+			"kind": "backfill",
+			"appId": "12345",
+			"status": "failed",
+			"reviewsFetched": 3,
+			"newReviews": 2,
+			"updatedReviews": 1,
+			"error": "feed unavailable",
+			"createdAt": "2026-01-02T03:04:05Z",
+			"startedAt": "2026-01-02T03:04:06Z",
+			"finishedAt": "2026-01-02T03:04:07Z"
+		}`, rr.Body.String())
+	})
+
+	s.Run("should return not found for unknown jobs", func() {
+		s.mockJobsUseCase.EXPECT().Get(mock.Anything, "missing").Return(nil, fmt.Errorf("%w: missing", job.ErrNotFound))
+
+		s.Equal(http.StatusNotFound, s.get("missing").Code)
+	})
+
+	s.Run("should return internal server error when the lookup fails", func() {
+		s.mockJobsUseCase.EXPECT().Get(mock.Anything, "j1").Return(nil, assert.AnError)
+
+		s.Equal(http.StatusInternalServerError, s.get("j1").Code)
+	})
+}
+
+func TestJobsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(JobsHandlerTestSuite))
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	_ "embed"
+	"net/http"
+
+	"appstorereviewsviewer/internal/infrastructure/openapi"
+)
+
+// openAPISpec describes every route of the API. Requests are validated
+// against it, so it must be kept in step with the handlers.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// APIDocument is the parsed OpenAPI document of the API.
+var APIDocument = openapi.MustParse(openAPISpec)
+
+// GetOpenAPISpec serves the OpenAPI document of the API.
+func (h *Handlers) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	serveDocument(w, "application/json", openAPISpec)
+}
+
+// GetAPIDocs serves a page browsing the OpenAPI document.
+func (h *Handlers) GetAPIDocs(w http.ResponseWriter, r *http.Request) {
+	serveDocument(w, "text/html; charset=utf-8", docsPage)
+}
+
+func serveDocument(w http.ResponseWriter, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(body)
+}
+
+// ValidateRequests rejects requests whose parameters or JSON body do not
+// match the operation the document describes for them, before they reach
+// next. It runs after LimitBody so that validation reads a bounded body.
+func ValidateRequests(document *openapi.Document, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := document.ValidateRequest(r); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
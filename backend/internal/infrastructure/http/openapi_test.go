@@ -0,0 +1,293 @@
+package http_test
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/importreviews"
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/workspace"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	"appstorereviewsviewer/internal/infrastructure/metrics"
+	"appstorereviewsviewer/internal/infrastructure/openapi"
+	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	apikeysmocks "appstorereviewsviewer/mocks/application/apikeys"
+	workspacesmocks "appstorereviewsviewer/mocks/application/workspaces"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// documentedTypes maps every schema of the OpenAPI document to the Go type
+// the handlers read or write for it.
+var documentedTypes = map[string]reflect.Type{
+	"Problem":                reflect.TypeFor[infrahttp.Problem](),
+	"AddAppRequest":          reflect.TypeFor[infrahttp.AddAppRequest](),
+	"AddAppSource":           reflect.TypeFor[infrahttp.AddAppSourceRequest](),
+	"Review":                 reflect.TypeFor[infrahttp.ReviewResponse](),
+	"Reply":                  reflect.TypeFor[infrahttp.ReplyResponse](),
+	"ReplyRevision":          reflect.TypeFor[infrahttp.ReplyRevisionResponse](),
+	"ReviewList":             reflect.TypeFor[infrahttp.ReviewsResponse](),
+	"ReplyRequest":           reflect.TypeFor[infrahttp.ReplyRequest](),
+	"Job":                    reflect.TypeFor[infrahttp.JobResponse](),
+	"AppStatus":              reflect.TypeFor[infrahttp.AppStatusResponse](),
+	"AppHealth":              reflect.TypeFor[reloadreviews.AppHealth](),
+	"FetchStats":             reflect.TypeFor[review.FetchStats](),
+	"ImportResult":           reflect.TypeFor[importreviews.Result](),
+	"ImportRowError":         reflect.TypeFor[importreviews.RowError](),
+	"ReplyTemplateRequest":   reflect.TypeFor[infrahttp.ReplyTemplateRequest](),
+	"ReplyTemplate":          reflect.TypeFor[infrahttp.ReplyTemplateResponse](),
+	"ReplyTemplateList":      reflect.TypeFor[infrahttp.ReplyTemplatesResponse](),
+	"ReplyTemplatePreview":   reflect.TypeFor[infrahttp.ReplyTemplatePreviewResponse](),
+	"CreateWorkspaceRequest": reflect.TypeFor[infrahttp.CreateWorkspaceRequest](),
+	"Workspace":              reflect.TypeFor[infrahttp.WorkspaceResponse](),
+	"WorkspaceList":          reflect.TypeFor[infrahttp.WorkspacesResponse](),
+	"CreateAPIKeyRequest":    reflect.TypeFor[infrahttp.CreateAPIKeyRequest](),
+	"APIKey":                 reflect.TypeFor[infrahttp.APIKeyResponse](),
+	"APIKeyList":             reflect.TypeFor[infrahttp.APIKeysResponse](),
+}
+
+type jsonField struct {
+	typ       reflect.Type
+	omitempty bool
+}
+
+func jsonFields(typ reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = jsonField{typ: field.Type, omitempty: strings.Contains(options, "omitempty")}
+	}
+	return fields
+}
+
+// jsonType is the JSON Schema type encoding/json writes for a Go type.
+func jsonType(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == reflect.TypeFor[time.Time]() {
+		return "string"
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func deref(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func schemaName(schema *openapi.Schema) string {
+	return strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+}
+
+type OpenAPITestSuite struct {
+	suite.Suite
+	mockAddAppUseCase     *addappmocks.UseCase
+	mockAPIKeysUseCase    *apikeysmocks.UseCase
+	mockWorkspacesUseCase *workspacesmocks.UseCase
+}
+
+func (s *OpenAPITestSuite) SetupSubTest() {
+	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
+	s.mockAPIKeysUseCase = apikeysmocks.NewUseCase(s.T())
+	s.mockWorkspacesUseCase = workspacesmocks.NewUseCase(s.T())
+	s.mockWorkspacesUseCase.EXPECT().Get(mock.Anything, workspace.DefaultID).
+		Return(&workspace.Workspace{ID: workspace.DefaultID}, nil).Maybe()
+}
+
+func (s *OpenAPITestSuite) newServer(config infrahttp.Config) *infrahttp.Server {
+	return infrahttp.NewServer(infrahttp.UseCases{
+		AddApp:     s.mockAddAppUseCase,
+		APIKeys:    s.mockAPIKeysUseCase,
+		Workspaces: s.mockWorkspacesUseCase,
+	}, config)
+}
+
+func (s *OpenAPITestSuite) serve(server *infrahttp.Server, method, target, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rr, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rr
+}
+
+// documentedURLs returns a URL for every server of a documented path, with
+// its wildcards filled in.
+func documentedURLs(path string, item *openapi.PathItem) []string {
+	servers := item.Servers
+	if len(servers) == 0 {
+		servers = infrahttp.APIDocument.Servers
+	}
+	wildcards := regexp.MustCompile(`\{[^}]+\}`)
+	var urls []string
+	for _, server := range servers {
+		url := strings.ReplaceAll(strings.TrimSuffix(server.URL, "/")+path, "{workspace}", workspace.DefaultID)
+		urls = append(urls, wildcards.ReplaceAllString(url, "x1"))
+	}
+	return urls
+}
+
+func (s *OpenAPITestSuite) TestDocument() {
+	s.Run("should describe every schema with the Go type handlers use", func() {
+		for name, schema := range infrahttp.APIDocument.Components.Schemas {
+			typ, ok := documentedTypes[name]
+			if !s.True(ok, "schema %s has no Go type", name) {
+				continue
+			}
+
+			fields := jsonFields(typ)
+			s.ElementsMatch(slices.Collect(maps.Keys(fields)), slices.Collect(maps.Keys(schema.Properties)), "properties of %s", name)
+
+			isRequest := strings.HasSuffix(typ.Name(), "Request")
+			for property, propertySchema := range schema.Properties {
+				field, ok := fields[property]
+				if !ok {
+					continue
+				}
+				if !isRequest {
+					s.Equal(!field.omitempty, slices.Contains(schema.Required, property), "%s.%s is required exactly when it is always written", name, property)
+				}
+
+				resolved := propertySchema.Resolved()
+				s.Equal(jsonType(field.typ), resolved.Type[0], "type of %s.%s", name, property)
+				if ref := schemaName(propertySchema); ref != "" {
+					s.Equal(documentedTypes[ref], deref(field.typ), "%s.%s", name, property)
+				}
+				if resolved.Items != nil {
+					if ref := schemaName(resolved.Items); ref != "" {
+						s.Equal(documentedTypes[ref], deref(field.typ).Elem(), "items of %s.%s", name, property)
+					}
+				}
+			}
+		}
+	})
+
+	s.Run("should route exactly the documented operations", func() {
+		var documented []infrahttp.Route
+		for path, item := range infrahttp.APIDocument.Paths {
+			servers := item.Servers
+			if len(servers) == 0 {
+				servers = infrahttp.APIDocument.Servers
+			}
+			for method := range item.Operations() {
+				for _, server := range servers {
+					documented = append(documented, infrahttp.Route{Method: method, Pattern: strings.TrimSuffix(server.URL, "/") + path})
+				}
+			}
+		}
+
+		s.ElementsMatch(documented, s.newServer(infrahttp.Config{Metrics: metrics.New()}).Routes())
+	})
+
+	s.Run("should reject methods that are not documented", func() {
+		server := s.newServer(infrahttp.Config{Metrics: metrics.New()})
+
+		for path, item := range infrahttp.APIDocument.Paths {
+			operations := item.Operations()
+			for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch} {
+				if _, documented := operations[method]; documented {
+					continue
+				}
+				for _, url := range documentedURLs(path, item) {
+					rr := s.serve(server, method, url, "")
+
+					s.Equal(http.StatusMethodNotAllowed, rr.Code, "%s %s", method, url)
+					s.NotEmpty(rr.Header().Get("Allow"), "%s %s", method, url)
+				}
+			}
+		}
+	})
+}
+
+func (s *OpenAPITestSuite) TestServeDocument() {
+	s.Run("should serve the OpenAPI document without credentials", func() {
+		rr := s.serve(s.newServer(infrahttp.Config{AuthEnabled: true}), http.MethodGet, "/api/v1/openapi.json", "")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("application/json", rr.Header().Get("Content-Type"))
+		var document map[string]any
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &document))
+		s.Equal("3.1.0", document["openapi"])
+	})
+
+	s.Run("should serve the docs page", func() {
+		rr := s.serve(s.newServer(infrahttp.Config{AuthEnabled: true}), http.MethodGet, "/api/v1/docs", "")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		s.Contains(rr.Body.String(), "openapi.json")
+	})
+}
+
+func (s *OpenAPITestSuite) TestValidateRequests() {
+	s.Run("should reject requests that do not match the document", func() {
+		server := s.newServer(infrahttp.Config{})
+
+		rr := s.serve(server, http.MethodPost, "/api/v1/app", `{"appId": 12345}`)
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Equal("invalid request body: appId must be a string", problemOf(s.T(), rr).Detail)
+	})
+
+	s.Run("should reject invalid query parameters", func() {
+		server := s.newServer(infrahttp.Config{})
+
+		rr := s.serve(server, http.MethodGet, "/api/v1/workspaces/default/app/12345/reviews.atom?minScore=9", "")
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Equal(`invalid query parameter "minScore": must be at most 5`, problemOf(s.T(), rr).Detail)
+	})
+
+	s.Run("should pass valid requests on with their body", func() {
+		s.mockAddAppUseCase.EXPECT().Execute(mock.Anything, "12345", "", []app.Source(nil)).
+			Return(&job.Job{ID: "job-1", Kind: job.KindBackfill, AppID: "12345", Status: job.StatusQueued}, nil)
+		server := s.newServer(infrahttp.Config{})
+
+		rr := s.serve(server, http.MethodPost, "/api/v1/app", `{"appId": "12345"}`)
+
+		s.Equal(http.StatusAccepted, rr.Code)
+	})
+
+	s.Run("should validate bodies within the body limit", func() {
+		server := s.newServer(infrahttp.Config{MaxBodyBytes: 8})
+
+		rr := s.serve(server, http.MethodPost, "/api/v1/app", `{"appId": "12345"}`)
+
+		s.Equal(http.StatusRequestEntityTooLarge, rr.Code)
+	})
+}
+
+func TestOpenAPITestSuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. RequestID, also sent in the
+// X-Request-ID header, finds the request in the server log.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// statusByKind maps domain error kinds to the status answering them.
+var statusByKind = map[fault.Kind]int{
+	fault.KindValidation:  http.StatusBadRequest,
+	fault.KindNotFound:    http.StatusNotFound,
+	fault.KindConflict:    http.StatusConflict,
+	fault.KindUnavailable: http.StatusServiceUnavailable,
+}
+
+// errorStatus returns the status answering err: 504 when a use case stopped
+// because the request's context ended, so clients can tell a timeout from a
+// failure, the status of the error's kind, or 500.
+func errorStatus(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return http.StatusGatewayTimeout
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	if status, ok := statusByKind[fault.KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// writeError answers a request that failed with err. Errors without a kind
+// are unexpected: they are logged and their details kept from the client.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := errorStatus(err)
+	detail := err.Error()
+	if status == http.StatusInternalServerError {
+		slog.Error("request failed", "requestId", RequestIDFromContext(r.Context()), "method", r.Method, "path", r.URL.Path, "error", err)
+		detail = "the server failed to handle the request"
+	}
+	writeProblem(w, r, status, detail)
+}
+
+// writeBodyError answers a request whose body could not be read, telling
+// bodies over the size limit apart from malformed ones.
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	writeProblem(w, r, http.StatusBadRequest, message)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: RequestIDFromContext(r.Context()),
+	})
+}
@@ -0,0 +1,112 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/fault"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	removeappmocks "appstorereviewsviewer/mocks/application/removeapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// problemOf decodes the RFC 7807 body of an error response.
+func problemOf(t *testing.T, rr *httptest.ResponseRecorder) infrahttp.Problem {
+	t.Helper()
+	require.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+	var problem infrahttp.Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	return problem
+}
+
+type ProblemTestSuite struct {
+	suite.Suite
+	mockRemoveAppUseCase *removeappmocks.UseCase
+	handler              http.Handler
+}
+
+func (s *ProblemTestSuite) SetupSubTest() {
+	s.mockRemoveAppUseCase = removeappmocks.NewUseCase(s.T())
+	handlers := infrahttp.NewHandlers(infrahttp.UseCases{RemoveApp: s.mockRemoveAppUseCase}, "")
+	s.handler = infrahttp.RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.SetPathValue("id", "12345")
+		handlers.RemoveApp(w, r)
+	}))
+}
+
+func (s *ProblemTestSuite) remove(requestID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/app/12345", nil)
+	if requestID != "" {
+		req.Header.Set("X-Request-ID", requestID)
+	}
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *ProblemTestSuite) TestWriteError() {
+	s.Run("should answer with a problem naming the request", func() {
+		s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(fmt.Errorf("%w: 12345", app.ErrNotFound))
+
+		rr := s.remove("req-1")
+
+		s.Equal(http.StatusNotFound, rr.Code)
+		s.Equal("req-1", rr.Header().Get("X-Request-ID"))
+		s.Equal(infrahttp.Problem{
+			Type:      "about:blank",
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    "app not found: 12345",
+			Instance:  "/api/v1/app/12345",
+			RequestID: "req-1",
+		}, problemOf(s.T(), rr))
+	})
+
+	s.Run("should map error kinds to status codes", func() {
+		cases := map[error]int{
+			fault.New(fault.KindValidation, "bad input"):          http.StatusBadRequest,
+			fault.New(fault.KindNotFound, "missing"):              http.StatusNotFound,
+			fault.New(fault.KindConflict, "exists"):               http.StatusConflict,
+			fault.Wrap(fault.KindUnavailable, errors.New("down")): http.StatusServiceUnavailable,
+			fmt.Errorf("reload: %w", context.DeadlineExceeded):    http.StatusGatewayTimeout,
+			assert.AnError: http.StatusInternalServerError,
+		}
+		for err, status := range cases {
+			s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(err).Once()
+
+			s.Equal(status, s.remove("").Code, err.Error())
+		}
+	})
+
+	s.Run("should keep the details of unexpected errors from the client", func() {
+		s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(assert.AnError)
+
+		problem := problemOf(s.T(), s.remove(""))
+
+		s.Equal("the server failed to handle the request", problem.Detail)
+		s.NotEmpty(problem.RequestID)
+	})
+}
+
+func (s *ProblemTestSuite) TestRequestIDMiddleware() {
+	s.Run("should replace request IDs that are unsafe to log", func() {
+		s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(nil)
+
+		rr := s.remove("bad id\nwith newline")
+
+		s.Regexp(`^[0-9a-f]{16}$`, rr.Header().Get("X-Request-ID"))
+	})
+}
+
+func TestProblemTestSuite(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"appstorereviewsviewer/internal/infrastructure/ratelimit"
+)
+
+// RateLimiter gives every client a token bucket of its own. Authenticated
+// requests are counted per user, so each API key or token user has its own
+// budget; other requests are counted per remote address. It is safe for
+// concurrent use. A nil *RateLimiter never limits.
+type RateLimiter struct {
+	rate  float64
+	burst int
+	// idle is how long a client's bucket is kept after its last request.
+	// By then it has refilled completely, so dropping it changes nothing.
+	idle time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*rateLimitedClient
+	lastSweep time.Time
+}
+
+type rateLimitedClient struct {
+	bucket   *ratelimit.TokenBucket
+	lastSeen time.Time
+}
+
+// NewRateLimiter allows each client bursts of burst requests, refilled at
+// rate requests per second. It returns nil, which never limits, when rate is
+// not positive.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	burst = max(burst, 1)
+	refill := time.Duration(float64(burst) / rate * float64(time.Second))
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		idle:    max(refill, time.Minute),
+		now:     time.Now,
+		clients: make(map[string]*rateLimitedClient),
+	}
+}
+
+// Limit answers requests of clients that have used up their budget with
+// 429 Too Many Requests and a Retry-After header. Requests are counted per
+// user when it runs after authentication, and per remote address otherwise.
+// Requests failing authentication never reach it; LimitAddresses counts
+// those.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return l.limit(next, clientKey)
+}
+
+// LimitAddresses is Limit counting every request per remote address, even
+// authenticated ones. It runs before authentication so that clients cannot
+// guess credentials at an unlimited rate.
+func (l *RateLimiter) LimitAddresses(next http.Handler) http.Handler {
+	return l.limit(next, addressKey)
+}
+
+func (l *RateLimiter) limit(next http.Handler, key func(*http.Request) string) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket := l.bucket(key(r))
+		if !bucket.Allow() {
+			retryAfter := math.Ceil(bucket.RetryAfter().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(retryAfter), 1)))
+			writeProblem(w, r, http.StatusTooManyRequests, "rate limit exceeded, retry later")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *RateLimiter) bucket(key string) *ratelimit.TokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= l.idle {
+		for k, c := range l.clients {
+			if now.Sub(c.lastSeen) >= l.idle {
+				delete(l.clients, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &rateLimitedClient{bucket: ratelimit.NewTokenBucket(l.rate, l.burst)}
+		l.clients[key] = c
+	}
+	c.lastSeen = now
+	return c.bucket
+}
+
+func clientKey(r *http.Request) string {
+	if u, ok := UserFromContext(r.Context()); ok {
+		return "user:" + u.ID
+	}
+	return addressKey(r)
+}
+
+func addressKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// LimitBody fails reads of request bodies longer than limit bytes, so
+// handlers decoding them reject the request instead of buffering it.
+func LimitBody(limit int64, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
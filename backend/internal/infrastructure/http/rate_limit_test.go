@@ -0,0 +1,100 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/application/apikeys"
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/user"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	apikeysmocks "appstorereviewsviewer/mocks/application/apikeys"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func (s *RateLimitTestSuite) serve(handler http.Handler, remoteAddr, secret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil)
+	req.RemoteAddr = remoteAddr
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *RateLimitTestSuite) TestLimit() {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	s.Run("should reject requests over the burst with retry after", func() {
+		handler := infrahttp.NewRateLimiter(0.01, 2).Limit(ok)
+
+		s.Equal(http.StatusOK, s.serve(handler, "192.0.2.1:1234", "").Code)
+		s.Equal(http.StatusOK, s.serve(handler, "192.0.2.1:1235", "").Code)
+		rr := s.serve(handler, "192.0.2.1:1236", "")
+
+		s.Equal(http.StatusTooManyRequests, rr.Code)
+		s.Equal("100", rr.Header().Get("Retry-After"))
+		s.Equal("rate limit exceeded, retry later", problemOf(s.T(), rr).Detail)
+	})
+
+	s.Run("should count each address and each user separately", func() {
+		keys := apikeysmocks.NewUseCase(s.T())
+		keys.EXPECT().Authenticate(mock.Anything, "arv_a").Return(&apikey.Key{ID: "a", Role: user.RoleViewer}, nil)
+		keys.EXPECT().Authenticate(mock.Anything, "arv_b").Return(&apikey.Key{ID: "b", Role: user.RoleViewer}, nil)
+		auth := infrahttp.NewAuth(keys, nil, true)
+		handler := auth.Require(user.RoleViewer, user.RoleViewer, infrahttp.NewRateLimiter(0.01, 1).Limit(ok))
+
+		s.Equal(http.StatusOK, s.serve(handler, "192.0.2.1:1234", "arv_a").Code)
+		s.Equal(http.StatusTooManyRequests, s.serve(handler, "192.0.2.2:1234", "arv_a").Code)
+		s.Equal(http.StatusOK, s.serve(handler, "192.0.2.1:1234", "arv_b").Code)
+
+		anonymous := infrahttp.NewRateLimiter(0.01, 1).Limit(ok)
+		s.Equal(http.StatusOK, s.serve(anonymous, "192.0.2.1:1234", "").Code)
+		s.Equal(http.StatusOK, s.serve(anonymous, "192.0.2.2:1234", "").Code)
+	})
+
+	s.Run("should limit requests failing authentication per address", func() {
+		keys := apikeysmocks.NewUseCase(s.T())
+		keys.EXPECT().Authenticate(mock.Anything, "arv_wrong").Return(nil, apikeys.ErrInvalidKey)
+		auth := infrahttp.NewAuth(keys, nil, true)
+		handler := infrahttp.NewRateLimiter(0.01, 2).LimitAddresses(auth.Require(user.RoleViewer, user.RoleViewer, infrahttp.NewRateLimiter(0.01, 100).Limit(ok)))
+
+		s.Equal(http.StatusUnauthorized, s.serve(handler, "192.0.2.1:1234", "arv_wrong").Code)
+		s.Equal(http.StatusUnauthorized, s.serve(handler, "192.0.2.1:1235", "").Code)
+		s.Equal(http.StatusTooManyRequests, s.serve(handler, "192.0.2.1:1236", "arv_wrong").Code)
+		s.Equal(http.StatusUnauthorized, s.serve(handler, "192.0.2.2:1234", "arv_wrong").Code)
+	})
+
+	s.Run("should not limit when the rate is zero", func() {
+		handler := infrahttp.NewRateLimiter(0, 1).Limit(ok)
+
+		for range 5 {
+			s.Equal(http.StatusOK, s.serve(handler, "192.0.2.1:1234", "").Code)
+		}
+	})
+}
+
+func (s *RateLimitTestSuite) TestLimitBody() {
+	s.Run("should reject bodies over the limit with payload too large", func() {
+		handlers := infrahttp.NewHandlers(infrahttp.UseCases{AddApp: addappmocks.NewUseCase(s.T())}, "")
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/app", strings.NewReader(`{"appId":"12345","name":"`+strings.Repeat("x", 64)+`"}`))
+		rr := httptest.NewRecorder()
+
+		infrahttp.LimitBody(16, http.HandlerFunc(handlers.AddApp)).ServeHTTP(rr, req)
+
+		s.Equal(http.StatusRequestEntityTooLarge, rr.Code)
+	})
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
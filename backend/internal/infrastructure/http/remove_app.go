@@ -0,0 +1,23 @@
+package http
+
+import (
+	"net/http"
+
+	"appstorereviewsviewer/internal/domain/app"
+)
+
+// RemoveApp stops tracking an app. Its stored reviews are kept.
+func (h *Handlers) RemoveApp(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("id")
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if err := h.removeAppUseCase.Execute(r.Context(), appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/app"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	removeappmocks "appstorereviewsviewer/mocks/application/removeapp"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RemoveAppHandlerTestSuite struct {
+	suite.Suite
+	mockRemoveAppUseCase *removeappmocks.UseCase
+	handlers             *infrahttp.Handlers
+}
+
+func (s *RemoveAppHandlerTestSuite) SetupSubTest() {
+	s.mockRemoveAppUseCase = removeappmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{RemoveApp: s.mockRemoveAppUseCase}, "")
+}
+
+func (s *RemoveAppHandlerTestSuite) newRequest(method string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/app/12345", nil)
+	req.SetPathValue("id", "12345")
+	return req
+}
+
+func (s *RemoveAppHandlerTestSuite) TestRemoveApp() {
+	s.Run("should remove the app", func() {
+		s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.RemoveApp(rr, s.newRequest(http.MethodDelete))
+
+		s.Equal(http.StatusNoContent, rr.Code)
+	})
+
+	s.Run("should return not found for untracked apps", func() {
+		s.mockRemoveAppUseCase.EXPECT().Execute(mock.Anything, "12345").Return(fmt.Errorf("%w: 12345", app.ErrNotFound))
+		rr := httptest.NewRecorder()
+
+		s.handlers.RemoveApp(rr, s.newRequest(http.MethodDelete))
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestRemoveAppHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveAppHandlerTestSuite))
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/replytemplate"
+)
+
+type ReplyTemplateRequest struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+type ReplyTemplateResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type ReplyTemplatesResponse struct {
+	Templates []ReplyTemplateResponse `json:"templates"`
+}
+
+type ReplyTemplatePreviewResponse struct {
+	Content string `json:"content"`
+}
+
+func newReplyTemplateResponse(template *replytemplate.Template) ReplyTemplateResponse {
+	return ReplyTemplateResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		Content:   template.Content,
+		CreatedAt: template.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: template.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ListReplyTemplates lists the saved reply templates.
+func (h *Handlers) ListReplyTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.replyTemplatesUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := ReplyTemplatesResponse{Templates: make([]ReplyTemplateResponse, len(templates))}
+	for i, template := range templates {
+		response.Templates[i] = newReplyTemplateResponse(template)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateReplyTemplate saves a new reply template.
+func (h *Handlers) CreateReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	var body ReplyTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeBodyError(w, r, err, "Invalid request body")
+		return
+	}
+
+	template, err := h.replyTemplatesUseCase.Create(r.Context(), body.Name, body.Content)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newReplyTemplateResponse(template))
+}
+
+// GetReplyTemplate reads a saved reply template.
+func (h *Handlers) GetReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("templateId")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	template, err := h.replyTemplatesUseCase.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newReplyTemplateResponse(template))
+}
+
+// UpdateReplyTemplate replaces the name and content of a saved reply
+// template.
+func (h *Handlers) UpdateReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("templateId")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	var body ReplyTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeBodyError(w, r, err, "Invalid request body")
+		return
+	}
+
+	template, err := h.replyTemplatesUseCase.Update(r.Context(), id, body.Name, body.Content)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newReplyTemplateResponse(template))
+}
+
+// DeleteReplyTemplate deletes a saved reply template.
+func (h *Handlers) DeleteReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("templateId")
+	if id == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	if err := h.replyTemplatesUseCase.Delete(r.Context(), id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewReplyTemplate renders a saved reply template for the review given by
+// the appId and reviewId query parameters.
+func (h *Handlers) PreviewReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("templateId")
+	appID := r.URL.Query().Get("appId")
+	reviewID := r.URL.Query().Get("reviewId")
+	if id == "" || appID == "" || reviewID == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Template ID, appId and reviewId are required")
+		return
+	}
+	if err := app.ValidateID(appID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	content, err := h.previewReplyTemplateUseCase.Execute(r.Context(), id, appID, reviewID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ReplyTemplatePreviewResponse{Content: content})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package http_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/domain/review"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	previewreplytemplatemocks "appstorereviewsviewer/mocks/application/previewreplytemplate"
+	replytemplatesmocks "appstorereviewsviewer/mocks/application/replytemplates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplyTemplatesHandlerTestSuite struct {
+	suite.Suite
+	mockReplyTemplatesUseCase       *replytemplatesmocks.UseCase
+	mockPreviewReplyTemplateUseCase *previewreplytemplatemocks.UseCase
+	handlers                        *infrahttp.Handlers
+}
+
+func (s *ReplyTemplatesHandlerTestSuite) SetupSubTest() {
+	s.mockReplyTemplatesUseCase = replytemplatesmocks.NewUseCase(s.T())
+	s.mockPreviewReplyTemplateUseCase = previewreplytemplatemocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{
+		ReplyTemplates:       s.mockReplyTemplatesUseCase,
+		PreviewReplyTemplate: s.mockPreviewReplyTemplateUseCase,
+	}, "")
+}
+
+func (s *ReplyTemplatesHandlerTestSuite) newRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("templateId", "t1")
+	return req
+}
+
+var thanksTemplate = &replytemplate.Template{
+	ID:        "t1",
+	Name:      "Thanks",
+	Content:   "Thanks {{author}}!",
+	CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+}
+
+func (s *ReplyTemplatesHandlerTestSuite) TestReplyTemplates() {
+	s.Run("should list templates", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().List(mock.Anything).Return([]*replytemplate.Template{thanksTemplate}, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ListReplyTemplates(rr, httptest.NewRequest(http.MethodGet, "/api/v1/reply-templates", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		var response infrahttp.ReplyTemplatesResponse
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+		s.Equal([]infrahttp.ReplyTemplateResponse{{
+			ID:        "t1",
+			Name:      "Thanks",
+			Content:   "Thanks {{author}}!",
+			CreatedAt: "2025-01-01T12:00:00Z",
+			UpdatedAt: "2025-01-02T12:00:00Z",
+		}}, response.Templates)
+	})
+
+	s.Run("should list no templates as an empty array", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().List(mock.Anything).Return(nil, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ListReplyTemplates(rr, httptest.NewRequest(http.MethodGet, "/api/v1/reply-templates", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"templates":[]}`, rr.Body.String())
+	})
+
+	s.Run("should create a template", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Create(mock.Anything, "Thanks", "Thanks {{author}}!").Return(thanksTemplate, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateReplyTemplate(rr, httptest.NewRequest(http.MethodPost, "/api/v1/reply-templates",
+			strings.NewReader(`{"name":"Thanks","content":"Thanks {{author}}!"}`)))
+
+		s.Equal(http.StatusCreated, rr.Code)
+		s.Contains(rr.Body.String(), `"id":"t1"`)
+	})
+
+	s.Run("should return bad request for invalid templates", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Create(mock.Anything, "Thanks", "{{author").
+			Return(nil, fmt.Errorf("%w: unclosed action", replytemplate.ErrInvalid))
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateReplyTemplate(rr, httptest.NewRequest(http.MethodPost, "/api/v1/reply-templates",
+			strings.NewReader(`{"name":"Thanks","content":"{{author"}`)))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+		s.Contains(rr.Body.String(), "invalid reply template")
+	})
+
+	s.Run("should return bad request for invalid JSON", func() {
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateReplyTemplate(rr, httptest.NewRequest(http.MethodPost, "/api/v1/reply-templates", strings.NewReader("{")))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+}
+
+func (s *ReplyTemplatesHandlerTestSuite) TestReplyTemplate() {
+	s.Run("should return a template", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Get(mock.Anything, "t1").Return(thanksTemplate, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetReplyTemplate(rr, s.newRequest(http.MethodGet, "/api/v1/reply-templates/t1", ""))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Contains(rr.Body.String(), `"name":"Thanks"`)
+	})
+
+	s.Run("should return not found for unknown templates", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Get(mock.Anything, "t1").Return(nil, replytemplate.ErrNotFound)
+		rr := httptest.NewRecorder()
+
+		s.handlers.GetReplyTemplate(rr, s.newRequest(http.MethodGet, "/api/v1/reply-templates/t1", ""))
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+
+	s.Run("should update a template", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Update(mock.Anything, "t1", "Thanks", "Thanks {{author}}!").Return(thanksTemplate, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.UpdateReplyTemplate(rr, s.newRequest(http.MethodPut, "/api/v1/reply-templates/t1",
+			`{"name":"Thanks","content":"Thanks {{author}}!"}`))
+
+		s.Equal(http.StatusOK, rr.Code)
+	})
+
+	s.Run("should delete a template", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Delete(mock.Anything, "t1").Return(nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.DeleteReplyTemplate(rr, s.newRequest(http.MethodDelete, "/api/v1/reply-templates/t1", ""))
+
+		s.Equal(http.StatusNoContent, rr.Code)
+	})
+
+	s.Run("should return internal server error when the use case fails", func() {
+		s.mockReplyTemplatesUseCase.EXPECT().Delete(mock.Anything, "t1").Return(assert.AnError)
+		rr := httptest.NewRecorder()
+
+		s.handlers.DeleteReplyTemplate(rr, s.newRequest(http.MethodDelete, "/api/v1/reply-templates/t1", ""))
+
+		s.Equal(http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func (s *ReplyTemplatesHandlerTestSuite) TestPreviewReplyTemplate() {
+	s.Run("should render the template for a review", func() {
+		s.mockPreviewReplyTemplateUseCase.EXPECT().Execute(mock.Anything, "t1", "12345", "r1").Return("Thanks Jane!", nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.PreviewReplyTemplate(rr, s.newRequest(http.MethodGet, "/api/v1/reply-templates/t1/preview?appId=12345&reviewId=r1", ""))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"content":"Thanks Jane!"}`, rr.Body.String())
+	})
+
+	s.Run("should require the app and review IDs", func() {
+		rr := httptest.NewRecorder()
+
+		s.handlers.PreviewReplyTemplate(rr, s.newRequest(http.MethodGet, "/api/v1/reply-templates/t1/preview?appId=12345", ""))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	s.Run("should return not found for unknown reviews", func() {
+		s.mockPreviewReplyTemplateUseCase.EXPECT().Execute(mock.Anything, "t1", "12345", "r1").Return("", review.ErrNotFound)
+		rr := httptest.NewRecorder()
+
+		s.handlers.PreviewReplyTemplate(rr, s.newRequest(http.MethodGet, "/api/v1/reply-templates/t1/preview?appId=12345&reviewId=r1", ""))
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestReplyTemplatesHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplyTemplatesHandlerTestSuite))
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"appstorereviewsviewer/internal/application/replyreview"
+	"appstorereviewsviewer/internal/domain/app"
+)
+
+type ReplyRequest struct {
+	Content string `json:"content"`
+}
+
+var replyActionsByMethod = map[string]replyreview.Action{
+	http.MethodPost:   replyreview.ActionCreate,
+	http.MethodPut:    replyreview.ActionUpdate,
+	http.MethodDelete: replyreview.ActionDelete,
+}
+
+// ReplyToReview creates (POST), replaces (PUT) or deletes (DELETE) the
+// developer reply to a review and answers with the updated review.
+func (h *Handlers) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	action := replyActionsByMethod[r.Method]
+	request := replyreview.Request{
+		AppID:    r.PathValue("id"),
+		ReviewID: r.PathValue("reviewId"),
+		Action:   action,
+	}
+	if request.AppID == "" || request.ReviewID == "" {
+		writeProblem(w, r, http.StatusBadRequest, "Invalid app or review ID")
+		return
+	}
+	if err := app.ValidateID(request.AppID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if action != replyreview.ActionDelete {
+		var body ReplyRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeBodyError(w, r, err, "Invalid request body")
+			return
+		}
+		request.Content = body.Content
+	}
+
+	updated, err := h.replyReviewUseCase.Execute(r.Context(), request)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if action == replyreview.ActionCreate {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := json.NewEncoder(w).Encode(newReviewResponse(updated)); err != nil {
+		slog.Error("failed to write response", "path", r.URL.Path, "error", err)
+	}
+}
@@ -0,0 +1,114 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/replyreview"
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	replyreviewmocks "appstorereviewsviewer/mocks/application/replyreview"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplyToReviewHandlerTestSuite struct {
+	suite.Suite
+	mockReplyReviewUseCase *replyreviewmocks.UseCase
+	handlers               *infrahttp.Handlers
+}
+
+func (s *ReplyToReviewHandlerTestSuite) SetupSubTest() {
+	s.mockReplyReviewUseCase = replyreviewmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{ReplyReview: s.mockReplyReviewUseCase}, "")
+}
+
+func (s *ReplyToReviewHandlerTestSuite) newRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/app/12345/reviews/r1/response", strings.NewReader(body))
+	req.SetPathValue("id", "12345")
+	req.SetPathValue("reviewId", "r1")
+	return req
+}
+
+func (s *ReplyToReviewHandlerTestSuite) TestReplyToReview() {
+	replied := &review.Review{
+		ID:          "r1",
+		AppID:       "12345",
+		Score:       2,
+		SubmittedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Source:      review.SourceAppStoreConnect,
+		Reply:       &review.Reply{Content: "Sorry!", State: review.ReplyPending, UpdatedAt: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)},
+	}
+
+	s.Run("should create a reply and return the review", func() {
+		s.mockReplyReviewUseCase.EXPECT().Execute(mock.Anything, replyreview.Request{
+			AppID: "12345", ReviewID: "r1", Action: replyreview.ActionCreate, Content: "Sorry!",
+		}).Return(replied, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ReplyToReview(rr, s.newRequest(http.MethodPost, `{"content":"Sorry!"}`))
+
+		s.Equal(http.StatusCreated, rr.Code)
+		var response infrahttp.ReviewResponse
+		s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+		s.Equal("pending", response.ReplyStatus)
+		s.Equal("Sorry!", response.Reply.Content)
+	})
+
+	s.Run("should map PUT to an update and DELETE to a deletion", func() {
+		s.mockReplyReviewUseCase.EXPECT().Execute(mock.Anything, mock.MatchedBy(func(r replyreview.Request) bool {
+			return r.Action == replyreview.ActionUpdate && r.Content == "Better"
+		})).Return(replied, nil)
+		s.mockReplyReviewUseCase.EXPECT().Execute(mock.Anything, replyreview.Request{
+			AppID: "12345", ReviewID: "r1", Action: replyreview.ActionDelete,
+		}).Return(replied, nil)
+
+		put := httptest.NewRecorder()
+		s.handlers.ReplyToReview(put, s.newRequest(http.MethodPut, `{"content":"Better"}`))
+		del := httptest.NewRecorder()
+		s.handlers.ReplyToReview(del, s.newRequest(http.MethodDelete, ""))
+
+		s.Equal(http.StatusOK, put.Code)
+		s.Equal(http.StatusOK, del.Code)
+	})
+
+	s.Run("should map use case errors to status codes", func() {
+		cases := map[error]int{
+			review.ErrNotFound:              http.StatusNotFound,
+			replyreview.ErrEmptyReply:       http.StatusBadRequest,
+			replyreview.ErrNotSupported:     http.StatusBadRequest,
+			replyreview.ErrReplyExists:      http.StatusConflict,
+			replyreview.ErrNoReply:          http.StatusConflict,
+			errors.New("store unavailable"): http.StatusInternalServerError,
+			fault.Wrap(fault.KindUnavailable, errors.New("store unavailable")): http.StatusServiceUnavailable,
+			context.DeadlineExceeded: http.StatusGatewayTimeout,
+		}
+		for err, status := range cases {
+			s.mockReplyReviewUseCase.EXPECT().Execute(mock.Anything, mock.Anything).Return(nil, err).Once()
+			rr := httptest.NewRecorder()
+
+			s.handlers.ReplyToReview(rr, s.newRequest(http.MethodPost, `{"content":"Hi"}`))
+
+			s.Equal(status, rr.Code, err.Error())
+		}
+	})
+
+	s.Run("should return bad request when invalid JSON provided", func() {
+		rr := httptest.NewRecorder()
+
+		s.handlers.ReplyToReview(rr, s.newRequest(http.MethodPost, "{"))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestReplyToReviewHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReplyToReviewHandlerTestSuite))
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Route is a method and path pattern the router serves.
+type Route struct {
+	Method  string
+	Pattern string
+}
+
+// Router routes requests by method and path pattern, with wildcards such as
+// {id} read through r.PathValue. Requests no pattern matches get a 404
+// problem, and requests whose path matches under other methods a 405
+// problem whose Allow header lists them. GET routes also answer HEAD.
+type Router struct {
+	mux      *http.ServeMux
+	routes   []Route
+	observer RequestObserver
+}
+
+// RequestObserver records the outcome of requests, e.g. as metrics.
+type RequestObserver interface {
+	// ObserveRequest is called once a request is answered, with the pattern
+	// of the route that served it, or "" when no route matched.
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// Group returns a group of routes below prefix, e.g. "/api/v1".
+func (rt *Router) Group(prefix string) *RouteGroup {
+	return &RouteGroup{router: rt, prefix: prefix}
+}
+
+// Observe reports every request the router serves to observer.
+func (rt *Router) Observe(observer RequestObserver) {
+	rt.observer = observer
+}
+
+// Routes returns the routes in the order they were registered.
+func (rt *Router) Routes() []Route {
+	return rt.routes
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, pattern := rt.mux.Handler(r)
+	if rt.observer == nil {
+		rt.serve(w, r, pattern)
+		return
+	}
+
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: w}
+	rt.serve(recorder, r, pattern)
+	// Patterns start with their method, which the observer gets separately.
+	_, route, _ := strings.Cut(pattern, " ")
+	rt.observer.ObserveRequest(r.Method, route, recorder.Status(), time.Since(start))
+}
+
+func (rt *Router) serve(w http.ResponseWriter, r *http.Request, pattern string) {
+	if pattern != "" {
+		rt.mux.ServeHTTP(w, r)
+		return
+	}
+
+	// Nothing matched: let the mux work out whether other methods would
+	// have, then answer in the API's error format instead of its plain text.
+	unmatched := &unmatchedResponse{header: http.Header{}}
+	rt.mux.ServeHTTP(unmatched, r)
+	if unmatched.status == http.StatusMethodNotAllowed {
+		w.Header().Set("Allow", unmatched.header.Get("Allow"))
+		writeProblem(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	writeProblem(w, r, http.StatusNotFound, "no route for "+r.URL.Path)
+}
+
+// RouteGroup registers routes below a common prefix. Groups nest, so an
+// API version and a resource scope below it are groups of their own.
+type RouteGroup struct {
+	router *Router
+	prefix string
+}
+
+// Group returns a group below this group's prefix.
+func (g *RouteGroup) Group(prefix string) *RouteGroup {
+	return &RouteGroup{router: g.router, prefix: g.prefix + prefix}
+}
+
+// Handle serves method requests to the group's prefix followed by path.
+// Wildcards match single path segments: requests whose wildcard values
+// contain a slash or backslash, which reach the mux percent-encoded, get a
+// 400 problem before handler sees them.
+func (g *RouteGroup) Handle(method, path string, handler http.Handler) {
+	pattern := g.prefix + path
+	g.router.mux.Handle(strings.ToUpper(method)+" "+pattern, segmentWildcards(pattern, handler))
+	g.router.routes = append(g.router.routes, Route{Method: strings.ToUpper(method), Pattern: pattern})
+}
+
+// segmentWildcards rejects requests whose values for the single-segment
+// wildcards of pattern span several segments once decoded.
+func segmentWildcards(pattern string, next http.Handler) http.Handler {
+	var names []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && !strings.HasSuffix(segment, "...}") && segment != "{$}" {
+			names = append(names, segment[1:len(segment)-1])
+		}
+	}
+	if len(names) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, name := range names {
+			if strings.ContainsAny(r.PathValue(name), `/\`) {
+				writeProblem(w, r, http.StatusBadRequest, "path parameter "+name+" must not contain slashes")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// unmatchedResponse records the status and headers the mux answers
+// unmatched requests with, discarding its body.
+type unmatchedResponse struct {
+	header http.Header
+	status int
+}
+
+func (u *unmatchedResponse) Header() http.Header {
+	return u.header
+}
+
+func (u *unmatchedResponse) Write(data []byte) (int, error) {
+	if u.status == 0 {
+		u.status = http.StatusOK
+	}
+	return len(data), nil
+}
+
+func (u *unmatchedResponse) WriteHeader(status int) {
+	if u.status == 0 {
+		u.status = status
+	}
+}
+
+// statusRecorder records the status a handler answers with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(data []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return s.ResponseWriter.Write(data)
+}
+
+// Status returns the status the response was written with, which is 200
+// when the handler wrote nothing.
+func (s *statusRecorder) Status() int {
+	if s.status == 0 {
+		return http.StatusOK
+	}
+	return s.status
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,128 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	httpmocks "appstorereviewsviewer/mocks/infrastructure/http"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RouterTestSuite struct {
+	suite.Suite
+	router *infrahttp.Router
+}
+
+func (s *RouterTestSuite) SetupSubTest() {
+	s.router = infrahttp.NewRouter()
+	v1 := s.router.Group("/api/v1")
+	v1.Handle(http.MethodGet, "/app/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("get " + r.PathValue("id")))
+	}))
+	v1.Handle(http.MethodDelete, "/app/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	v1.Group("/workspaces/{workspace}").Handle(http.MethodGet, "/app/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.PathValue("workspace") + " " + r.PathValue("id")))
+	}))
+	s.router.Group("/api/v2").Handle(http.MethodGet, "/apps/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("v2 " + r.PathValue("id")))
+	}))
+}
+
+func (s *RouterTestSuite) serve(method, target string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+	return rr
+}
+
+func (s *RouterTestSuite) TestServeHTTP() {
+	s.Run("should route by method and fill in wildcards", func() {
+		rr := s.serve(http.MethodGet, "/api/v1/app/12345")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("get 12345", rr.Body.String())
+		s.Equal(http.StatusNoContent, s.serve(http.MethodDelete, "/api/v1/app/12345").Code)
+	})
+
+	s.Run("should route nested and versioned groups", func() {
+		s.Equal("team-a 12345", s.serve(http.MethodGet, "/api/v1/workspaces/team-a/app/12345").Body.String())
+		s.Equal("v2 12345", s.serve(http.MethodGet, "/api/v2/apps/12345").Body.String())
+	})
+
+	s.Run("should answer HEAD requests to GET routes", func() {
+		s.Equal(http.StatusOK, s.serve(http.MethodHead, "/api/v1/app/12345").Code)
+	})
+
+	s.Run("should answer unknown paths with a not found problem", func() {
+		for _, target := range []string{"/api/v1/app/12345/reviews/unknown", "/api/v1/app", "/api/v2/app/12345", "/"} {
+			rr := s.serve(http.MethodGet, target)
+
+			s.Equal(http.StatusNotFound, rr.Code, target)
+			s.Equal("no route for "+target, problemOf(s.T(), rr).Detail)
+		}
+	})
+
+	s.Run("should reject wildcards holding encoded slashes with a bad request problem", func() {
+		for _, target := range []string{"/api/v1/app/..%2Fb%2F123", "/api/v1/workspaces/a/app/..%2F..%2Fb", "/api/v1/app/..%5Cb"} {
+			rr := s.serve(http.MethodGet, target)
+
+			s.Equal(http.StatusBadRequest, rr.Code, target)
+			s.Equal("path parameter id must not contain slashes", problemOf(s.T(), rr).Detail, target)
+		}
+	})
+
+	s.Run("should answer other methods with a method not allowed problem listing the allowed ones", func() {
+		rr := s.serve(http.MethodPost, "/api/v1/app/12345")
+
+		s.Equal(http.StatusMethodNotAllowed, rr.Code)
+		s.Equal("DELETE, GET, HEAD", rr.Header().Get("Allow"))
+		s.Equal(infrahttp.Problem{
+			Type:     "about:blank",
+			Title:    "Method Not Allowed",
+			Status:   http.StatusMethodNotAllowed,
+			Detail:   "method not allowed",
+			Instance: "/api/v1/app/12345",
+		}, problemOf(s.T(), rr))
+	})
+}
+
+func (s *RouterTestSuite) TestObserve() {
+	s.Run("should report requests with the pattern and status of their route", func() {
+		observer := httpmocks.NewRequestObserver(s.T())
+		observer.EXPECT().ObserveRequest(http.MethodGet, "/api/v1/app/{id}", http.StatusOK, mock.AnythingOfType("time.Duration")).Once()
+		observer.EXPECT().ObserveRequest(http.MethodDelete, "/api/v1/app/{id}", http.StatusNoContent, mock.AnythingOfType("time.Duration")).Once()
+		s.router.Observe(observer)
+
+		s.Equal("get 12345", s.serve(http.MethodGet, "/api/v1/app/12345").Body.String())
+		s.serve(http.MethodDelete, "/api/v1/app/12345")
+	})
+
+	s.Run("should report unmatched requests without a route", func() {
+		observer := httpmocks.NewRequestObserver(s.T())
+		observer.EXPECT().ObserveRequest(http.MethodGet, "", http.StatusNotFound, mock.AnythingOfType("time.Duration")).Once()
+		observer.EXPECT().ObserveRequest(http.MethodPost, "", http.StatusMethodNotAllowed, mock.AnythingOfType("time.Duration")).Once()
+		s.router.Observe(observer)
+
+		s.serve(http.MethodGet, "/api/v1/unknown")
+		s.serve(http.MethodPost, "/api/v1/app/12345")
+	})
+}
+
+func (s *RouterTestSuite) TestRoutes() {
+	s.Run("should list routes with their group prefixes", func() {
+		s.Equal([]infrahttp.Route{
+			{Method: http.MethodGet, Pattern: "/api/v1/app/{id}"},
+			{Method: http.MethodDelete, Pattern: "/api/v1/app/{id}"},
+			{Method: http.MethodGet, Pattern: "/api/v1/workspaces/{workspace}/app/{id}"},
+			{Method: http.MethodGet, Pattern: "/api/v2/apps/{id}"},
+		}, s.router.Routes())
+	})
+}
+
+func TestRouterTestSuite(t *testing.T) {
+	suite.Run(t, new(RouterTestSuite))
+}
@@ -3,28 +3,148 @@ package http
 import (
 	"log"
 	"net/http"
+	"time"
 
-	"appstorereviewsviewer/internal/application/addapp"
-	"appstorereviewsviewer/internal/application/getrecentreviews"
+	"appstorereviewsviewer/internal/domain/user"
 )
 
+type Config struct {
+	Port string
+	// BaseURL is the public URL of the API. When empty, links are built from
+	// the incoming request.
+	BaseURL string
+	// AuthEnabled requires an API key with a sufficient role on every
+	// route.
+	AuthEnabled bool
+	// Tokens, when set, accepts JWTs from the identity provider besides API
+	// keys.
+	Tokens TokenVerifier
+	// RateLimit is how many requests per second each client may make on
+	// average, with bursts of up to RateBurst. Zero disables rate limiting.
+	RateLimit float64
+	RateBurst int
+	// AddressRateLimit and AddressRateBurst limit each remote address
+	// before authentication, so that requests failing it are limited too.
+	// They should allow for several users sharing an address.
+	AddressRateLimit float64
+	AddressRateBurst int
+	// MaxBodyBytes bounds request bodies, except review imports which are
+	// bounded by MaxImportBytes. Zero means no limit.
+	MaxBodyBytes   int64
+	MaxImportBytes int64
+	// Cors decides which browser origins may call the API.
+	Cors CorsPolicy
+	// Metrics, when set, records every request and is served to admins at
+	// /metrics.
+	Metrics Metrics
+	// Timeouts of the underlying http.Server; zero means none.
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+}
+
+// Metrics records requests and serves everything it recorded in the
+// Prometheus text format.
+type Metrics interface {
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
 type Server struct {
 	*http.Server
+	router *Router
 }
 
-func NewServer(getRecentReviewsUseCase getrecentreviews.UseCase, addAppUseCase addapp.UseCase, port string) *Server {
-	handlers := NewHandlers(getRecentReviewsUseCase, addAppUseCase)
-	mux := http.NewServeMux()
-	mux.HandleFunc("/api/v1/app/", handlers.GetRecentReviews)
-	mux.HandleFunc("/api/v1/app", handlers.AddApp)
-	handler := CorsMiddleware(mux)
+func NewServer(useCases UseCases, config Config) *Server {
+	handlers := NewHandlers(useCases, config.BaseURL)
+	auth := NewAuth(useCases.APIKeys, config.Tokens, config.AuthEnabled)
+	addressLimiter := NewRateLimiter(config.AddressRateLimit, config.AddressRateBurst)
+	limiter := NewRateLimiter(config.RateLimit, config.RateBurst)
+	// Requests are rate limited per address before authentication, so that
+	// failing it costs budget too, and per user after it, so that each user
+	// has a budget of their own.
+	require := func(read, write user.Role, handler http.Handler) http.Handler {
+		return addressLimiter.LimitAddresses(auth.Require(read, write, limiter.Limit(handler)))
+	}
+	viewer := func(handler http.Handler) http.Handler {
+		return require(user.RoleViewer, user.RoleViewer, handler)
+	}
+	triager := func(handler http.Handler) http.Handler {
+		return require(user.RoleViewer, user.RoleTriager, handler)
+	}
+	admin := func(handler http.Handler) http.Handler {
+		return require(user.RoleAdmin, user.RoleAdmin, handler)
+	}
+
+	router := NewRouter()
+	// Routes are grouped by API version; a new version gets a group of its
+	// own next to v1.
+	v1 := router.Group("/api/v1")
+	// Routes reaching workspace data are served below
+	// /api/v1/workspaces/{workspace} and, for the default workspace, at their
+	// original paths.
+	workspaceV1 := v1.Group("/workspaces/{workspace}")
+	scopedWithLimit := func(method, path string, guard func(http.Handler) http.Handler, bodyLimit int64, handler http.HandlerFunc) {
+		scopedHandler := guard(WorkspaceMiddleware(useCases.Workspaces, LimitBody(bodyLimit, ValidateRequests(APIDocument, handler))))
+		v1.Handle(method, path, scopedHandler)
+		workspaceV1.Handle(method, path, scopedHandler)
+	}
+	scoped := func(method, path string, guard func(http.Handler) http.Handler, handler http.HandlerFunc) {
+		scopedWithLimit(method, path, guard, config.MaxBodyBytes, handler)
+	}
+	scoped(http.MethodPost, "/app", admin, handlers.AddApp)
+	scoped(http.MethodDelete, "/app/{id}", admin, handlers.RemoveApp)
+	scoped(http.MethodGet, "/app/{id}/reviews/recent", viewer, handlers.GetRecentReviews)
+	scoped(http.MethodGet, "/app/{id}/reviews.atom", viewer, handlers.GetReviewsAtom)
+	scoped(http.MethodGet, "/app/{id}/reviews.rss", viewer, handlers.GetReviewsRSS)
+	scoped(http.MethodGet, "/app/{id}/reviews/export", viewer, handlers.ExportReviews)
+	scopedWithLimit(http.MethodPost, "/app/{id}/reviews/import", admin, config.MaxImportBytes, handlers.ImportReviews)
+	scoped(http.MethodPost, "/app/{id}/reviews/{reviewId}/response", triager, handlers.ReplyToReview)
+	scoped(http.MethodPut, "/app/{id}/reviews/{reviewId}/response", triager, handlers.ReplyToReview)
+	scoped(http.MethodDelete, "/app/{id}/reviews/{reviewId}/response", triager, handlers.ReplyToReview)
+	scoped(http.MethodGet, "/apps/status", viewer, handlers.GetAppStatus)
+	scoped(http.MethodGet, "/jobs/{jobId}", viewer, handlers.GetJob)
+	scoped(http.MethodGet, "/reply-templates", viewer, handlers.ListReplyTemplates)
+	scoped(http.MethodPost, "/reply-templates", triager, handlers.CreateReplyTemplate)
+	scoped(http.MethodGet, "/reply-templates/{templateId}", viewer, handlers.GetReplyTemplate)
+	scoped(http.MethodPut, "/reply-templates/{templateId}", triager, handlers.UpdateReplyTemplate)
+	scoped(http.MethodDelete, "/reply-templates/{templateId}", triager, handlers.DeleteReplyTemplate)
+	scoped(http.MethodGet, "/reply-templates/{templateId}/preview", viewer, handlers.PreviewReplyTemplate)
+	unscoped := func(method, path string, guard func(http.Handler) http.Handler, handler http.HandlerFunc) {
+		v1.Handle(method, path, guard(LimitBody(config.MaxBodyBytes, ValidateRequests(APIDocument, handler))))
+	}
+	unscoped(http.MethodGet, "/workspaces", viewer, handlers.ListWorkspaces)
+	unscoped(http.MethodPost, "/workspaces", admin, handlers.CreateWorkspace)
+	unscoped(http.MethodGet, "/api-keys", admin, handlers.ListAPIKeys)
+	unscoped(http.MethodPost, "/api-keys", admin, handlers.CreateAPIKey)
+	unscoped(http.MethodDelete, "/api-keys/{keyId}", admin, handlers.DeleteAPIKey)
+	// The API's description is public so that clients can be generated
+	// before they have a key.
+	unscoped(http.MethodGet, "/openapi.json", limiter.Limit, handlers.GetOpenAPISpec)
+	unscoped(http.MethodGet, "/docs", limiter.Limit, handlers.GetAPIDocs)
+	if config.Metrics != nil {
+		// Prometheus expects metrics at the root rather than in an API
+		// version. Reading them needs no more than a viewer key, so that
+		// scrapers do not hold admin credentials.
+		router.Group("").Handle(http.MethodGet, "/metrics", viewer(ValidateRequests(APIDocument, config.Metrics)))
+		router.Observe(config.Metrics)
+	}
+	handler := RequestIDMiddleware(CorsMiddleware(config.Cors, router))
 
 	server := &http.Server{
-		Addr:    ":" + port,
-		Handler: handler,
+		Addr:              ":" + config.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+		WriteTimeout:      config.WriteTimeout,
+		IdleTimeout:       config.IdleTimeout,
 	}
 
-	return &Server{Server: server}
+	return &Server{Server: server, router: router}
+}
+
+// Routes returns the routes the server serves, in registration order.
+func (s *Server) Routes() []Route {
+	return s.router.Routes()
 }
 
 func (s *Server) Start() {
@@ -1,12 +1,21 @@
 package http_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"appstorereviewsviewer/internal/domain/workspace"
 	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	"appstorereviewsviewer/internal/infrastructure/metrics"
 	addappmocks "appstorereviewsviewer/mocks/application/addapp"
+	exportreviewsmocks "appstorereviewsviewer/mocks/application/exportreviews"
 	getrecentreviewsmocks "appstorereviewsviewer/mocks/application/getrecentreviews"
+	importreviewsmocks "appstorereviewsviewer/mocks/application/importreviews"
+	listreviewsmocks "appstorereviewsviewer/mocks/application/listreviews"
+	workspacesmocks "appstorereviewsviewer/mocks/application/workspaces"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -14,17 +23,33 @@ type ServerTestSuite struct {
 	suite.Suite
 	mockAddAppUseCase           *addappmocks.UseCase
 	mockGetRecentReviewsUseCase *getrecentreviewsmocks.UseCase
+	mockListReviewsUseCase      *listreviewsmocks.UseCase
+	mockExportReviewsUseCase    *exportreviewsmocks.UseCase
+	mockImportReviewsUseCase    *importreviewsmocks.UseCase
 }
 
 func (s *ServerTestSuite) SetupSubTest() {
 	s.mockAddAppUseCase = addappmocks.NewUseCase(s.T())
 	s.mockGetRecentReviewsUseCase = getrecentreviewsmocks.NewUseCase(s.T())
+	s.mockListReviewsUseCase = listreviewsmocks.NewUseCase(s.T())
+	s.mockExportReviewsUseCase = exportreviewsmocks.NewUseCase(s.T())
+	s.mockImportReviewsUseCase = importreviewsmocks.NewUseCase(s.T())
+}
+
+func (s *ServerTestSuite) useCases() infrahttp.UseCases {
+	return infrahttp.UseCases{
+		GetRecentReviews: s.mockGetRecentReviewsUseCase,
+		AddApp:           s.mockAddAppUseCase,
+		ListReviews:      s.mockListReviewsUseCase,
+		ExportReviews:    s.mockExportReviewsUseCase,
+		ImportReviews:    s.mockImportReviewsUseCase,
+	}
 }
 
 func (s *ServerTestSuite) TestNewServer() {
 	s.Run("should create server with correct configuration", func() {
 		port := "8080"
-		server := infrahttp.NewServer(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase, port)
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: port})
 
 		s.NotNil(server)
 		s.Equal(":8080", server.Addr)
@@ -33,7 +58,7 @@ func (s *ServerTestSuite) TestNewServer() {
 
 	s.Run("should create server with custom port", func() {
 		port := "3000"
-		server := infrahttp.NewServer(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase, port)
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: port})
 
 		s.NotNil(server)
 		s.Equal(":3000", server.Addr)
@@ -43,7 +68,7 @@ func (s *ServerTestSuite) TestNewServer() {
 func (s *ServerTestSuite) TestServerStart() {
 	s.Run("should start server without blocking", func() {
 		port := "0"
-		server := infrahttp.NewServer(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase, port)
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: port})
 
 		done := make(chan bool)
 		go func() {
@@ -65,10 +90,80 @@ func (s *ServerTestSuite) TestServerStart() {
 
 func (s *ServerTestSuite) TestServerHandlerRoutes() {
 	s.Run("should configure routes correctly", func() {
-		server := infrahttp.NewServer(s.mockGetRecentReviewsUseCase, s.mockAddAppUseCase, "8080")
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080"})
 		s.NotNil(server.Handler)
 		s.NotNil(server.Handler)
 	})
+
+	s.Run("should answer unknown paths below an app with not found", func() {
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080"})
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/app/12345/unknown", nil))
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+
+	s.Run("should answer methods a route does not serve with method not allowed", func() {
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080"})
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/app/12345/reviews/recent", nil))
+
+		s.Equal(http.StatusMethodNotAllowed, rr.Code)
+		s.Equal("GET, HEAD", rr.Header().Get("Allow"))
+	})
+
+	s.Run("should answer preflight requests before routing them", func() {
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080", Cors: infrahttp.CorsPolicy{
+			AllowedOrigins: []string{"https://reviews.example.com"},
+			AllowedMethods: []string{http.MethodDelete},
+		}})
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/app/12345", nil)
+		req.Header.Set("Origin", "https://reviews.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, req)
+
+		s.Equal(http.StatusNoContent, rr.Code)
+		s.Equal("https://reviews.example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+		s.Equal("DELETE", rr.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	s.Run("should not let app IDs reach into other workspaces", func() {
+		workspacesUseCase := workspacesmocks.NewUseCase(s.T())
+		workspacesUseCase.EXPECT().Get(mock.Anything, "a").Return(&workspace.Workspace{ID: "a"}, nil).Maybe()
+		useCases := s.useCases()
+		useCases.Workspaces = workspacesUseCase
+		server := infrahttp.NewServer(useCases, infrahttp.Config{Port: "8080"})
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/a/app/..%2Fb%2F123/reviews/export", nil))
+
+		s.Equal(http.StatusBadRequest, rr.Code)
+	})
+
+	s.Run("should serve the metrics of the requests it answered", func() {
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080", Metrics: metrics.New()})
+		server.Handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/app/12345/unknown", nil))
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal(metrics.ContentType, rr.Header().Get("Content-Type"))
+		s.Contains(rr.Body.String(), `appstorereviews_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	})
+
+	s.Run("should not serve metrics without them", func() {
+		server := infrahttp.NewServer(s.useCases(), infrahttp.Config{Port: "8080"})
+		rr := httptest.NewRecorder()
+
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
 }
 
 func TestServerTestSuite(t *testing.T) {
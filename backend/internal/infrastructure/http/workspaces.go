@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"appstorereviewsviewer/internal/application/workspaces"
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+type CreateWorkspaceRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WorkspaceResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WorkspacesResponse struct {
+	Workspaces []WorkspaceResponse `json:"workspaces"`
+}
+
+// WorkspaceMiddleware scopes a request to the workspace named by the
+// {workspace} path value, or to the default workspace on routes without one.
+// It runs after authentication so it can check that the user is a member.
+func WorkspaceMiddleware(workspacesUseCase workspaces.UseCase, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("workspace")
+		if id == "" {
+			id = workspace.DefaultID
+		}
+
+		if u, ok := UserFromContext(r.Context()); ok && !u.CanAccess(id) {
+			writeProblem(w, r, http.StatusForbidden, "not a member of workspace "+id)
+			return
+		}
+
+		if _, err := workspacesUseCase.Get(r.Context(), id); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(workspace.WithID(r.Context(), id)))
+	})
+}
+
+// ListWorkspaces lists the workspaces the user is a member of.
+func (h *Handlers) ListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	u, authenticated := UserFromContext(r.Context())
+
+	all, err := h.workspacesUseCase.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := WorkspacesResponse{Workspaces: []WorkspaceResponse{}}
+	for _, ws := range all {
+		if !authenticated || u.CanAccess(ws.ID) {
+			response.Workspaces = append(response.Workspaces, WorkspaceResponse{ID: ws.ID, Name: ws.Name})
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateWorkspace creates a workspace. Only users who are members of every
+// workspace may create them.
+func (h *Handlers) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	if u, authenticated := UserFromContext(r.Context()); authenticated && u.Workspaces != nil {
+		writeProblem(w, r, http.StatusForbidden, "only members of every workspace may create workspaces")
+		return
+	}
+
+	var body CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeBodyError(w, r, err, "Invalid request body")
+		return
+	}
+
+	created, err := h.workspacesUseCase.Create(r.Context(), body.ID, body.Name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, WorkspaceResponse{ID: created.ID, Name: created.Name})
+}
@@ -0,0 +1,149 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/domain/workspace"
+	infrahttp "appstorereviewsviewer/internal/infrastructure/http"
+	apikeysmocks "appstorereviewsviewer/mocks/application/apikeys"
+	workspacesmocks "appstorereviewsviewer/mocks/application/workspaces"
+	httpmocks "appstorereviewsviewer/mocks/infrastructure/http"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspacesHandlerTestSuite struct {
+	suite.Suite
+	mockWorkspacesUseCase *workspacesmocks.UseCase
+	handlers              *infrahttp.Handlers
+}
+
+func (s *WorkspacesHandlerTestSuite) SetupSubTest() {
+	s.mockWorkspacesUseCase = workspacesmocks.NewUseCase(s.T())
+	s.handlers = infrahttp.NewHandlers(infrahttp.UseCases{Workspaces: s.mockWorkspacesUseCase}, "")
+}
+
+// asUser authenticates req as u through a token verifier, the way the
+// server does before calling the handlers.
+func (s *WorkspacesHandlerTestSuite) asUser(u *user.User, handler http.HandlerFunc) http.Handler {
+	tokens := httpmocks.NewTokenVerifier(s.T())
+	tokens.EXPECT().Verify(mock.Anything, mock.Anything).Return(u, nil)
+	auth := infrahttp.NewAuth(apikeysmocks.NewUseCase(s.T()), tokens, true)
+	return auth.Require(user.RoleViewer, user.RoleViewer, handler)
+}
+
+func (s *WorkspacesHandlerTestSuite) serve(handler http.Handler, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/workspaces", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer a.b.c")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func (s *WorkspacesHandlerTestSuite) TestWorkspaces() {
+	all := []*workspace.Workspace{{ID: "default", Name: "Default"}, {ID: "team-a", Name: "Team A"}}
+
+	s.Run("should list every workspace when authentication is disabled", func() {
+		s.mockWorkspacesUseCase.EXPECT().List(mock.Anything).Return(all, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.ListWorkspaces(rr, httptest.NewRequest(http.MethodGet, "/api/v1/workspaces", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"workspaces":[{"id":"default","name":"Default"},{"id":"team-a","name":"Team A"}]}`, rr.Body.String())
+	})
+
+	s.Run("should list only the workspaces the user is a member of", func() {
+		s.mockWorkspacesUseCase.EXPECT().List(mock.Anything).Return(all, nil)
+		handler := s.asUser(&user.User{Role: user.RoleViewer, Workspaces: []string{"team-a"}}, s.handlers.ListWorkspaces)
+
+		rr := s.serve(handler, http.MethodGet, "")
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.JSONEq(`{"workspaces":[{"id":"team-a","name":"Team A"}]}`, rr.Body.String())
+	})
+
+	s.Run("should create a workspace", func() {
+		s.mockWorkspacesUseCase.EXPECT().Create(mock.Anything, "team-b", "Team B").Return(&workspace.Workspace{ID: "team-b", Name: "Team B"}, nil)
+		rr := httptest.NewRecorder()
+
+		s.handlers.CreateWorkspace(rr, httptest.NewRequest(http.MethodPost, "/api/v1/workspaces", strings.NewReader(`{"id":"team-b","name":"Team B"}`)))
+
+		s.Equal(http.StatusCreated, rr.Code)
+		s.JSONEq(`{"id":"team-b","name":"Team B"}`, rr.Body.String())
+	})
+
+	s.Run("should let only members of every workspace create workspaces", func() {
+		handler := s.asUser(&user.User{Role: user.RoleAdmin, Workspaces: []string{"team-a"}}, s.handlers.CreateWorkspace)
+
+		rr := s.serve(handler, http.MethodPost, `{"id":"team-b"}`)
+
+		s.Equal(http.StatusForbidden, rr.Code)
+	})
+
+	s.Run("should map use case errors to status codes", func() {
+		cases := map[error]int{
+			fmt.Errorf("%w: x", workspace.ErrInvalidID): http.StatusBadRequest,
+			fmt.Errorf("%w: x", workspace.ErrExists):    http.StatusConflict,
+			context.DeadlineExceeded:                    http.StatusGatewayTimeout,
+		}
+		for err, status := range cases {
+			s.mockWorkspacesUseCase.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).Return(nil, err).Once()
+			rr := httptest.NewRecorder()
+
+			s.handlers.CreateWorkspace(rr, httptest.NewRequest(http.MethodPost, "/api/v1/workspaces", strings.NewReader(`{"id":"x"}`)))
+
+			s.Equal(status, rr.Code, err.Error())
+		}
+	})
+}
+
+func (s *WorkspacesHandlerTestSuite) TestWorkspaceMiddleware() {
+	var reached string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = workspace.IDFromContext(r.Context())
+	})
+
+	s.Run("should scope requests without a workspace to the default one", func() {
+		s.mockWorkspacesUseCase.EXPECT().Get(mock.Anything, "default").Return(&workspace.Workspace{ID: "default"}, nil)
+		rr := httptest.NewRecorder()
+
+		infrahttp.WorkspaceMiddleware(s.mockWorkspacesUseCase, next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/apps/status", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("default", reached)
+	})
+
+	s.Run("should scope requests to the workspace in the path", func() {
+		s.mockWorkspacesUseCase.EXPECT().Get(mock.Anything, "team-a").Return(&workspace.Workspace{ID: "team-a"}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/team-a/apps/status", nil)
+		req.SetPathValue("workspace", "team-a")
+		rr := httptest.NewRecorder()
+
+		infrahttp.WorkspaceMiddleware(s.mockWorkspacesUseCase, next).ServeHTTP(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("team-a", reached)
+	})
+
+	s.Run("should return not found for unknown workspaces", func() {
+		s.mockWorkspacesUseCase.EXPECT().Get(mock.Anything, "team-b").Return(nil, fmt.Errorf("%w: team-b", workspace.ErrNotFound))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/team-b/apps/status", nil)
+		req.SetPathValue("workspace", "team-b")
+		rr := httptest.NewRecorder()
+
+		infrahttp.WorkspaceMiddleware(s.mockWorkspacesUseCase, next).ServeHTTP(rr, req)
+
+		s.Equal(http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestWorkspacesHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkspacesHandlerTestSuite))
+}
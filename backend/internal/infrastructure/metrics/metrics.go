@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+const namespace = "appstorereviews_"
+
+// fetchBuckets and reloadBuckets cover feed requests with their retries and
+// reload runs, which take far longer than API requests.
+var (
+	fetchBuckets  = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+	reloadBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+)
+
+// Metrics records how the API, review ingestion and storage behave and
+// serves the measurements to Prometheus. Its decorators wrap repositories,
+// review sources and use cases without them knowing.
+type Metrics struct {
+	registry *Registry
+	now      func() time.Time
+
+	httpRequests        *Counter
+	httpRequestDuration *Histogram
+
+	feedFetches       *Counter
+	feedFetchDuration *Histogram
+	reviewsIngested   *Counter
+
+	repositoryDuration *Histogram
+	repositoryErrors   *Counter
+
+	reloadDuration    *Histogram
+	reloadLastSuccess *Gauge
+}
+
+func New() *Metrics {
+	registry := NewRegistry()
+	return &Metrics{
+		registry: registry,
+		now:      time.Now,
+
+		httpRequests: registry.NewCounter(namespace+"http_requests_total",
+			"API requests by method, route pattern and response status.", "method", "route", "status"),
+		httpRequestDuration: registry.NewHistogram(namespace+"http_request_duration_seconds",
+			"Time taken to answer API requests by method and route pattern.", DefaultBuckets, "method", "route"),
+
+		feedFetches: registry.NewCounter(namespace+"feed_fetches_total",
+			"Review fetches by source, app ID in the source and HTTP status code, or error when the fetch failed without one.", "source", "app", "status"),
+		feedFetchDuration: registry.NewHistogram(namespace+"feed_fetch_duration_seconds",
+			"Time taken to fetch an app's reviews from a source, retries included.", fetchBuckets, "source", "app"),
+		reviewsIngested: registry.NewCounter(namespace+"reviews_ingested_total",
+			"Reviews stored by reloads by workspace, app and whether they were new or updated.", "workspace", "app", "change"),
+
+		repositoryDuration: registry.NewHistogram(namespace+"repository_operation_duration_seconds",
+			"Time taken by repository operations.", DefaultBuckets, "repository", "operation"),
+		repositoryErrors: registry.NewCounter(namespace+"repository_operation_errors_total",
+			"Repository operations that failed, not counting lookups of missing entities.", "repository", "operation"),
+
+		reloadDuration: registry.NewHistogram(namespace+"reload_duration_seconds",
+			"Time taken to reload the reviews of a workspace's apps by trigger.", reloadBuckets, "trigger", "workspace"),
+		reloadLastSuccess: registry.NewGauge(namespace+"reload_last_success_timestamp_seconds",
+			"Unix time a reload of a workspace last ran to completion, by trigger.", "trigger", "workspace"),
+	}
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.registry.ServeHTTP(w, r)
+}
+
+// ObserveRequest records an API request. Requests no route matched are
+// recorded under the route "unmatched".
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	m.httpRequests.Inc(method, route, strconv.Itoa(status))
+	m.httpRequestDuration.Observe(duration.Seconds(), method, route)
+}
+
+// observe records the duration and outcome of a repository operation
+// started at start. Lookups of missing entities are answers rather than
+// failures and are not counted as errors.
+func (m *Metrics) observe(repository, operation string, start time.Time, err error) {
+	m.repositoryDuration.Observe(m.now().Sub(start).Seconds(), repository, operation)
+	if err != nil && fault.KindOf(err) != fault.KindNotFound {
+		m.repositoryErrors.Inc(repository, operation)
+	}
+}
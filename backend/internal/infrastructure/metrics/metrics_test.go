@@ -0,0 +1,167 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/metrics"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	reloadreviewsmocks "appstorereviewsviewer/mocks/application/reloadreviews"
+	appmocks "appstorereviewsviewer/mocks/domain/app"
+	reviewmocks "appstorereviewsviewer/mocks/domain/review"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	metrics *metrics.Metrics
+}
+
+func (s *MetricsTestSuite) SetupSubTest() {
+	s.metrics = metrics.New()
+}
+
+func (s *MetricsTestSuite) scrape() string {
+	rr := httptest.NewRecorder()
+	s.metrics.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rr.Body.String()
+}
+
+func (s *MetricsTestSuite) TestObserveRequest() {
+	s.Run("should count requests and their latency per route", func() {
+		s.metrics.ObserveRequest(http.MethodGet, "/api/v1/app/{id}", http.StatusOK, 30*time.Millisecond)
+		s.metrics.ObserveRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_http_requests_total{method="GET",route="/api/v1/app/{id}",status="200"} 1`)
+		s.Contains(out, `appstorereviews_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+		s.Contains(out, `appstorereviews_http_request_duration_seconds_bucket{method="GET",route="/api/v1/app/{id}",le="0.05"} 1`)
+		s.Contains(out, `appstorereviews_http_request_duration_seconds_count{method="GET",route="/api/v1/app/{id}"} 1`)
+	})
+}
+
+func (s *MetricsTestSuite) TestSource() {
+	s.Run("should record fetches by app and status", func() {
+		source := reviewmocks.NewSource(s.T())
+		source.EXPECT().FindByAppIDSince(mock.Anything, "12345", mock.Anything).Return([]*review.Review{{ID: "r1"}}, nil).Once()
+		source.EXPECT().FindByAppIDSince(mock.Anything, "12345", mock.Anything).Return(nil, fmt.Errorf("failed: %w", &persistencereview.StatusError{StatusCode: 503})).Once()
+		source.EXPECT().FindByAppIDSince(mock.Anything, "67890", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+		instrumented := s.metrics.Source(review.SourceAppStore, source)
+
+		reviews, err := instrumented.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.NoError(err)
+		s.Len(reviews, 1)
+		_, err = instrumented.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Error(err)
+		_, err = instrumented.FindByAppIDSince(context.Background(), "67890", time.Time{})
+		s.EqualError(err, "connection refused")
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="appstore",app="12345",status="200"} 1`)
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="appstore",app="12345",status="503"} 1`)
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="appstore",app="67890",status="error"} 1`)
+		s.Contains(out, `appstorereviews_feed_fetch_duration_seconds_count{source="appstore",app="12345"} 2`)
+	})
+}
+
+func (s *MetricsTestSuite) TestSourceStatuses() {
+	s.Run("should record feeds answered from the cache and the statuses of other sources", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/apps/67890/reviews":
+				w.WriteHeader(http.StatusForbidden)
+			case r.Header.Get("If-None-Match") == `"v1"`:
+				w.WriteHeader(http.StatusNotModified)
+			default:
+				w.Header().Set("ETag", `"v1"`)
+				_, _ = w.Write([]byte(`{"feed": {"entry": []}}`))
+			}
+		}))
+		defer server.Close()
+		cache, err := persistencereview.NewFeedCache(s.T().TempDir())
+		s.Require().NoError(err)
+		rss := s.metrics.Source(review.SourceAppStore, persistencereview.NewRSSRepository(persistencereview.RSSOptions{BaseURL: server.URL, Timeout: time.Second, Cache: cache}))
+		json := s.metrics.Source("json", persistencereview.NewJSONSource(persistencereview.JSONSourceOptions{URL: server.URL + "/apps/{id}/reviews", Timeout: time.Second}))
+
+		for range 2 {
+			_, err := rss.FindByAppIDSince(context.Background(), "12345", time.Time{})
+			s.Require().NoError(err)
+		}
+		_, err = json.FindByAppIDSince(context.Background(), "67890", time.Time{})
+		s.Require().Error(err)
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="appstore",app="12345",status="200"} 1`)
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="appstore",app="12345",status="304"} 1`)
+		s.Contains(out, `appstorereviews_feed_fetches_total{source="json",app="67890",status="403"} 1`)
+	})
+}
+
+func (s *MetricsTestSuite) TestRepositories() {
+	s.Run("should record operation latencies and failures", func() {
+		repo := appmocks.NewRepository(s.T())
+		repo.EXPECT().FindAll(mock.Anything).Return([]*app.App{{ID: "12345"}}, nil)
+		repo.EXPECT().Delete(mock.Anything, "12345").Return(errors.New("disk full")).Once()
+		repo.EXPECT().Delete(mock.Anything, "67890").Return(app.ErrNotFound).Once()
+		instrumented := s.metrics.AppRepository(repo)
+
+		apps, err := instrumented.FindAll(context.Background())
+		s.NoError(err)
+		s.Len(apps, 1)
+		s.EqualError(instrumented.Delete(context.Background(), "12345"), "disk full")
+		s.ErrorIs(instrumented.Delete(context.Background(), "67890"), app.ErrNotFound)
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_repository_operation_duration_seconds_count{repository="app",operation="find_all"} 1`)
+		s.Contains(out, `appstorereviews_repository_operation_duration_seconds_count{repository="app",operation="delete"} 2`)
+		s.Contains(out, `appstorereviews_repository_operation_errors_total{repository="app",operation="delete"} 1`)
+		s.NotContains(out, `appstorereviews_repository_operation_errors_total{repository="app",operation="find_all"}`)
+	})
+}
+
+func (s *MetricsTestSuite) TestReloadReviews() {
+	s.Run("should record run durations, ingested reviews and the last successful run", func() {
+		useCase := reloadreviewsmocks.NewUseCase(s.T())
+		useCase.EXPECT().Execute(mock.Anything).Return(&reloadreviews.Summary{
+			Ingested: []reloadreviews.AppReviews{{AppID: "12345", NewReviews: 3, UpdatedReviews: 1}},
+		}, nil).Once()
+		instrumented := s.metrics.ReloadReviews(useCase, "cron")
+
+		summary, err := instrumented.Execute(workspace.WithID(context.Background(), "team-a"))
+		s.NoError(err)
+		s.NotNil(summary)
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_reload_duration_seconds_count{trigger="cron",workspace="team-a"} 1`)
+		s.Contains(out, `appstorereviews_reviews_ingested_total{workspace="team-a",app="12345",change="new"} 3`)
+		s.Contains(out, `appstorereviews_reviews_ingested_total{workspace="team-a",app="12345",change="updated"} 1`)
+		s.Contains(out, `appstorereviews_reload_last_success_timestamp_seconds{trigger="cron",workspace="team-a"} `)
+	})
+
+	s.Run("should not count failed runs as successful", func() {
+		useCase := reloadreviewsmocks.NewUseCase(s.T())
+		useCase.EXPECT().Execute(mock.Anything).Return(&reloadreviews.Summary{}, context.DeadlineExceeded)
+		instrumented := s.metrics.ReloadReviews(useCase, "cron")
+
+		_, err := instrumented.Execute(context.Background())
+		s.ErrorIs(err, context.DeadlineExceeded)
+
+		out := s.scrape()
+		s.Contains(out, `appstorereviews_reload_duration_seconds_count{trigger="cron",workspace="default"} 1`)
+		s.NotContains(out, `appstorereviews_reload_last_success_timestamp_seconds{`)
+	})
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets suit request latencies in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metric families and writes them in the Prometheus text
+// format. Families are created once at startup; creating two of the same
+// name or recording a series with the wrong number of label values panics.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewCounter returns a counter family whose series are told apart by
+// labelNames.
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	return &Counter{r.register(name, help, "counter", nil, labelNames)}
+}
+
+// NewGauge returns a gauge family whose series are told apart by labelNames.
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	return &Gauge{r.register(name, help, "gauge", nil, labelNames)}
+}
+
+// NewHistogram returns a histogram family counting observations into
+// buckets, given as increasing upper bounds.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if !slices.IsSorted(buckets) {
+		panic(fmt.Sprintf("metrics: buckets of %s must be increasing", name))
+	}
+	return &Histogram{r.register(name, help, "histogram", buckets, labelNames)}
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.families {
+		if existing.name == name {
+			panic(fmt.Sprintf("metrics: %s is already registered", name))
+		}
+	}
+	f := &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     map[string]*series{},
+	}
+	r.families = append(r.families, f)
+	return f
+}
+
+// Write writes every family in registration order, with its series sorted
+// by label values.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	families := slices.Clone(r.families)
+	r.mu.Unlock()
+
+	buffered := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(buffered)
+	}
+	return buffered.Flush()
+}
+
+// ServeHTTP serves the registry's metrics to Prometheus.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	_ = r.Write(w)
+}
+
+// Counter is a family of values that only go up.
+type Counter struct {
+	*family
+}
+
+// Inc adds one to the series with labelValues.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta, which must not be negative, to the series with
+// labelValues.
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: %s cannot decrease", c.name))
+	}
+	c.update(labelValues, func(s *series) { s.value += delta })
+}
+
+// Gauge is a family of values that may go up and down.
+type Gauge struct {
+	*family
+}
+
+// Set sets the series with labelValues to value.
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.update(labelValues, func(s *series) { s.value = value })
+}
+
+// Histogram is a family of observation distributions.
+type Histogram struct {
+	*family
+}
+
+// Observe records value in the series with labelValues.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.update(labelValues, func(s *series) {
+		if s.bucketCounts == nil {
+			s.bucketCounts = make([]uint64, len(h.buckets))
+		}
+		for i, bound := range h.buckets {
+			if value <= bound {
+				s.bucketCounts[i]++
+			}
+		}
+		s.sum += value
+		s.count++
+	})
+}
+
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	// value is the value of counters and gauges.
+	value float64
+	// bucketCounts are the cumulative counts of a histogram's buckets.
+	bucketCounts []uint64
+	sum          float64
+	count        uint64
+}
+
+func (f *family) update(labelValues []string, fn func(*series)) {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: slices.Clone(labelValues)}
+		f.series[key] = s
+	}
+	fn(s)
+}
+
+func (f *family) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		if f.kind != "histogram" {
+			writeSample(w, f.name, f.labels(s, ""), s.value)
+			continue
+		}
+		for i, bound := range f.buckets {
+			writeSample(w, f.name+"_bucket", f.labels(s, formatFloat(bound)), float64(s.bucketCounts[i]))
+		}
+		writeSample(w, f.name+"_bucket", f.labels(s, "+Inf"), float64(s.count))
+		writeSample(w, f.name+"_sum", f.labels(s, ""), s.sum)
+		writeSample(w, f.name+"_count", f.labels(s, ""), float64(s.count))
+	}
+}
+
+// labels renders the label set of s, with an le label for histogram buckets
+// when le is not empty.
+func (f *family) labels(s *series, le string) string {
+	if len(f.labelNames) == 0 && le == "" {
+		return ""
+	}
+	pairs := make([]string, 0, len(f.labelNames)+1)
+	for i, name := range f.labelNames {
+		pairs = append(pairs, name+`="`+escapeLabelValue(s.labelValues[i])+`"`)
+	}
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func writeSample(w *bufio.Writer, name, labels string, value float64) {
+	w.WriteString(name)
+	w.WriteString(labels)
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelEscaper.Replace(value)
+}
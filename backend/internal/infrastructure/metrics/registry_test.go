@@ -0,0 +1,102 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type RegistryTestSuite struct {
+	suite.Suite
+	registry *metrics.Registry
+}
+
+func (s *RegistryTestSuite) SetupSubTest() {
+	s.registry = metrics.NewRegistry()
+}
+
+func (s *RegistryTestSuite) write() string {
+	var out strings.Builder
+	s.Require().NoError(s.registry.Write(&out))
+	return out.String()
+}
+
+func (s *RegistryTestSuite) TestWrite() {
+	s.Run("should write counters and gauges with their series sorted by labels", func() {
+		requests := s.registry.NewCounter("requests_total", "Requests served.", "route", "status")
+		temperature := s.registry.NewGauge("temperature", "Current temperature.")
+		requests.Inc("/b", "200")
+		requests.Add(2, "/a", "500")
+		requests.Inc("/b", "200")
+		temperature.Set(-1.5)
+
+		s.Equal(`# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{route="/a",status="500"} 2
+requests_total{route="/b",status="200"} 2
+# HELP temperature Current temperature.
+# TYPE temperature gauge
+temperature -1.5
+`, s.write())
+	})
+
+	s.Run("should write cumulative histogram buckets with their sum and count", func() {
+		latency := s.registry.NewHistogram("latency_seconds", "Request latency.", []float64{0.1, 1}, "route")
+		latency.Observe(0.05, "/a")
+		latency.Observe(0.5, "/a")
+		latency.Observe(3, "/a")
+
+		s.Equal(`# HELP latency_seconds Request latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 1
+latency_seconds_bucket{route="/a",le="1"} 2
+latency_seconds_bucket{route="/a",le="+Inf"} 3
+latency_seconds_sum{route="/a"} 3.55
+latency_seconds_count{route="/a"} 3
+`, s.write())
+	})
+
+	s.Run("should escape help texts and label values", func() {
+		errors := s.registry.NewCounter("errors_total", "Errors\nby \\ message.", "message")
+		errors.Inc("say \"hi\"\n\\")
+
+		s.Contains(s.write(), `# HELP errors_total Errors\nby \\ message.`)
+		s.Contains(s.write(), `errors_total{message="say \"hi\"\n\\"} 1`)
+	})
+
+	s.Run("should write families without series", func() {
+		s.registry.NewCounter("idle_total", "Never incremented.", "route")
+
+		s.Equal("# HELP idle_total Never incremented.\n# TYPE idle_total counter\n", s.write())
+	})
+
+	s.Run("should reject misuse", func() {
+		counter := s.registry.NewCounter("requests_total", "Requests served.", "route")
+
+		s.Panics(func() { s.registry.NewGauge("requests_total", "Duplicate.") })
+		s.Panics(func() { counter.Inc() })
+		s.Panics(func() { counter.Add(-1, "/a") })
+		s.Panics(func() { s.registry.NewHistogram("latency_seconds", "Unsorted.", []float64{1, 0.1}) })
+	})
+}
+
+func (s *RegistryTestSuite) TestServeHTTP() {
+	s.Run("should serve the text format", func() {
+		s.registry.NewCounter("requests_total", "Requests served.").Inc()
+		rr := httptest.NewRecorder()
+
+		s.registry.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("text/plain; version=0.0.4; charset=utf-8", rr.Header().Get("Content-Type"))
+		s.Contains(rr.Body.String(), "requests_total 1\n")
+	})
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
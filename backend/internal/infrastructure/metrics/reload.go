@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+// ReloadReviews records how long the runs of useCase take, when they last
+// ran to completion and the reviews they stored per app, labelled with
+// trigger, e.g. "cron" for the scheduled reloads. Runs cover the workspace
+// of their context. Failures of single apps do not fail a run; they show in
+// the feed fetch metrics instead.
+func (m *Metrics) ReloadReviews(useCase reloadreviews.UseCase, trigger string) reloadreviews.UseCase {
+	return &reloadReviews{useCase: useCase, trigger: trigger, metrics: m}
+}
+
+type reloadReviews struct {
+	useCase reloadreviews.UseCase
+	trigger string
+	metrics *Metrics
+}
+
+func (r *reloadReviews) Execute(ctx context.Context, appIDs ...string) (*reloadreviews.Summary, error) {
+	workspaceID := workspace.IDFromContext(ctx)
+	start := r.metrics.now()
+	summary, err := r.useCase.Execute(ctx, appIDs...)
+	end := r.metrics.now()
+
+	r.metrics.reloadDuration.Observe(end.Sub(start).Seconds(), r.trigger, workspaceID)
+	if err == nil {
+		r.metrics.reloadLastSuccess.Set(float64(end.UnixMilli())/1000, r.trigger, workspaceID)
+	}
+	if summary != nil {
+		for _, ingested := range summary.Ingested {
+			r.metrics.reviewsIngested.Add(float64(ingested.NewReviews), workspaceID, ingested.AppID, "new")
+			r.metrics.reviewsIngested.Add(float64(ingested.UpdatedReviews), workspaceID, ingested.AppID, "updated")
+		}
+	}
+	return summary, err
+}
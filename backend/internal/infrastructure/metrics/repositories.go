@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+// ReviewRepository records the latency of repo's operations under the
+// repository name "review".
+func (m *Metrics) ReviewRepository(repo review.Repository) review.Repository {
+	return &reviewRepository{repo: repo, metrics: m}
+}
+
+type reviewRepository struct {
+	repo    review.Repository
+	metrics *Metrics
+}
+
+func (r *reviewRepository) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	start := r.metrics.now()
+	reviews, err := r.repo.FindByAppIDSince(ctx, appID, since)
+	r.metrics.observe("review", "find_by_app_id_since", start, err)
+	return reviews, err
+}
+
+func (r *reviewRepository) StreamByAppIDBetween(ctx context.Context, appID string, since, until time.Time, fn func(*review.Review) error) error {
+	start := r.metrics.now()
+	err := r.repo.StreamByAppIDBetween(ctx, appID, since, until, fn)
+	r.metrics.observe("review", "stream_by_app_id_between", start, err)
+	return err
+}
+
+func (r *reviewRepository) Save(ctx context.Context, reviews ...*review.Review) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, reviews...)
+	r.metrics.observe("review", "save", start, err)
+	return err
+}
+
+func (r *reviewRepository) Merge(ctx context.Context, reviews ...*review.Review) (int, int, error) {
+	start := r.metrics.now()
+	newReviews, updatedReviews, err := r.repo.Merge(ctx, reviews...)
+	r.metrics.observe("review", "merge", start, err)
+	return newReviews, updatedReviews, err
+}
+
+// AppRepository records the latency of repo's operations under the
+// repository name "app".
+func (m *Metrics) AppRepository(repo app.Repository) app.Repository {
+	return &appRepository{repo: repo, metrics: m}
+}
+
+type appRepository struct {
+	repo    app.Repository
+	metrics *Metrics
+}
+
+func (r *appRepository) FindAll(ctx context.Context) ([]*app.App, error) {
+	start := r.metrics.now()
+	apps, err := r.repo.FindAll(ctx)
+	r.metrics.observe("app", "find_all", start, err)
+	return apps, err
+}
+
+func (r *appRepository) Save(ctx context.Context, a *app.App) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, a)
+	r.metrics.observe("app", "save", start, err)
+	return err
+}
+
+func (r *appRepository) Delete(ctx context.Context, id string) error {
+	start := r.metrics.now()
+	err := r.repo.Delete(ctx, id)
+	r.metrics.observe("app", "delete", start, err)
+	return err
+}
+
+// ReplyTemplateRepository records the latency of repo's operations under
+// the repository name "reply_template".
+func (m *Metrics) ReplyTemplateRepository(repo replytemplate.Repository) replytemplate.Repository {
+	return &replyTemplateRepository{repo: repo, metrics: m}
+}
+
+type replyTemplateRepository struct {
+	repo    replytemplate.Repository
+	metrics *Metrics
+}
+
+func (r *replyTemplateRepository) FindAll(ctx context.Context) ([]*replytemplate.Template, error) {
+	start := r.metrics.now()
+	templates, err := r.repo.FindAll(ctx)
+	r.metrics.observe("reply_template", "find_all", start, err)
+	return templates, err
+}
+
+func (r *replyTemplateRepository) FindByID(ctx context.Context, id string) (*replytemplate.Template, error) {
+	start := r.metrics.now()
+	template, err := r.repo.FindByID(ctx, id)
+	r.metrics.observe("reply_template", "find_by_id", start, err)
+	return template, err
+}
+
+func (r *replyTemplateRepository) Save(ctx context.Context, template *replytemplate.Template) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, template)
+	r.metrics.observe("reply_template", "save", start, err)
+	return err
+}
+
+func (r *replyTemplateRepository) Delete(ctx context.Context, id string) error {
+	start := r.metrics.now()
+	err := r.repo.Delete(ctx, id)
+	r.metrics.observe("reply_template", "delete", start, err)
+	return err
+}
+
+// APIKeyRepository records the latency of repo's operations under the
+// repository name "api_key".
+func (m *Metrics) APIKeyRepository(repo apikey.Repository) apikey.Repository {
+	return &apiKeyRepository{repo: repo, metrics: m}
+}
+
+type apiKeyRepository struct {
+	repo    apikey.Repository
+	metrics *Metrics
+}
+
+func (r *apiKeyRepository) FindAll(ctx context.Context) ([]*apikey.Key, error) {
+	start := r.metrics.now()
+	keys, err := r.repo.FindAll(ctx)
+	r.metrics.observe("api_key", "find_all", start, err)
+	return keys, err
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*apikey.Key, error) {
+	start := r.metrics.now()
+	key, err := r.repo.FindByHash(ctx, hash)
+	r.metrics.observe("api_key", "find_by_hash", start, err)
+	return key, err
+}
+
+func (r *apiKeyRepository) Save(ctx context.Context, key *apikey.Key) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, key)
+	r.metrics.observe("api_key", "save", start, err)
+	return err
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id string) error {
+	start := r.metrics.now()
+	err := r.repo.Delete(ctx, id)
+	r.metrics.observe("api_key", "delete", start, err)
+	return err
+}
+
+// WorkspaceRepository records the latency of repo's operations under the
+// repository name "workspace".
+func (m *Metrics) WorkspaceRepository(repo workspace.Repository) workspace.Repository {
+	return &workspaceRepository{repo: repo, metrics: m}
+}
+
+type workspaceRepository struct {
+	repo    workspace.Repository
+	metrics *Metrics
+}
+
+func (r *workspaceRepository) FindAll(ctx context.Context) ([]*workspace.Workspace, error) {
+	start := r.metrics.now()
+	workspaces, err := r.repo.FindAll(ctx)
+	r.metrics.observe("workspace", "find_all", start, err)
+	return workspaces, err
+}
+
+func (r *workspaceRepository) Save(ctx context.Context, w *workspace.Workspace) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, w)
+	r.metrics.observe("workspace", "save", start, err)
+	return err
+}
+
+// JobRepository records the latency of repo's operations under the
+// repository name "job".
+func (m *Metrics) JobRepository(repo job.Repository) job.Repository {
+	return &jobRepository{repo: repo, metrics: m}
+}
+
+type jobRepository struct {
+	repo    job.Repository
+	metrics *Metrics
+}
+
+func (r *jobRepository) FindByID(ctx context.Context, id string) (*job.Job, error) {
+	start := r.metrics.now()
+	j, err := r.repo.FindByID(ctx, id)
+	r.metrics.observe("job", "find_by_id", start, err)
+	return j, err
+}
+
+func (r *jobRepository) Save(ctx context.Context, j *job.Job) error {
+	start := r.metrics.now()
+	err := r.repo.Save(ctx, j)
+	r.metrics.observe("job", "save", start, err)
+	return err
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+)
+
+// Source records the duration and status of fetches from source, registered
+// under name. Fetches count under the status of the last response the
+// source got, e.g. 304 for feeds answered from the feed cache, or as error
+// when they failed without a response or on reading it.
+func (m *Metrics) Source(name string, source review.Source) review.Source {
+	return &instrumentedSource{name: name, source: source, metrics: m}
+}
+
+type instrumentedSource struct {
+	name    string
+	source  review.Source
+	metrics *Metrics
+}
+
+func (s *instrumentedSource) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	observed := 0
+	ctx = persistencereview.WithStatusObserver(ctx, func(statusCode int) { observed = statusCode })
+
+	start := s.metrics.now()
+	reviews, err := s.source.FindByAppIDSince(ctx, appID, since)
+	s.metrics.feedFetchDuration.Observe(s.metrics.now().Sub(start).Seconds(), s.name, appID)
+	s.metrics.feedFetches.Inc(s.name, appID, fetchStatus(observed, err))
+	return reviews, err
+}
+
+// fetchStatus labels a fetch by the status the source last observed.
+// Sources that do not report statuses count as 200 when they succeed.
+func fetchStatus(observed int, err error) string {
+	var statusErr *persistencereview.StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		return strconv.Itoa(statusErr.StatusCode)
+	case err != nil:
+		return "error"
+	case observed != 0:
+		return strconv.Itoa(observed)
+	default:
+		return "200"
+	}
+}
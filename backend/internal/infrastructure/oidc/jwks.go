@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+// minRefreshInterval keeps tokens signed with unknown key IDs, and an
+// unreachable issuer, from making every request fetch the key set again.
+const minRefreshInterval = time.Minute
+
+// keySet fetches the issuer's JSON Web Key Set and caches it for ttl, or
+// until a token names a key it does not hold. Concurrent requests share a
+// single fetch, and after a failed one the cached keys are served for
+// minRefreshInterval before the issuer is asked again. It is safe for
+// concurrent use.
+type keySet struct {
+	issuer  string
+	jwksURL string
+	ttl     time.Duration
+	client  *http.Client
+	now     func() time.Time
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	// failedAt and err describe the last fetch when it failed.
+	failedAt time.Time
+	err      error
+	// inflight is closed when the running fetch, if any, is done.
+	inflight chan struct{}
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// key returns the key kid names. Unknown keys are invalid tokens unless the
+// key set could not be fetched, which is reported as unavailable.
+func (s *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	if s.needsFetch(kid) {
+		done := s.inflight
+		if done == nil {
+			done = make(chan struct{})
+			s.inflight = done
+			// The fetch outlives a caller giving up, as others may wait
+			// for it; the client's timeout bounds it.
+			go s.refresh(context.WithoutCancel(ctx), done)
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		s.mu.Lock()
+	}
+	defer s.mu.Unlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+// needsFetch reports whether the keys must be fetched to look up kid. It
+// must be called with s.mu held.
+func (s *keySet) needsFetch(kid string) bool {
+	now := s.now()
+	if s.err != nil && now.Sub(s.failedAt) < minRefreshInterval {
+		return false
+	}
+	if s.keys == nil || now.Sub(s.fetchedAt) >= s.ttl {
+		return true
+	}
+	_, known := s.keys[kid]
+	return !known && now.Sub(s.fetchedAt) >= minRefreshInterval
+}
+
+// refresh fetches the keys and closes done. Only one refresh runs at a
+// time, so it may update jwksURL without holding s.mu.
+func (s *keySet) refresh(ctx context.Context, done chan struct{}) {
+	keys, err := s.fetch(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		// Keep serving the cached keys while the issuer is unreachable.
+		s.err = fault.Wrap(fault.KindUnavailable, err)
+		s.failedAt = s.now()
+	} else {
+		s.keys = keys
+		s.fetchedAt = s.now()
+		s.err = nil
+	}
+	s.inflight = nil
+	close(done)
+}
+
+func (s *keySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	if s.jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := s.get(ctx, strings.TrimRight(s.issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+			return nil, fmt.Errorf("failed to discover OpenID configuration: %w", err)
+		}
+		// The document must be the issuer's own, or its keys would let
+		// another issuer sign tokens.
+		if discovery.Issuer != s.issuer {
+			return nil, fmt.Errorf("OpenID configuration is for issuer %q, not %q", discovery.Issuer, s.issuer)
+		}
+		if discovery.JWKSURI == "" {
+			return nil, errors.New("OpenID configuration has no jwks_uri")
+		}
+		s.jwksURL = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := s.get(ctx, s.jwksURL, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys of types we cannot use rather than failing the set.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (s *keySet) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("%s returned status: %d", url, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", url, err)
+	}
+	return nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return nil, errors.New("EC key is not on the curve")
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
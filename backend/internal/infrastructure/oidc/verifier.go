@@ -0,0 +1,298 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/user"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+const (
+	defaultClockSkew = time.Minute
+	defaultCacheTTL  = time.Hour
+	defaultTimeout   = 10 * time.Second
+)
+
+type Options struct {
+	// Issuer must match the iss claim. The key set is discovered from its
+	// /.well-known/openid-configuration unless JWKSURL is set.
+	Issuer   string
+	Audience string
+	JWKSURL  string
+	// RolesClaim names the claim listing the user's roles or groups; dots
+	// reach into nested objects, e.g. realm_access.roles.
+	RolesClaim string
+	// RoleMapping maps claim values to roles. Values naming a role
+	// (viewer, triager, admin) map to it without an entry.
+	RoleMapping map[string]user.Role
+	// DefaultRole is given to users none of whose claim values map to a
+	// role; when empty such users are rejected.
+	DefaultRole user.Role
+	// WorkspacesClaim, when set, names the claim listing the workspaces the
+	// user is a member of, with dots like RolesClaim. Users reach every
+	// workspace when it is empty.
+	WorkspacesClaim string
+	ClockSkew       time.Duration
+	CacheTTL        time.Duration
+	Timeout         time.Duration
+}
+
+// Verifier validates OIDC ID or access tokens signed with RS256 or ES256 by
+// the configured issuer and maps their claims to users. It is safe for
+// concurrent use.
+type Verifier struct {
+	issuer      string
+	audience    string
+	rolesClaim  []string
+	roleMapping map[string]user.Role
+	defaultRole user.Role
+	// workspacesClaim is nil when membership is not taken from tokens.
+	workspacesClaim []string
+	clockSkew       time.Duration
+	keys            *keySet
+	now             func() time.Time
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+func NewVerifier(options Options) *Verifier {
+	if options.RolesClaim == "" {
+		options.RolesClaim = "roles"
+	}
+	if options.ClockSkew == 0 {
+		options.ClockSkew = defaultClockSkew
+	}
+	if options.CacheTTL == 0 {
+		options.CacheTTL = defaultCacheTTL
+	}
+	if options.Timeout == 0 {
+		options.Timeout = defaultTimeout
+	}
+
+	var workspacesClaim []string
+	if options.WorkspacesClaim != "" {
+		workspacesClaim = strings.Split(options.WorkspacesClaim, ".")
+	}
+
+	return &Verifier{
+		issuer:          options.Issuer,
+		audience:        options.Audience,
+		rolesClaim:      strings.Split(options.RolesClaim, "."),
+		roleMapping:     options.RoleMapping,
+		defaultRole:     options.DefaultRole,
+		workspacesClaim: workspacesClaim,
+		clockSkew:       options.ClockSkew,
+		keys: &keySet{
+			issuer:  options.Issuer,
+			jwksURL: options.JWKSURL,
+			ttl:     options.CacheTTL,
+			client:  &http.Client{Timeout: options.Timeout},
+			now:     time.Now,
+		},
+		now: time.Now,
+	}
+}
+
+// LooksLikeToken reports whether value has the shape of a JWT, so it can be
+// told apart from an API key.
+func LooksLikeToken(value string) bool {
+	return strings.Count(value, ".") == 2
+}
+
+// Verify checks the token's signature, issuer, audience and validity period
+// and returns the user it was issued to. Tokens that fail the checks are
+// reported as ErrInvalidToken; when the key set could not be loaded the
+// error is an unavailable fault instead.
+func (v *Verifier) Verify(ctx context.Context, token string) (*user.User, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", ErrInvalidToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+
+	key, err := v.keys.key(ctx, h.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	if err := v.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return v.user(claims)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: key does not match algorithm %s", ErrInvalidToken, alg)
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return fmt.Errorf("%w: key does not match algorithm %s", ErrInvalidToken, alg)
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+	return nil
+}
+
+func (v *Verifier) checkClaims(claims map[string]any) error {
+	if iss, _ := claims["iss"].(string); iss != v.issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, iss)
+	}
+	if !containsAudience(claims["aud"], v.audience) {
+		return fmt.Errorf("%w: not issued for audience %q", ErrInvalidToken, v.audience)
+	}
+
+	now := v.now()
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	}
+	if now.After(exp.Add(v.clockSkew)) {
+		return fmt.Errorf("%w: expired at %s", ErrInvalidToken, exp.Format(time.RFC3339))
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Before(nbf.Add(-v.clockSkew)) {
+		return fmt.Errorf("%w: not valid before %s", ErrInvalidToken, nbf.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (v *Verifier) user(claims map[string]any) (*user.User, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	var roles []user.Role
+	for _, value := range stringValues(lookup(claims, v.rolesClaim)) {
+		if role, ok := v.roleMapping[value]; ok {
+			roles = append(roles, role)
+		} else if role, err := user.ParseRole(value); err == nil {
+			roles = append(roles, role)
+		}
+	}
+	role := user.Highest(roles...)
+	if role == "" {
+		role = v.defaultRole
+	}
+	if role == "" {
+		return nil, fmt.Errorf("%w: no role granted to %s", ErrInvalidToken, subject)
+	}
+
+	name := subject
+	for _, claim := range []string{"name", "preferred_username", "email"} {
+		if value, _ := claims[claim].(string); value != "" {
+			name = value
+			break
+		}
+	}
+
+	u := &user.User{ID: v.issuer + "#" + subject, Name: name, Role: role}
+	if v.workspacesClaim != nil {
+		// A token without the claim grants no workspace rather than all.
+		u.Workspaces = append([]string{}, stringValues(lookup(claims, v.workspacesClaim))...)
+	}
+	return u, nil
+}
+
+func decodeSegment(segment string, out any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func containsAudience(aud any, audience string) bool {
+	for _, value := range stringValues(aud) {
+		if value == audience {
+			return true
+		}
+	}
+	return false
+}
+
+func numericDate(value any) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func lookup(claims map[string]any, path []string) any {
+	var value any = claims
+	for _, name := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// stringValues reads a claim that is either a string or a list of strings.
+func stringValues(value any) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
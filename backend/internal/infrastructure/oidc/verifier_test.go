@@ -0,0 +1,327 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/infrastructure/oidc"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifierTestSuite struct {
+	suite.Suite
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	server   *httptest.Server
+	jwks     []map[string]string
+	jwksHits atomic.Int32
+	// jwksStatus is the status the key set is served with.
+	jwksStatus atomic.Int32
+	// discoveredIssuer overrides the issuer of the discovery document.
+	discoveredIssuer string
+	issuer           string
+	verifier         *oidc.Verifier
+}
+
+func (s *VerifierTestSuite) SetupSuite() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+}
+
+func (s *VerifierTestSuite) SetupSubTest() {
+	s.jwks = []map[string]string{s.rsaJWK("rsa1"), s.ecJWK("ec1")}
+	s.jwksHits.Store(0)
+	s.jwksStatus.Store(http.StatusOK)
+	s.discoveredIssuer = ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := s.issuer
+		if s.discoveredIssuer != "" {
+			issuer = s.discoveredIssuer
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": s.issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		s.jwksHits.Add(1)
+		if status := int(s.jwksStatus.Load()); status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.jwks})
+	})
+	s.server = httptest.NewServer(mux)
+	s.issuer = s.server.URL
+
+	s.verifier = oidc.NewVerifier(s.options())
+}
+
+func (s *VerifierTestSuite) options() oidc.Options {
+	return oidc.Options{
+		Issuer:      s.issuer,
+		Audience:    "reviews",
+		RoleMapping: map[string]user.Role{"support": user.RoleTriager},
+	}
+}
+
+func (s *VerifierTestSuite) TearDownSubTest() {
+	s.server.Close()
+}
+
+func (s *VerifierTestSuite) rsaJWK(kid string) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(s.rsaKey.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+	}
+}
+
+func (s *VerifierTestSuite) ecJWK(kid string) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(s.ecKey.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(s.ecKey.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func (s *VerifierTestSuite) claims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":   s.issuer,
+		"aud":   "reviews",
+		"sub":   "u1",
+		"name":  "Jane Doe",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"viewer"},
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+	}
+	return claims
+}
+
+func (s *VerifierTestSuite) sign(alg, kid string, claims map[string]any) string {
+	encode := func(value any) string {
+		data, err := json.Marshal(value)
+		s.Require().NoError(err)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+		s.Require().NoError(err)
+	case "ES256":
+		r, sig, err := ecdsa.Sign(rand.Reader, s.ecKey, digest[:])
+		s.Require().NoError(err)
+		signature = append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *VerifierTestSuite) TestVerify() {
+	s.Run("should accept RS256 tokens and map the claims to a user", func() {
+		u, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+
+		s.Require().NoError(err)
+		s.Equal(&user.User{ID: s.issuer + "#u1", Name: "Jane Doe", Role: user.RoleViewer}, u)
+	})
+
+	s.Run("should accept ES256 tokens", func() {
+		_, err := s.verifier.Verify(context.Background(), s.sign("ES256", "ec1", s.claims(nil)))
+
+		s.NoError(err)
+	})
+
+	s.Run("should pick the highest mapped role", func() {
+		u, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{
+			"roles": []string{"viewer", "support", "unrelated"},
+		})))
+
+		s.Require().NoError(err)
+		s.Equal(user.RoleTriager, u.Role)
+	})
+
+	s.Run("should read roles from a nested claim", func() {
+		options := s.options()
+		options.RolesClaim = "realm_access.roles"
+		verifier := oidc.NewVerifier(options)
+
+		u, err := verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{
+			"roles":        nil,
+			"realm_access": map[string]any{"roles": []string{"admin"}},
+		})))
+
+		s.Require().NoError(err)
+		s.Equal(user.RoleAdmin, u.Role)
+	})
+
+	s.Run("should limit users to the workspaces in the workspaces claim", func() {
+		options := s.options()
+		options.WorkspacesClaim = "workspaces"
+		verifier := oidc.NewVerifier(options)
+
+		member, err := verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{
+			"workspaces": []string{"team-a"},
+		})))
+		s.Require().NoError(err)
+		s.Equal([]string{"team-a"}, member.Workspaces)
+
+		outsider, err := verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+		s.Require().NoError(err)
+		s.False(outsider.CanAccess("default"))
+	})
+
+	s.Run("should reject users without a role unless a default is set", func() {
+		token := s.sign("RS256", "rsa1", s.claims(map[string]any{"roles": nil}))
+
+		_, err := s.verifier.Verify(context.Background(), token)
+		s.ErrorIs(err, oidc.ErrInvalidToken)
+
+		options := s.options()
+		options.DefaultRole = user.RoleViewer
+		u, err := oidc.NewVerifier(options).Verify(context.Background(), token)
+		s.Require().NoError(err)
+		s.Equal(user.RoleViewer, u.Role)
+	})
+
+	s.Run("should accept an audience list containing the audience", func() {
+		_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{
+			"aud": []string{"other", "reviews"},
+		})))
+
+		s.NoError(err)
+	})
+
+	s.Run("should allow expiry within the clock skew", func() {
+		_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{
+			"exp": time.Now().Add(-30 * time.Second).Unix(),
+		})))
+
+		s.NoError(err)
+	})
+
+	s.Run("should reject tokens that fail the checks", func() {
+		tokens := map[string]string{
+			"expired":        s.sign("RS256", "rsa1", s.claims(map[string]any{"exp": time.Now().Add(-2 * time.Minute).Unix()})),
+			"no expiry":      s.sign("RS256", "rsa1", s.claims(map[string]any{"exp": nil})),
+			"not yet valid":  s.sign("RS256", "rsa1", s.claims(map[string]any{"nbf": time.Now().Add(5 * time.Minute).Unix()})),
+			"other audience": s.sign("RS256", "rsa1", s.claims(map[string]any{"aud": "other"})),
+			"other issuer":   s.sign("RS256", "rsa1", s.claims(map[string]any{"iss": "https://evil.example.com"})),
+			"no subject":     s.sign("RS256", "rsa1", s.claims(map[string]any{"sub": nil})),
+			"unknown key":    s.sign("RS256", "rsa2", s.claims(nil)),
+			"wrong key type": s.sign("ES256", "rsa1", s.claims(nil)),
+			"unsigned":       s.sign("none", "rsa1", s.claims(nil)),
+			"malformed":      "not-a-token",
+		}
+		tampered := s.sign("RS256", "rsa1", s.claims(nil))
+		tokens["tampered"] = tampered[:len(tampered)-4] + "AAAA"
+
+		for name, token := range tokens {
+			_, err := s.verifier.Verify(context.Background(), token)
+			s.ErrorIs(err, oidc.ErrInvalidToken, name)
+		}
+	})
+
+	s.Run("should cache the key set", func() {
+		for range 3 {
+			_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+			s.Require().NoError(err)
+		}
+
+		s.Equal(int32(1), s.jwksHits.Load())
+	})
+
+	s.Run("should use a configured JWKS URL without discovery", func() {
+		options := s.options()
+		options.Issuer = "https://login.example.com"
+		options.JWKSURL = s.server.URL + "/keys"
+		verifier := oidc.NewVerifier(options)
+
+		_, err := verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(map[string]any{"iss": "https://login.example.com"})))
+
+		s.NoError(err)
+	})
+
+	s.Run("should report an unreachable issuer as a failure rather than an invalid token", func() {
+		s.server.Close()
+
+		_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+
+		s.Require().Error(err)
+		s.NotErrorIs(err, oidc.ErrInvalidToken)
+		s.Equal(fault.KindUnavailable, fault.KindOf(err))
+	})
+
+	s.Run("should reject a discovery document of another issuer", func() {
+		s.discoveredIssuer = "https://evil.example.com"
+
+		_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+
+		s.Require().Error(err)
+		s.Contains(err.Error(), `OpenID configuration is for issuer "https://evil.example.com"`)
+		s.Equal(fault.KindUnavailable, fault.KindOf(err))
+		s.Zero(s.jwksHits.Load())
+	})
+
+	s.Run("should share one fetch between concurrent requests", func() {
+		token := s.sign("RS256", "rsa1", s.claims(nil))
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.verifier.Verify(context.Background(), token)
+				s.NoError(err)
+			}()
+		}
+		wg.Wait()
+
+		s.Equal(int32(1), s.jwksHits.Load())
+	})
+
+	s.Run("should not ask a failing issuer again right away", func() {
+		s.jwksStatus.Store(http.StatusInternalServerError)
+
+		for range 3 {
+			_, err := s.verifier.Verify(context.Background(), s.sign("RS256", "rsa1", s.claims(nil)))
+			s.Equal(fault.KindUnavailable, fault.KindOf(err))
+		}
+
+		s.Equal(int32(1), s.jwksHits.Load())
+	})
+}
+
+func TestVerifierTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifierTestSuite))
+}
@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Document is the part of an OpenAPI 3.1 document needed to route and
+// validate requests. Responses are served to clients as they are written.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Servers    []Server             `json:"servers"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	routes []routeTemplate
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem describes the operations of a path. Its servers, when set,
+// replace the document's, which is how a path is served below more than one
+// prefix.
+type PathItem struct {
+	Servers    []Server     `json:"servers"`
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Put        *Operation   `json:"put"`
+	Post       *Operation   `json:"post"`
+	Delete     *Operation   `json:"delete"`
+	Patch      *Operation   `json:"patch"`
+}
+
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+	// Security overrides the document's requirements; an empty, non-nil
+	// list makes the operation public.
+	Security []map[string][]string `json:"security"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+}
+
+// Operations returns the operations of the path item by HTTP method.
+func (p *PathItem) Operations() map[string]*Operation {
+	operations := map[string]*Operation{}
+	for method, operation := range map[string]*Operation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodDelete: p.Delete,
+		http.MethodPatch:  p.Patch,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+// Parse reads an OpenAPI document and resolves its references, failing on
+// ones that point nowhere and on invalid patterns.
+func Parse(data []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+	if len(doc.Servers) == 0 {
+		doc.Servers = []Server{{URL: "/"}}
+	}
+
+	for _, schema := range doc.Components.Schemas {
+		if err := doc.resolveSchema(schema); err != nil {
+			return nil, err
+		}
+	}
+	for path, item := range doc.Paths {
+		if err := doc.resolveParameters(item.Parameters); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for method, operation := range item.Operations() {
+			if err := doc.resolveParameters(operation.Parameters); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if operation.RequestBody == nil {
+				continue
+			}
+			for _, media := range operation.RequestBody.Content {
+				if err := doc.resolveSchema(media.Schema); err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+			}
+		}
+	}
+
+	doc.routes = doc.routeTemplates()
+	return &doc, nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for documents
+// embedded in the binary.
+func MustParse(data []byte) *Document {
+	doc, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return doc
+}
+
+// Schema returns the component schema with the given name, or nil.
+func (d *Document) Schema(name string) *Schema {
+	return d.Components.Schemas[name]
+}
+
+func (d *Document) resolveParameters(parameters []*Parameter) error {
+	for i, parameter := range parameters {
+		if parameter.Ref != "" {
+			resolved, ok := d.Components.Parameters[strings.TrimPrefix(parameter.Ref, "#/components/parameters/")]
+			if !ok {
+				return fmt.Errorf("unknown parameter %s", parameter.Ref)
+			}
+			parameters[i], parameter = resolved, resolved
+		}
+		if err := d.resolveSchema(parameter.Schema); err != nil {
+			return fmt.Errorf("parameter %s: %w", parameter.Name, err)
+		}
+	}
+	return nil
+}
+
+func (d *Document) resolveSchema(schema *Schema) error {
+	if schema == nil || schema.resolved {
+		return nil
+	}
+	schema.resolved = true
+
+	if schema.Ref != "" {
+		target, ok := d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			return fmt.Errorf("unknown schema %s", schema.Ref)
+		}
+		schema.target = target
+		return d.resolveSchema(target)
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", schema.Pattern, err)
+		}
+		schema.pattern = pattern
+	}
+
+	if err := d.resolveSchema(schema.Items); err != nil {
+		return err
+	}
+	if err := d.resolveSchema(schema.AdditionalProperties); err != nil {
+		return err
+	}
+	for _, property := range schema.Properties {
+		if err := d.resolveSchema(property); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/openapi"
+	"github.com/stretchr/testify/suite"
+)
+
+const petsDocument = `{
+	"openapi": "3.1.0",
+	"servers": [{"url": "/api/v1"}],
+	"paths": {
+		"/pets": {
+			"servers": [{"url": "/api/v1"}, {"url": "/api/v1/shops/{shop}"}],
+			"get": {
+				"operationId": "listPets",
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1}},
+					{"name": "since", "in": "query", "schema": {"type": "string", "format": "date-time"}}
+				]
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+			}
+		},
+		"/pets/{petId}": {
+			"parameters": [{"$ref": "#/components/parameters/PetID"}],
+			"get": {"operationId": "getPet"},
+			"delete": {"operationId": "deletePet", "security": []}
+		},
+		"/pets/search": {
+			"get": {
+				"operationId": "searchPets",
+				"parameters": [{"name": "q", "in": "query", "required": true, "schema": {"type": "string"}}]
+			}
+		},
+		"/pets/{petId}/photo": {
+			"parameters": [{"$ref": "#/components/parameters/PetID"}],
+			"put": {
+				"operationId": "uploadPhoto",
+				"requestBody": {"required": true, "content": {"multipart/form-data": {"schema": {"type": "object"}}}}
+			}
+		}
+	},
+	"components": {
+		"parameters": {
+			"PetID": {"name": "petId", "in": "path", "required": true, "schema": {"type": "string", "pattern": "^p[0-9]+$"}}
+		},
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string", "minLength": 1},
+					"kind": {"type": "string", "enum": ["cat", "dog"]},
+					"age": {"type": ["integer", "null"], "minimum": 0},
+					"tags": {"type": "array", "items": {"$ref": "#/components/schemas/Tag"}}
+				}
+			},
+			"Tag": {
+				"type": "object",
+				"required": ["label"],
+				"properties": {"label": {"type": "string"}},
+				"additionalProperties": false
+			}
+		}
+	}
+}`
+
+type DocumentTestSuite struct {
+	suite.Suite
+}
+
+func (s *DocumentTestSuite) TestParse() {
+	s.Run("should resolve parameter and schema references", func() {
+		doc, err := openapi.Parse([]byte(petsDocument))
+
+		s.Require().NoError(err)
+		parameter := doc.Paths["/pets/{petId}"].Parameters[0]
+		s.Equal("petId", parameter.Name)
+		s.Equal("path", parameter.In)
+		s.Equal(doc.Schema("Tag"), doc.Schema("Pet").Properties["tags"].Items.Resolved())
+	})
+
+	s.Run("should list the operations of a path by method", func() {
+		doc, err := openapi.Parse([]byte(petsDocument))
+
+		s.Require().NoError(err)
+		operations := doc.Paths["/pets/{petId}"].Operations()
+		s.Len(operations, 2)
+		s.Equal("getPet", operations["GET"].OperationID)
+		s.Equal("deletePet", operations["DELETE"].OperationID)
+		s.NotNil(operations["DELETE"].Security)
+		s.Empty(operations["DELETE"].Security)
+	})
+
+	s.Run("should reject references that point nowhere", func() {
+		_, err := openapi.Parse([]byte(`{"openapi": "3.1.0", "paths": {"/pets": {"post": {"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Missing"}}}}}}}}`))
+
+		s.ErrorContains(err, "unknown schema #/components/schemas/Missing")
+	})
+
+	s.Run("should reject invalid patterns", func() {
+		_, err := openapi.Parse([]byte(`{"openapi": "3.1.0", "components": {"schemas": {"ID": {"type": "string", "pattern": "("}}}}`))
+
+		s.ErrorContains(err, "invalid pattern")
+	})
+
+	s.Run("should reject documents other than OpenAPI 3", func() {
+		_, err := openapi.Parse([]byte(`{"swagger": "2.0"}`))
+
+		s.ErrorContains(err, "unsupported OpenAPI version")
+	})
+}
+
+func TestDocumentTestSuite(t *testing.T) {
+	suite.Run(t, new(DocumentTestSuite))
+}
@@ -0,0 +1,302 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"appstorereviewsviewer/internal/domain/fault"
+)
+
+// Route is the documented path a request addresses.
+type Route struct {
+	// Path is the path template, e.g. "/app/{id}".
+	Path     string
+	PathItem *PathItem
+	// Operation is nil when the path is documented without the request's
+	// method.
+	Operation *Operation
+	// PathParams holds the values of the template's wildcards, including
+	// those of the server URL.
+	PathParams map[string]string
+}
+
+type routeTemplate struct {
+	path     string
+	item     *PathItem
+	segments []string
+	literals int
+}
+
+// FindRoute returns the documented path matching a request path, preferring
+// templates with more literal segments. HEAD requests use the GET operation.
+func (d *Document) FindRoute(method, path string) (*Route, bool) {
+	segments := splitPath(path)
+	var best *routeTemplate
+	for i, template := range d.routes {
+		if matchSegments(template.segments, segments) && (best == nil || template.literals > best.literals) {
+			best = &d.routes[i]
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	route := &Route{
+		Path:       best.path,
+		PathItem:   best.item,
+		Operation:  best.item.Operations()[method],
+		PathParams: map[string]string{},
+	}
+	for i, segment := range best.segments {
+		if name, ok := wildcard(segment); ok {
+			route.PathParams[name] = segments[i]
+		}
+	}
+	return route, true
+}
+
+// FindPatternRoute returns the documented path a net/http ServeMux pattern
+// such as "GET /api/v1/app/{id}" serves. Unlike FindRoute it compares
+// templates rather than the request path, so wildcard values containing
+// slashes cannot make a request look like another route. pathValue returns
+// the values of the pattern's wildcards, as r.PathValue does.
+func (d *Document) FindPatternRoute(pattern string, pathValue func(name string) string) (*Route, bool) {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found {
+		method, path = "", pattern
+	}
+	segments := splitPath(path)
+	var best *routeTemplate
+	for i, template := range d.routes {
+		if matchTemplate(template.segments, segments) {
+			best = &d.routes[i]
+			break
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	route := &Route{
+		Path:       best.path,
+		PathItem:   best.item,
+		Operation:  best.item.Operations()[method],
+		PathParams: map[string]string{},
+	}
+	for i, segment := range best.segments {
+		if name, ok := wildcard(segment); ok {
+			patternName, _ := wildcard(segments[i])
+			route.PathParams[name] = pathValue(strings.TrimSuffix(patternName, "..."))
+		}
+	}
+	return route, true
+}
+
+// ValidateRequest checks the parameters and JSON body of a request against
+// the operation it addresses. Requests a ServeMux routed are looked up by
+// the pattern that matched them, others by their path. Requests no
+// operation documents are rejected as not found, so that routes missing
+// from the document fail closed. Mismatches are validation faults; errors
+// reading the body, such as exceeding its size limit, are returned as is.
+// The body stays readable for the handler.
+func (d *Document) ValidateRequest(r *http.Request) error {
+	var route *Route
+	var ok bool
+	if r.Pattern != "" {
+		route, ok = d.FindPatternRoute(r.Pattern, r.PathValue)
+	} else {
+		route, ok = d.FindRoute(r.Method, r.URL.Path)
+	}
+	if !ok || route.Operation == nil {
+		return fault.New(fault.KindNotFound, fmt.Sprintf("%s %s is not a documented operation", r.Method, r.URL.Path))
+	}
+
+	query := r.URL.Query()
+	for _, parameter := range route.parameters() {
+		var raw string
+		var present bool
+		switch parameter.In {
+		case "path":
+			raw, present = route.PathParams[parameter.Name]
+		case "query":
+			present = query.Has(parameter.Name)
+			raw = query.Get(parameter.Name)
+		case "header":
+			raw = r.Header.Get(parameter.Name)
+			present = raw != ""
+		default:
+			continue
+		}
+
+		if !present || raw == "" && parameter.In == "query" {
+			if parameter.Required {
+				return fault.New(fault.KindValidation, fmt.Sprintf("%s parameter %q is required", parameter.In, parameter.Name))
+			}
+			continue
+		}
+		if parameter.Schema == nil {
+			continue
+		}
+		if err := parameter.Schema.Validate(parseScalar(raw, parameter.Schema)); err != nil {
+			return fault.New(fault.KindValidation, fmt.Sprintf("invalid %s parameter %q: %v", parameter.In, parameter.Name, err))
+		}
+	}
+
+	return validateBody(r, route.Operation.RequestBody)
+}
+
+// parameters merges the path item's parameters with the operation's, which
+// take precedence.
+func (r *Route) parameters() []*Parameter {
+	parameters := slices.Clone(r.Operation.Parameters)
+	for _, inherited := range r.PathItem.Parameters {
+		overridden := slices.ContainsFunc(parameters, func(p *Parameter) bool {
+			return p.Name == inherited.Name && p.In == inherited.In
+		})
+		if !overridden {
+			parameters = append(parameters, inherited)
+		}
+	}
+	return parameters
+}
+
+func validateBody(r *http.Request, body *RequestBody) error {
+	if body == nil {
+		return nil
+	}
+	// Other media types, such as multipart uploads, are checked by their
+	// handlers.
+	media, ok := body.Content["application/json"]
+	if !ok {
+		return nil
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		if body.Required {
+			return fault.New(fault.KindValidation, "request body is required")
+		}
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fault.New(fault.KindValidation, "request body is not valid JSON")
+	}
+	if media.Schema != nil {
+		if err := media.Schema.Validate(value); err != nil {
+			return fault.New(fault.KindValidation, "invalid request body: "+err.Error())
+		}
+	}
+	return nil
+}
+
+// parseScalar converts a parameter to the first type of its schema it
+// parses as, leaving it a string otherwise so that validation reports the
+// expected type.
+func parseScalar(raw string, schema *Schema) any {
+	for _, name := range schema.Resolved().Type {
+		switch name {
+		case "integer", "number":
+			if number, err := strconv.ParseFloat(raw, 64); err == nil {
+				return number
+			}
+		case "boolean":
+			if boolean, err := strconv.ParseBool(raw); err == nil {
+				return boolean
+			}
+		}
+	}
+	return raw
+}
+
+func (d *Document) routeTemplates() []routeTemplate {
+	var templates []routeTemplate
+	for path, item := range d.Paths {
+		servers := item.Servers
+		if len(servers) == 0 {
+			servers = d.Servers
+		}
+		for _, server := range servers {
+			prefix := server.URL
+			if parsed, err := url.Parse(server.URL); err == nil {
+				prefix = parsed.Path
+			}
+
+			template := routeTemplate{path: path, item: item}
+			template.segments = append(splitPath(prefix), splitPath(path)...)
+			for _, segment := range template.segments {
+				if _, ok := wildcard(segment); !ok {
+					template.literals++
+				}
+			}
+			templates = append(templates, template)
+		}
+	}
+	return templates
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func matchSegments(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, segment := range template {
+		if _, ok := wildcard(segment); ok {
+			if segments[i] == "" {
+				return false
+			}
+		} else if segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTemplate reports whether a pattern's segments equal a template's,
+// taking any two wildcards as equal.
+func matchTemplate(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, segment := range template {
+		_, templateWildcard := wildcard(segment)
+		_, patternWildcard := wildcard(segments[i])
+		if templateWildcard != patternWildcard || !templateWildcard && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func wildcard(segment string) (string, bool) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
@@ -0,0 +1,171 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/infrastructure/openapi"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestTestSuite struct {
+	suite.Suite
+	doc *openapi.Document
+}
+
+func (s *RequestTestSuite) SetupSubTest() {
+	var err error
+	s.doc, err = openapi.Parse([]byte(petsDocument))
+	s.Require().NoError(err)
+}
+
+func (s *RequestTestSuite) TestFindRoute() {
+	s.Run("should match paths below every server of a path", func() {
+		route, ok := s.doc.FindRoute(http.MethodGet, "/api/v1/shops/north/pets")
+
+		s.Require().True(ok)
+		s.Equal("/pets", route.Path)
+		s.Equal("listPets", route.Operation.OperationID)
+		s.Equal("north", route.PathParams["shop"])
+	})
+
+	s.Run("should prefer literal segments over wildcards", func() {
+		route, ok := s.doc.FindRoute(http.MethodGet, "/api/v1/pets/search")
+
+		s.Require().True(ok)
+		s.Equal("/pets/search", route.Path)
+	})
+
+	s.Run("should extract path parameters", func() {
+		route, ok := s.doc.FindRoute(http.MethodDelete, "/api/v1/pets/p12")
+
+		s.Require().True(ok)
+		s.Equal("deletePet", route.Operation.OperationID)
+		s.Equal(map[string]string{"petId": "p12"}, route.PathParams)
+	})
+
+	s.Run("should answer HEAD requests with the GET operation", func() {
+		route, ok := s.doc.FindRoute(http.MethodHead, "/api/v1/pets/p12")
+
+		s.Require().True(ok)
+		s.Equal("getPet", route.Operation.OperationID)
+	})
+
+	s.Run("should leave the operation empty for undocumented methods", func() {
+		route, ok := s.doc.FindRoute(http.MethodPatch, "/api/v1/pets/p12")
+
+		s.Require().True(ok)
+		s.Nil(route.Operation)
+	})
+
+	s.Run("should match patterns by template and read their wildcards", func() {
+		values := map[string]string{"id": "p1/photo"}
+
+		route, ok := s.doc.FindPatternRoute("DELETE /api/v1/pets/{id}", func(name string) string { return values[name] })
+
+		s.Require().True(ok)
+		s.Equal("/pets/{petId}", route.Path)
+		s.Equal("deletePet", route.Operation.OperationID)
+		s.Equal(map[string]string{"petId": "p1/photo"}, route.PathParams)
+	})
+
+	s.Run("should not match patterns by their literal wildcards", func() {
+		_, ok := s.doc.FindPatternRoute("GET /api/v1/pets/{petId}/photo/{extra}", func(string) string { return "" })
+
+		s.False(ok)
+	})
+
+	s.Run("should not match undocumented paths", func() {
+		for _, path := range []string{"/api/v1/cats", "/api/v1/pets/p1/photo/extra", "/pets", "/api/v1/shops/north/pets/search"} {
+			_, ok := s.doc.FindRoute(http.MethodGet, path)
+
+			s.False(ok, path)
+		}
+	})
+}
+
+func (s *RequestTestSuite) TestValidateRequest() {
+	s.Run("should accept valid requests and keep their body readable", func() {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name": "Tom"}`))
+
+		s.Require().NoError(s.doc.ValidateRequest(req))
+		body, err := io.ReadAll(req.Body)
+		s.NoError(err)
+		s.Equal(`{"name": "Tom"}`, string(body))
+	})
+
+	s.Run("should reject invalid requests as validation errors", func() {
+		cases := map[*http.Request]string{
+			httptest.NewRequest(http.MethodGet, "/api/v1/pets?limit=0", nil):                       `invalid query parameter "limit": must be at least 1`,
+			httptest.NewRequest(http.MethodGet, "/api/v1/pets?limit=ten", nil):                     `invalid query parameter "limit": must be an integer`,
+			httptest.NewRequest(http.MethodGet, "/api/v1/pets?since=yesterday", nil):               `invalid query parameter "since": must be an RFC 3339 date-time`,
+			httptest.NewRequest(http.MethodGet, "/api/v1/pets/search", nil):                        `query parameter "q" is required`,
+			httptest.NewRequest(http.MethodGet, "/api/v1/pets/12", nil):                            `invalid path parameter "petId": must match ^p[0-9]+$`,
+			httptest.NewRequest(http.MethodPost, "/api/v1/pets", nil):                              "request body is required",
+			httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader("{")):           "request body is not valid JSON",
+			httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name": 1}`)): "invalid request body: name must be a string",
+		}
+		for req, message := range cases {
+			err := s.doc.ValidateRequest(req)
+
+			s.EqualError(err, message, req.URL.String())
+			s.Equal(fault.KindValidation, fault.KindOf(err))
+		}
+	})
+
+	s.Run("should leave other media types to the handler", func() {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/pets/p1/photo", strings.NewReader("not json"))
+
+		s.NoError(s.doc.ValidateRequest(req))
+	})
+
+	s.Run("should reject undocumented requests as not found", func() {
+		requests := []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/api/v1/cats?limit=0", nil),
+			httptest.NewRequest(http.MethodPatch, "/api/v1/pets/12", nil),
+		}
+		for _, req := range requests {
+			err := s.doc.ValidateRequest(req)
+
+			s.Error(err, req.URL.String())
+			s.Equal(fault.KindNotFound, fault.KindOf(err), req.URL.String())
+		}
+	})
+
+	s.Run("should validate routed requests against the operation of their pattern", func() {
+		var errs []error
+		mux := http.NewServeMux()
+		validate := func(w http.ResponseWriter, r *http.Request) { errs = append(errs, s.doc.ValidateRequest(r)) }
+		mux.HandleFunc("GET /api/v1/pets/{petId}", validate)
+		mux.HandleFunc("GET /api/v1/cats", validate)
+
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/pets/p1", nil))
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/pets/p1%2Fphoto", nil))
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/cats", nil))
+
+		s.Require().Len(errs, 3)
+		s.NoError(errs[0])
+		s.EqualError(errs[1], `invalid path parameter "petId": must match ^p[0-9]+$`)
+		s.Equal(fault.KindNotFound, fault.KindOf(errs[2]))
+	})
+
+	s.Run("should return errors reading the body as they are", func() {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name": "Tom"}`))
+		req.Body = http.MaxBytesReader(rr, req.Body, 4)
+
+		err := s.doc.ValidateRequest(req)
+
+		var maxBytesErr *http.MaxBytesError
+		s.ErrorAs(err, &maxBytesErr)
+		s.Empty(fault.KindOf(err))
+	})
+}
+
+func TestRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestTestSuite))
+}
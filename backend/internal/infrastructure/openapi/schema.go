@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema is the subset of JSON Schema used by the API's OpenAPI document.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 Types              `json:"type"`
+	Format               string             `json:"format"`
+	Enum                 []any              `json:"enum"`
+	Pattern              string             `json:"pattern"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Items                *Schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+
+	// never is set for the boolean schema false, which no value matches.
+	never    bool
+	resolved bool
+	target   *Schema
+	pattern  *regexp.Regexp
+}
+
+// Types is a schema's type, written as a single name or, in OpenAPI 3.1, a
+// list such as ["string", "null"].
+type Types []string
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings: %w", err)
+	}
+	*t = list
+	return nil
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(data, &boolean); err == nil {
+		*s = Schema{never: !boolean}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Resolved follows the schema's reference, if it has one.
+func (s *Schema) Resolved() *Schema {
+	if s.target != nil {
+		return s.target
+	}
+	return s
+}
+
+// SchemaError reports where and why a value does not match a schema.
+type SchemaError struct {
+	// Path locates the value, e.g. "sources[0].name", and is empty for the
+	// value itself.
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + " " + e.Message
+}
+
+// Validate checks a value decoded from JSON, with numbers as float64,
+// against the schema.
+func (s *Schema) Validate(value any) error {
+	return s.validate(value, "")
+}
+
+func (s *Schema) validate(value any, path string) error {
+	s = s.Resolved()
+	fail := func(format string, args ...any) error {
+		return &SchemaError{Path: path, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if s.never {
+		return fail("is not allowed")
+	}
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return hasType(value, t) }) {
+		return fail("must be %s", describeTypes(s.Type))
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(allowed any) bool { return reflect.DeepEqual(allowed, value) }) {
+		return fail("must be one of %s", describeEnum(s.Enum))
+	}
+
+	switch value := value.(type) {
+	case string:
+		return s.validateString(value, fail)
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			return fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			return fail("must be at most %v", *s.Maximum)
+		}
+	case []any:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			return fail("must have at least %d items", *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case map[string]any:
+		return s.validateObject(value, path)
+	}
+	return nil
+}
+
+func (s *Schema) validateString(value string, fail func(string, ...any) error) error {
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		if *s.MinLength == 1 {
+			return fail("must not be empty")
+		}
+		return fail("must be at least %d characters long", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fail("must be at most %d characters long", *s.MaxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return fail("must match %s", s.Pattern)
+	}
+	if s.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fail("must be an RFC 3339 date-time")
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(value map[string]any, path string) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return &SchemaError{Path: join(path, name), Message: "is required"}
+		}
+	}
+
+	// Properties are checked in order so that the same value always reports
+	// the same error.
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			property = s.AdditionalProperties
+		}
+		if property == nil {
+			continue
+		}
+		if err := property.validate(value[name], join(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasType(value any, name string) bool {
+	switch value := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case float64:
+		return name == "number" || name == "integer" && value == math.Trunc(value)
+	case []any:
+		return name == "array"
+	case map[string]any:
+		return name == "object"
+	}
+	return false
+}
+
+func describeTypes(types Types) string {
+	described := make([]string, len(types))
+	for i, name := range types {
+		switch name {
+		case "null":
+			described[i] = "null"
+		case "array", "integer", "object":
+			described[i] = "an " + name
+		default:
+			described[i] = "a " + name
+		}
+	}
+	return strings.Join(described, " or ")
+}
+
+func describeEnum(values []any) string {
+	described := make([]string, len(values))
+	for i, value := range values {
+		encoded, _ := json.Marshal(value)
+		described[i] = string(encoded)
+	}
+	return strings.Join(described, ", ")
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,58 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/openapi"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+	pet *openapi.Schema
+}
+
+func (s *SchemaTestSuite) SetupSubTest() {
+	doc, err := openapi.Parse([]byte(petsDocument))
+	s.Require().NoError(err)
+	s.pet = doc.Schema("Pet")
+}
+
+func (s *SchemaTestSuite) validate(value string) error {
+	var decoded any
+	s.Require().NoError(json.Unmarshal([]byte(value), &decoded))
+	return s.pet.Validate(decoded)
+}
+
+func (s *SchemaTestSuite) TestValidate() {
+	s.Run("should accept matching values", func() {
+		s.NoError(s.validate(`{"name": "Tom", "kind": "cat", "age": 3, "tags": [{"label": "grey"}], "extra": true}`))
+		s.NoError(s.validate(`{"name": "Rex", "age": null}`))
+	})
+
+	s.Run("should report where values do not match", func() {
+		cases := map[string]string{
+			`[]`:                              "must be an object",
+			`{}`:                              "name is required",
+			`{"name": 5}`:                     "name must be a string",
+			`{"name": ""}`:                    "name must not be empty",
+			`{"name": "Tom", "kind": "bird"}`: `kind must be one of "cat", "dog"`,
+			`{"name": "Tom", "age": 1.5}`:     "age must be an integer or null",
+			`{"name": "Tom", "age": -1}`:      "age must be at least 0",
+			`{"name": "Tom", "tags": [{}]}`:   "tags[0].label is required",
+			`{"name": "Tom", "tags": [{"label": "a", "color": "b"}]}`: "tags[0].color is not allowed",
+		}
+		for value, message := range cases {
+			err := s.validate(value)
+
+			s.EqualError(err, message, value)
+			var schemaErr *openapi.SchemaError
+			s.ErrorAs(err, &schemaErr)
+		}
+	})
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
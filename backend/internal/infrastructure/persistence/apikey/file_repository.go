@@ -0,0 +1,188 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/user"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+)
+
+// FileRepository keeps API keys in api_keys.json in the data directory. The
+// file holds only hashes, but is written readable by its owner alone.
+type FileRepository struct {
+	dataDir string
+	// mu serialises the read-modify-write cycles of Save and Delete.
+	mu sync.Mutex
+}
+
+type KeyData struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Role       string    `json:"role"`
+	Hash       string    `json:"hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	Workspaces []string  `json:"workspaces"`
+}
+
+func NewFileRepository(dataDir string) (*FileRepository, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	return &FileRepository{
+		dataDir: dataDir,
+	}, nil
+}
+
+// FindAll returns the keys ordered by creation.
+func (r *FileRepository) FindAll(ctx context.Context) ([]*apikey.Key, error) {
+	keysData, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*apikey.Key, len(keysData))
+	for i, keyData := range keysData {
+		keys[i] = keyData.toKey()
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+
+	return keys, nil
+}
+
+func (r *FileRepository) FindByHash(ctx context.Context, hash string) (*apikey.Key, error) {
+	keysData, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, keyData := range keysData {
+		if subtle.ConstantTimeCompare([]byte(keyData.Hash), []byte(hash)) == 1 {
+			return keyData.toKey(), nil
+		}
+	}
+	return nil, apikey.ErrNotFound
+}
+
+func (r *FileRepository) Save(ctx context.Context, key *apikey.Key) error {
+	if key == nil {
+		return fmt.Errorf("API key cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keysData, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	keyData := KeyData{
+		ID:        key.ID,
+		Name:      key.Name,
+		Role:      string(key.Role),
+		Hash:      key.Hash,
+		CreatedAt: key.CreatedAt,
+		// A key without workspaces is stored with an empty list, so that it
+		// is not read back as one stored before keys had workspaces.
+		Workspaces: append([]string{}, key.Workspaces...),
+	}
+
+	replaced := false
+	for i := range keysData {
+		if keysData[i].ID == key.ID {
+			keysData[i] = keyData
+			replaced = true
+		}
+	}
+	if !replaced {
+		keysData = append(keysData, keyData)
+	}
+
+	return r.write(keysData)
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keysData, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]KeyData, 0, len(keysData))
+	for _, keyData := range keysData {
+		if keyData.ID != id {
+			remaining = append(remaining, keyData)
+		}
+	}
+
+	if len(remaining) == len(keysData) {
+		return fmt.Errorf("%w: %s", apikey.ErrNotFound, id)
+	}
+
+	return r.write(remaining)
+}
+
+func (r *FileRepository) read() ([]KeyData, error) {
+	data, err := os.ReadFile(r.getFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var keysData []KeyData
+	if err := json.Unmarshal(data, &keysData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API keys: %w", err)
+	}
+	return keysData, nil
+}
+
+func (r *FileRepository) write(keysData []KeyData) error {
+	data, err := json.MarshalIndent(keysData, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal API keys: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(r.getFilePath(), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+func (d KeyData) toKey() *apikey.Key {
+	return &apikey.Key{
+		ID:         d.ID,
+		Name:       d.Name,
+		Role:       user.Role(d.Role),
+		Hash:       d.Hash,
+		CreatedAt:  d.CreatedAt,
+		Workspaces: d.workspaces(),
+	}
+}
+
+// workspaces returns the key's workspaces. Keys stored before keys were
+// limited to workspaces have none recorded and keep access to every
+// workspace.
+func (d KeyData) workspaces() []string {
+	if d.Workspaces == nil {
+		return []string{apikey.AllWorkspaces}
+	}
+	return d.Workspaces
+}
+
+func (r *FileRepository) getFilePath() string {
+	return filepath.Join(r.dataDir, "api_keys.json")
+}
@@ -0,0 +1,141 @@
+package apikey_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/apikey"
+	"appstorereviewsviewer/internal/domain/user"
+	apikeyRepo "appstorereviewsviewer/internal/infrastructure/persistence/apikey"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyFileRepositoryTestSuite struct {
+	suite.Suite
+	tempDir string
+	repo    *apikeyRepo.FileRepository
+}
+
+func (s *APIKeyFileRepositoryTestSuite) SetupSubTest() {
+	s.tempDir = s.T().TempDir()
+
+	var err error
+	s.repo, err = apikeyRepo.NewFileRepository(s.tempDir)
+	s.Require().NoError(err)
+}
+
+func (s *APIKeyFileRepositoryTestSuite) key(id string, role user.Role, createdAt time.Time) *apikey.Key {
+	return &apikey.Key{ID: id, Name: "key " + id, Role: role, Hash: apikey.Hash("secret-" + id), CreatedAt: createdAt, Workspaces: []string{apikey.AllWorkspaces}}
+}
+
+func (s *APIKeyFileRepositoryTestSuite) TestFindAll() {
+	s.Run("should return no keys when the file does not exist", func() {
+		keys, err := s.repo.FindAll(context.Background())
+
+		s.NoError(err)
+		s.Empty(keys)
+	})
+
+	s.Run("should return keys ordered by creation", func() {
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k2", user.RoleAdmin, now.Add(time.Hour))))
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k1", user.RoleViewer, now)))
+
+		keys, err := s.repo.FindAll(context.Background())
+
+		s.Require().NoError(err)
+		s.Require().Len(keys, 2)
+		s.Equal(s.key("k1", user.RoleViewer, now), keys[0])
+		s.Equal("k2", keys[1].ID)
+	})
+}
+
+func (s *APIKeyFileRepositoryTestSuite) TestFindByHash() {
+	s.Run("should find a key by the hash of its secret", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k1", user.RoleTriager, time.Time{})))
+
+		key, err := s.repo.FindByHash(context.Background(), apikey.Hash("secret-k1"))
+
+		s.Require().NoError(err)
+		s.Equal("k1", key.ID)
+		s.Equal(user.RoleTriager, key.Role)
+		s.Equal([]string{"*"}, key.Workspaces)
+	})
+
+	s.Run("should keep the workspaces a key is limited to", func() {
+		key := s.key("k1", user.RoleViewer, time.Time{})
+		key.Workspaces = []string{"team-a", "team-b"}
+		s.Require().NoError(s.repo.Save(context.Background(), key))
+
+		found, err := s.repo.FindByHash(context.Background(), apikey.Hash("secret-k1"))
+
+		s.Require().NoError(err)
+		s.Equal([]string{"team-a", "team-b"}, found.Workspaces)
+	})
+
+	s.Run("should keep keys without workspaces limited to none", func() {
+		key := s.key("k1", user.RoleViewer, time.Time{})
+		key.Workspaces = nil
+		s.Require().NoError(s.repo.Save(context.Background(), key))
+
+		found, err := s.repo.FindByHash(context.Background(), apikey.Hash("secret-k1"))
+
+		s.Require().NoError(err)
+		s.NotNil(found.Workspaces)
+		s.Empty(found.Workspaces)
+	})
+
+	s.Run("should give keys stored before workspaces every workspace", func() {
+		s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "api_keys.json"),
+			[]byte(`[{"id":"k1","name":"old","role":"admin","hash":"`+apikey.Hash("secret-k1")+`"}]`), 0o600))
+
+		found, err := s.repo.FindByHash(context.Background(), apikey.Hash("secret-k1"))
+
+		s.Require().NoError(err)
+		s.Equal([]string{"*"}, found.Workspaces)
+	})
+
+	s.Run("should return not found for unknown secrets", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k1", user.RoleTriager, time.Time{})))
+
+		_, err := s.repo.FindByHash(context.Background(), apikey.Hash("other"))
+
+		s.ErrorIs(err, apikey.ErrNotFound)
+	})
+}
+
+func (s *APIKeyFileRepositoryTestSuite) TestSave() {
+	s.Run("should write the file readable by its owner only", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k1", user.RoleAdmin, time.Time{})))
+
+		info, err := os.Stat(filepath.Join(s.tempDir, "api_keys.json"))
+
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(0o600), info.Mode().Perm())
+	})
+}
+
+func (s *APIKeyFileRepositoryTestSuite) TestDelete() {
+	s.Run("should delete a key", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.key("k1", user.RoleAdmin, time.Time{})))
+
+		s.Require().NoError(s.repo.Delete(context.Background(), "k1"))
+
+		keys, err := s.repo.FindAll(context.Background())
+		s.Require().NoError(err)
+		s.Empty(keys)
+	})
+
+	s.Run("should return not found for unknown keys", func() {
+		err := s.repo.Delete(context.Background(), "missing")
+
+		s.ErrorIs(err, apikey.ErrNotFound)
+	})
+}
+
+func TestAPIKeyFileRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyFileRepositoryTestSuite))
+}
@@ -1,20 +1,32 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
 )
 
 type FileRepository struct {
 	dataDir string
+	// mu serialises the read-modify-write cycles of Save and Delete.
+	mu sync.Mutex
 }
 
 type AppData struct {
-	ID string `json:"id"`
+	ID      string       `json:"id"`
+	Name    string       `json:"name,omitempty"`
+	Sources []SourceData `json:"sources,omitempty"`
+}
+
+type SourceData struct {
+	Name       string `json:"name"`
+	ExternalID string `json:"externalId"`
 }
 
 func NewFileRepository(dataDir string) (*FileRepository, error) {
@@ -27,7 +39,7 @@ func NewFileRepository(dataDir string) (*FileRepository, error) {
 	}, nil
 }
 
-func (r *FileRepository) FindAll() ([]*app.App, error) {
+func (r *FileRepository) FindAll(ctx context.Context) ([]*app.App, error) {
 	filePath := r.getFilePath()
 
 	data, err := os.ReadFile(filePath)
@@ -45,20 +57,32 @@ func (r *FileRepository) FindAll() ([]*app.App, error) {
 
 	var apps []*app.App
 	for _, appData := range appsData {
-		app, err := app.NewApp(appData.ID)
+		sources := make([]app.Source, len(appData.Sources))
+		for i, source := range appData.Sources {
+			sources[i] = app.Source{Name: source.Name, ExternalID: source.ExternalID}
+		}
+
+		app, err := app.NewApp(appData.ID, sources...)
 		if err != nil {
 			continue
 		}
+		app.Name = appData.Name
 		apps = append(apps, app)
 	}
 
 	return apps, nil
 }
 
-func (r *FileRepository) Save(app *app.App) error {
-	if app == nil {
+func (r *FileRepository) Save(ctx context.Context, a *app.App) error {
+	if a == nil {
 		return fmt.Errorf("app cannot be nil")
 	}
+	if err := app.ValidateID(a.ID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	filePath := r.getFilePath()
 
@@ -75,9 +99,13 @@ func (r *FileRepository) Save(app *app.App) error {
 	}
 
 	appData := AppData{
-		ID: app.ID,
+		ID:   a.ID,
+		Name: a.Name,
+	}
+	for _, source := range a.Sources {
+		appData.Sources = append(appData.Sources, SourceData{Name: source.Name, ExternalID: source.ExternalID})
 	}
-	appMap[app.ID] = appData
+	appMap[a.ID] = appData
 
 	var allApps []AppData
 	for _, app := range appMap {
@@ -89,7 +117,53 @@ func (r *FileRepository) Save(app *app.App) error {
 		return fmt.Errorf("failed to marshal apps: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+	if err := atomicfile.WriteFile(filePath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	if err := app.ValidateID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	filePath := r.getFilePath()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", app.ErrNotFound, id)
+		}
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var existingApps []AppData
+	if err := json.Unmarshal(data, &existingApps); err != nil {
+		return fmt.Errorf("failed to unmarshal existing apps: %w", err)
+	}
+
+	remainingApps := make([]AppData, 0, len(existingApps))
+	for _, existingApp := range existingApps {
+		if existingApp.ID != id {
+			remainingApps = append(remainingApps, existingApp)
+		}
+	}
+
+	if len(remainingApps) == len(existingApps) {
+		return fmt.Errorf("%w: %s", app.ErrNotFound, id)
+	}
+
+	data, err = json.MarshalIndent(remainingApps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal apps: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(filePath, data, 0o644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
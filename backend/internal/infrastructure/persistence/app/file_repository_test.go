@@ -1,6 +1,7 @@
 package app_test
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -56,7 +57,7 @@ func (s *AppFileRepositoryTestSuite) TestNewFileRepository() {
 
 func (s *AppFileRepositoryTestSuite) TestFindAll() {
 	s.Run("should return empty slice when no apps file exists", func() {
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 
 		s.NoError(err)
 		s.Empty(apps)
@@ -64,10 +65,10 @@ func (s *AppFileRepositoryTestSuite) TestFindAll() {
 
 	s.Run("should return apps when file exists with valid data", func() {
 		testApp, _ := app.NewApp("12345")
-		err := s.repo.Save(testApp)
+		err := s.repo.Save(context.Background(), testApp)
 		s.Require().NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 
 		s.NoError(err)
 		s.Len(apps, 1)
@@ -79,7 +80,7 @@ func (s *AppFileRepositoryTestSuite) TestFindAll() {
 		err := os.WriteFile(filePath, []byte("[]"), 0644)
 		s.Require().NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 
 		s.NoError(err)
 		s.Empty(apps)
@@ -90,7 +91,7 @@ func (s *AppFileRepositoryTestSuite) TestFindAll() {
 		err := os.WriteFile(filePath, []byte("invalid json"), 0644)
 		s.Require().NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 
 		s.Error(err)
 		s.Nil(apps)
@@ -103,7 +104,7 @@ func (s *AppFileRepositoryTestSuite) TestFindAll() {
 		err := os.WriteFile(filePath, []byte(invalidAppsJSON), 0644)
 		s.Require().NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 
 		s.NoError(err)
 		s.Len(apps, 2)
@@ -116,35 +117,60 @@ func (s *AppFileRepositoryTestSuite) TestSave() {
 	s.Run("should save app successfully when valid app provided", func() {
 		testApp, _ := app.NewApp("12345")
 
-		err := s.repo.Save(testApp)
+		err := s.repo.Save(context.Background(), testApp)
 
 		s.NoError(err)
 		s.FileExists(filepath.Join(s.tempDir, "apps.json"))
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 		s.NoError(err)
 		s.Len(apps, 1)
 		s.Equal("12345", apps[0].ID)
 	})
 
+	s.Run("should keep the name and review sources of an app", func() {
+		testApp, _ := app.NewApp("12345",
+			app.Source{Name: "appstore"},
+			app.Source{Name: "googleplay", ExternalID: "com.example.app"},
+		)
+		testApp.Name = "Example"
+
+		err := s.repo.Save(context.Background(), testApp)
+		s.Require().NoError(err)
+
+		apps, err := s.repo.FindAll(context.Background())
+		s.Require().NoError(err)
+		s.Require().Len(apps, 1)
+		s.Equal("Example", apps[0].Name)
+		s.Equal([]app.Source{
+			{Name: "appstore", ExternalID: "12345"},
+			{Name: "googleplay", ExternalID: "com.example.app"},
+		}, apps[0].Sources)
+	})
+
 	s.Run("should return error when app is nil", func() {
-		err := s.repo.Save(nil)
+		err := s.repo.Save(context.Background(), nil)
 
 		s.Error(err)
 		s.Contains(err.Error(), "app cannot be nil")
 	})
 
+	s.Run("should reject app IDs that leave the data directory", func() {
+		s.ErrorIs(s.repo.Save(context.Background(), &app.App{ID: "../b/123"}), app.ErrInvalidID)
+		s.ErrorIs(s.repo.Delete(context.Background(), ".."), app.ErrInvalidID)
+	})
+
 	s.Run("should update existing app when saving duplicate ID", func() {
 		testApp1, _ := app.NewApp("12345")
 		testApp2, _ := app.NewApp("12345")
 
-		err := s.repo.Save(testApp1)
+		err := s.repo.Save(context.Background(), testApp1)
 		s.NoError(err)
 
-		err = s.repo.Save(testApp2)
+		err = s.repo.Save(context.Background(), testApp2)
 		s.NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 		s.NoError(err)
 		s.Len(apps, 1)
 		s.Equal("12345", apps[0].ID)
@@ -154,13 +180,13 @@ func (s *AppFileRepositoryTestSuite) TestSave() {
 		testApp1, _ := app.NewApp("12345")
 		testApp2, _ := app.NewApp("67890")
 
-		err := s.repo.Save(testApp1)
+		err := s.repo.Save(context.Background(), testApp1)
 		s.NoError(err)
 
-		err = s.repo.Save(testApp2)
+		err = s.repo.Save(context.Background(), testApp2)
 		s.NoError(err)
 
-		apps, err := s.repo.FindAll()
+		apps, err := s.repo.FindAll(context.Background())
 		s.NoError(err)
 		s.Len(apps, 2)
 
@@ -178,13 +204,45 @@ func (s *AppFileRepositoryTestSuite) TestSave() {
 		s.Require().NoError(err)
 
 		testApp, _ := app.NewApp("12345")
-		err = s.repo.Save(testApp)
+		err = s.repo.Save(context.Background(), testApp)
 
 		s.Error(err)
 		s.Contains(err.Error(), "failed to unmarshal existing apps")
 	})
 }
 
+func (s *AppFileRepositoryTestSuite) TestDelete() {
+	s.Run("should delete existing app and keep the others", func() {
+		testApp1, _ := app.NewApp("12345")
+		testApp2, _ := app.NewApp("67890")
+		s.Require().NoError(s.repo.Save(context.Background(), testApp1))
+		s.Require().NoError(s.repo.Save(context.Background(), testApp2))
+
+		err := s.repo.Delete(context.Background(), "12345")
+
+		s.NoError(err)
+		apps, err := s.repo.FindAll(context.Background())
+		s.NoError(err)
+		s.Len(apps, 1)
+		s.Equal("67890", apps[0].ID)
+	})
+
+	s.Run("should return not found when app is not tracked", func() {
+		testApp, _ := app.NewApp("12345")
+		s.Require().NoError(s.repo.Save(context.Background(), testApp))
+
+		err := s.repo.Delete(context.Background(), "67890")
+
+		s.ErrorIs(err, app.ErrNotFound)
+	})
+
+	s.Run("should return not found when no apps file exists", func() {
+		err := s.repo.Delete(context.Background(), "12345")
+
+		s.ErrorIs(err, app.ErrNotFound)
+	})
+}
+
 func TestAppFileRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(AppFileRepositoryTestSuite))
 }
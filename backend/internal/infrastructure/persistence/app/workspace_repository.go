@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+)
+
+// WorkspaceRepository stores the apps of each workspace in the workspace's
+// directory, addressing the workspace the context names.
+type WorkspaceRepository struct {
+	partition *workspace.Partition[*FileRepository]
+}
+
+func NewWorkspaceRepository(dataDir string) *WorkspaceRepository {
+	return &WorkspaceRepository{partition: workspace.NewPartition(dataDir, NewFileRepository)}
+}
+
+func (r *WorkspaceRepository) FindAll(ctx context.Context) ([]*app.App, error) {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindAll(ctx)
+}
+
+func (r *WorkspaceRepository) Save(ctx context.Context, app *app.App) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Save(ctx, app)
+}
+
+func (r *WorkspaceRepository) Delete(ctx context.Context, id string) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
@@ -0,0 +1,94 @@
+package atomicfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+	"github.com/stretchr/testify/suite"
+)
+
+type AtomicFileTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *AtomicFileTestSuite) SetupSubTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *AtomicFileTestSuite) fileNames() []string {
+	entries, err := os.ReadDir(s.dir)
+	s.Require().NoError(err)
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names
+}
+
+func (s *AtomicFileTestSuite) TestWriteFile() {
+	s.Run("should create the file", func() {
+		path := filepath.Join(s.dir, "apps.json")
+
+		err := atomicfile.WriteFile(path, []byte(`[{"id":"12345"}]`), 0o644)
+
+		s.Require().NoError(err)
+		data, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		s.Equal(`[{"id":"12345"}]`, string(data))
+		s.Equal([]string{"apps.json"}, s.fileNames())
+
+		info, err := os.Stat(path)
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(0o644), info.Mode().Perm())
+	})
+
+	s.Run("should replace existing content", func() {
+		path := filepath.Join(s.dir, "apps.json")
+		s.Require().NoError(os.WriteFile(path, []byte("old content that is longer"), 0o644))
+
+		err := atomicfile.WriteFile(path, []byte("new"), 0o644)
+
+		s.Require().NoError(err)
+		data, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		s.Equal("new", string(data))
+		s.Equal([]string{"apps.json"}, s.fileNames())
+	})
+
+	s.Run("should leave no partial files when the write fails", func() {
+		path := filepath.Join(s.dir, "reviews_12345.json")
+		s.Require().NoError(os.Mkdir(path, 0o755))
+		s.Require().NoError(os.WriteFile(filepath.Join(path, "keep"), []byte("x"), 0o644))
+
+		err := atomicfile.WriteFile(path, []byte("[]"), 0o644)
+
+		s.Error(err)
+		s.Equal([]string{"reviews_12345.json"}, s.fileNames())
+		info, err := os.Stat(path)
+		s.Require().NoError(err)
+		s.True(info.IsDir())
+	})
+
+	s.Run("should fail when the directory does not exist", func() {
+		err := atomicfile.WriteFile(filepath.Join(s.dir, "missing", "apps.json"), []byte("[]"), 0o644)
+
+		s.Error(err)
+		s.Empty(s.fileNames())
+	})
+}
+
+func (s *AtomicFileTestSuite) TestIsTemporary() {
+	s.Run("should recognise temporary file names", func() {
+		s.True(atomicfile.IsTemporary(".reviews_12345.json.tmp-123456"))
+		s.False(atomicfile.IsTemporary("reviews_12345.json"))
+		s.False(atomicfile.IsTemporary("schema.json"))
+	})
+}
+
+func TestAtomicFileTestSuite(t *testing.T) {
+	suite.Run(t, new(AtomicFileTestSuite))
+}
@@ -0,0 +1,244 @@
+package datadir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	persistenceworkspace "appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+)
+
+const (
+	schemaFileName    = "schema.json"
+	reviewsFileSuffix = "_reviews.json"
+	appsFileName      = "apps.json"
+	templatesFileName = "reply_templates.json"
+)
+
+type Migration struct {
+	Version     int
+	Description string
+	apply       func(dataDir string) error
+}
+
+// migrations must stay ordered by version; CurrentSchemaVersion is the last one.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "sort review files chronologically and drop duplicate review IDs",
+		apply:       normaliseReviewFiles,
+	},
+	{
+		Version:     2,
+		Description: "move apps, reviews and reply templates into the default workspace",
+		apply:       moveIntoDefaultWorkspace,
+	},
+}
+
+var CurrentSchemaVersion = migrations[len(migrations)-1].Version
+
+type schemaData struct {
+	SchemaVersion int `json:"schemaVersion"`
+}
+
+// SchemaVersion reports the version the data directory is at. A directory
+// without a schema file is at version 0 if it already holds data, and is
+// treated as current if it is empty.
+func SchemaVersion(dataDir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, schemaFileName))
+	if err == nil {
+		var schema schemaData
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal schema: %w", err)
+		}
+		return schema.SchemaVersion, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	hasData, err := hasDataFiles(dataDir)
+	if err != nil {
+		return 0, err
+	}
+	if hasData {
+		return 0, nil
+	}
+	return CurrentSchemaVersion, nil
+}
+
+// Pending lists the migrations that have not been applied to dataDir yet.
+func Pending(dataDir string) ([]Migration, error) {
+	version, err := SchemaVersion(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, fmt.Errorf("data directory schema version %d is newer than supported version %d", version, CurrentSchemaVersion)
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies pending migrations in order, recording the schema version
+// after each one so an interrupted run resumes where it stopped.
+func Migrate(dataDir string) ([]Migration, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	pending, err := Pending(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range pending {
+		if err := migration.apply(dataDir); err != nil {
+			return pending[:i], fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		if err := writeSchemaVersion(dataDir, migration.Version); err != nil {
+			return pending[:i], err
+		}
+	}
+
+	if len(pending) == 0 {
+		if _, err := os.Stat(filepath.Join(dataDir, schemaFileName)); errors.Is(err, os.ErrNotExist) {
+			return nil, writeSchemaVersion(dataDir, CurrentSchemaVersion)
+		}
+	}
+
+	return pending, nil
+}
+
+func writeSchemaVersion(dataDir string, version int) error {
+	data, err := json.MarshalIndent(schemaData{SchemaVersion: version}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	if err := atomicfile.WriteFile(filepath.Join(dataDir, schemaFileName), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
+
+func hasDataFiles(dataDir string) (bool, error) {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == persistenceworkspace.DirName || isWorkspaceFile(entry.Name()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isWorkspaceFile reports whether a file holds data that belongs to a
+// workspace rather than to the whole installation.
+func isWorkspaceFile(name string) bool {
+	return name == appsFileName || name == templatesFileName || strings.HasSuffix(name, reviewsFileSuffix)
+}
+
+// workspaceDirs lists the directories of the workspaces that hold data.
+func workspaceDirs(dataDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dataDir, persistenceworkspace.DirName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read workspaces directory: %w", err)
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, filepath.Join(persistenceworkspace.DirName, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+func reviewFileAppIDs(dataDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dataDir, "*"+reviewsFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	appIDs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		appIDs = append(appIDs, strings.TrimSuffix(filepath.Base(match), reviewsFileSuffix))
+	}
+	return appIDs, nil
+}
+
+// normaliseReviewFiles rewrites every review file through the repository,
+// whose Save de-duplicates by ID and keeps the file in chronological order.
+func normaliseReviewFiles(dataDir string) error {
+	repo, err := persistencereview.NewFileRepository(dataDir)
+	if err != nil {
+		return err
+	}
+
+	appIDs, err := reviewFileAppIDs(dataDir)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, appID := range appIDs {
+		reviews, err := repo.FindByAppIDSince(ctx, appID, time.Time{})
+		if err != nil {
+			return fmt.Errorf("app %s: %w", appID, err)
+		}
+		if err := repo.Save(ctx, reviews...); err != nil {
+			return fmt.Errorf("app %s: %w", appID, err)
+		}
+	}
+
+	return nil
+}
+
+// moveIntoDefaultWorkspace moves the data stored before workspaces existed
+// into the directory of the default workspace. Renames within the data
+// directory are atomic, so an interrupted run moves the remaining files when
+// it is resumed.
+func moveIntoDefaultWorkspace(dataDir string) error {
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read data directory: %w", err)
+	}
+
+	workspaceDir := persistenceworkspace.Dir(dataDir, workspace.DefaultID)
+	if err := os.MkdirAll(workspaceDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isWorkspaceFile(entry.Name()) {
+			continue
+		}
+		if err := os.Rename(filepath.Join(dataDir, entry.Name()), filepath.Join(workspaceDir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to move %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package datadir_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/persistence/datadir"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrateTestSuite struct {
+	suite.Suite
+	tempDir string
+}
+
+func (s *MigrateTestSuite) SetupSubTest() {
+	var err error
+	s.tempDir, err = os.MkdirTemp("", "datadir_test")
+	s.Require().NoError(err)
+}
+
+func (s *MigrateTestSuite) TearDownSubTest() {
+	os.RemoveAll(s.tempDir)
+}
+
+func (s *MigrateTestSuite) writeFile(name, content string) {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, name), []byte(content), 0o644))
+}
+
+func (s *MigrateTestSuite) TestMigrate() {
+	s.Run("should stamp an empty data directory with the current version", func() {
+		applied, err := datadir.Migrate(s.tempDir)
+
+		s.NoError(err)
+		s.Empty(applied)
+		version, err := datadir.SchemaVersion(s.tempDir)
+		s.NoError(err)
+		s.Equal(datadir.CurrentSchemaVersion, version)
+		s.FileExists(filepath.Join(s.tempDir, "schema.json"))
+	})
+
+	s.Run("should normalise existing review files and move them into the default workspace", func() {
+		s.writeFile("apps.json", `[{"id": "12345"}]`)
+		s.writeFile("reply_templates.json", `[]`)
+		s.writeFile("12345_reviews.json", `[
+			{"id": "b", "app_id": "12345", "score": 4, "submitted_at": "2025-01-02T00:00:00Z"},
+			{"id": "a", "app_id": "12345", "score": 5, "submitted_at": "2025-01-01T00:00:00Z"},
+			{"id": "b", "app_id": "12345", "score": 3, "submitted_at": "2025-01-02T00:00:00Z"}
+		]`)
+
+		pending, err := datadir.Pending(s.tempDir)
+		s.Require().NoError(err)
+		s.Len(pending, datadir.CurrentSchemaVersion)
+
+		applied, err := datadir.Migrate(s.tempDir)
+
+		s.NoError(err)
+		s.Len(applied, datadir.CurrentSchemaVersion)
+
+		workspaceDir := filepath.Join(s.tempDir, "workspaces", "default")
+		s.FileExists(filepath.Join(workspaceDir, "apps.json"))
+		s.FileExists(filepath.Join(workspaceDir, "reply_templates.json"))
+		s.NoFileExists(filepath.Join(s.tempDir, "apps.json"))
+		data, err := os.ReadFile(filepath.Join(workspaceDir, "12345_reviews.json"))
+		s.Require().NoError(err)
+		var reviews []persistencereview.ReviewData
+		s.Require().NoError(json.Unmarshal(data, &reviews))
+		s.Require().Len(reviews, 2)
+		s.Equal("a", reviews[0].ID)
+		s.Equal("b", reviews[1].ID)
+		s.Equal(3, reviews[1].Score)
+
+		pending, err = datadir.Pending(s.tempDir)
+		s.NoError(err)
+		s.Empty(pending)
+	})
+
+	s.Run("should refuse a data directory from a newer version", func() {
+		s.writeFile("schema.json", `{"schemaVersion": 999}`)
+
+		_, err := datadir.Migrate(s.tempDir)
+
+		s.Error(err)
+		s.Contains(err.Error(), "newer than supported")
+	})
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrateTestSuite))
+}
@@ -0,0 +1,167 @@
+package datadir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	persistenceapp "appstorereviewsviewer/internal/infrastructure/persistence/app"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+	persistencereview "appstorereviewsviewer/internal/infrastructure/persistence/review"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Issue struct {
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Message  string   `json:"message"`
+}
+
+// Verify checks the data directory for problems the repositories would trip
+// over or silently skip. It only returns an error when the directory itself
+// cannot be read.
+func Verify(dataDir string) ([]Issue, error) {
+	var issues []Issue
+	report := func(severity Severity, file, format string, args ...any) {
+		issues = append(issues, Issue{Severity: severity, File: file, Message: fmt.Sprintf(format, args...)})
+	}
+
+	pending, err := Pending(dataDir)
+	if err != nil {
+		report(SeverityError, schemaFileName, "%v", err)
+	} else if len(pending) > 0 {
+		report(SeverityError, schemaFileName, "%d pending migration(s), run `reviewsctl db migrate`", len(pending))
+	}
+
+	// Data that has not been moved into a workspace yet is still checked
+	// where it is.
+	dirs, err := workspaceDirs(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range append([]string{""}, dirs...) {
+		if err := verifyDir(dataDir, dir, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+type reporter func(severity Severity, file, format string, args ...any)
+
+// verifyDir checks the apps and reviews in dir, relative to dataDir, and
+// reports files by their path relative to dataDir.
+func verifyDir(dataDir, dir string, report reporter) error {
+	trackedApps := verifyApps(dataDir, dir, report)
+
+	appIDs, err := reviewFileAppIDs(filepath.Join(dataDir, dir))
+	if err != nil {
+		return err
+	}
+	for _, appID := range appIDs {
+		verifyReviews(dataDir, dir, appID, trackedApps, report)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dataDir, dir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, entry := range entries {
+		if atomicfile.IsTemporary(entry.Name()) {
+			report(SeverityWarning, filepath.Join(dir, entry.Name()), "is left over from an interrupted write and can be deleted")
+		}
+	}
+	return nil
+}
+
+func verifyApps(dataDir, dir string, report reporter) map[string]bool {
+	trackedApps := make(map[string]bool)
+	appsFileName := filepath.Join(dir, appsFileName)
+
+	data, err := os.ReadFile(filepath.Join(dataDir, appsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return trackedApps
+	}
+	if err != nil {
+		report(SeverityError, appsFileName, "cannot be read: %v", err)
+		return trackedApps
+	}
+
+	var appsData []persistenceapp.AppData
+	if err := json.Unmarshal(data, &appsData); err != nil {
+		report(SeverityError, appsFileName, "is not valid JSON: %v", err)
+		return trackedApps
+	}
+
+	for i, appData := range appsData {
+		switch {
+		case appData.ID == "":
+			report(SeverityWarning, appsFileName, "entry %d has no id and is ignored", i+1)
+		case trackedApps[appData.ID]:
+			report(SeverityWarning, appsFileName, "app %s is listed more than once", appData.ID)
+		}
+		trackedApps[appData.ID] = true
+	}
+
+	return trackedApps
+}
+
+func verifyReviews(dataDir, dir, appID string, trackedApps map[string]bool, report reporter) {
+	fileName := filepath.Join(dir, appID+reviewsFileSuffix)
+
+	data, err := os.ReadFile(filepath.Join(dataDir, fileName))
+	if err != nil {
+		report(SeverityError, fileName, "cannot be read: %v", err)
+		return
+	}
+
+	var reviewsData []persistencereview.ReviewData
+	if err := json.Unmarshal(data, &reviewsData); err != nil {
+		report(SeverityError, fileName, "is not valid JSON: %v", err)
+		return
+	}
+
+	if !trackedApps[appID] {
+		report(SeverityWarning, fileName, "holds reviews for app %s which is not tracked", appID)
+	}
+
+	seenIDs := make(map[string]bool, len(reviewsData))
+	outOfOrder := false
+	for i, reviewData := range reviewsData {
+		entry := fmt.Sprintf("review %d", i+1)
+		if reviewData.ID != "" {
+			entry = "review " + reviewData.ID
+		}
+
+		switch {
+		case reviewData.ID == "":
+			report(SeverityError, fileName, "%s has no id", entry)
+		case seenIDs[reviewData.ID]:
+			report(SeverityError, fileName, "%s appears more than once", entry)
+		}
+		seenIDs[reviewData.ID] = true
+
+		if reviewData.AppID != appID {
+			report(SeverityError, fileName, "%s belongs to app %q", entry, reviewData.AppID)
+		}
+		if reviewData.Score < 1 || reviewData.Score > 5 {
+			report(SeverityError, fileName, "%s has score %d outside 1-5", entry, reviewData.Score)
+		}
+		if reviewData.SubmittedAt.IsZero() {
+			report(SeverityError, fileName, "%s has no submission time", entry)
+		}
+		if !outOfOrder && i > 0 && reviewData.SubmittedAt.Before(reviewsData[i-1].SubmittedAt) {
+			outOfOrder = true
+			report(SeverityWarning, fileName, "reviews are not in chronological order from %s on, run `reviewsctl db migrate`", entry)
+		}
+	}
+}
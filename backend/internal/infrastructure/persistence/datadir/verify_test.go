@@ -0,0 +1,108 @@
+package datadir_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"appstorereviewsviewer/internal/infrastructure/persistence/datadir"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifyTestSuite struct {
+	suite.Suite
+	tempDir string
+}
+
+func (s *VerifyTestSuite) SetupSubTest() {
+	var err error
+	s.tempDir, err = os.MkdirTemp("", "datadir_test")
+	s.Require().NoError(err)
+}
+
+func (s *VerifyTestSuite) TearDownSubTest() {
+	os.RemoveAll(s.tempDir)
+}
+
+func (s *VerifyTestSuite) writeFile(name, content string) {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, name), []byte(content), 0o644))
+}
+
+func (s *VerifyTestSuite) TestVerify() {
+	s.Run("should report no issues for a healthy data directory", func() {
+		s.writeFile("apps.json", `[{"id": "12345"}]`)
+		s.writeFile("12345_reviews.json", `[{"id": "a", "app_id": "12345", "score": 5, "submitted_at": "2025-01-01T00:00:00Z"}]`)
+		_, err := datadir.Migrate(s.tempDir)
+		s.Require().NoError(err)
+
+		issues, err := datadir.Verify(s.tempDir)
+
+		s.NoError(err)
+		s.Empty(issues)
+	})
+
+	s.Run("should report problems in workspace directories by their path", func() {
+		s.writeFile("schema.json", `{"schemaVersion": 2}`)
+		s.Require().NoError(os.MkdirAll(filepath.Join(s.tempDir, "workspaces", "team"), 0o755))
+		s.writeFile("workspaces/team/apps.json", `[{"id": "12345"}]`)
+		s.writeFile("workspaces/team/67890_reviews.json", `[{"id": "a", "app_id": "67890", "score": 5, "submitted_at": "2025-01-01T00:00:00Z"}]`)
+
+		issues, err := datadir.Verify(s.tempDir)
+
+		s.NoError(err)
+		s.Equal([]datadir.Issue{
+			{Severity: datadir.SeverityWarning, File: "workspaces/team/67890_reviews.json", Message: "holds reviews for app 67890 which is not tracked"},
+		}, issues)
+	})
+
+	s.Run("should report invalid reviews, orphaned files and pending migrations", func() {
+		s.writeFile("apps.json", `[{"id": "12345"}, {"id": ""}]`)
+		s.writeFile("12345_reviews.json", `[
+			{"id": "a", "app_id": "12345", "score": 9, "submitted_at": "2025-01-02T00:00:00Z"},
+			{"id": "a", "app_id": "67890", "score": 5, "submitted_at": "2025-01-01T00:00:00Z"}
+		]`)
+		s.writeFile("67890_reviews.json", `not json`)
+
+		issues, err := datadir.Verify(s.tempDir)
+
+		s.NoError(err)
+		s.ElementsMatch([]datadir.Issue{
+			{Severity: datadir.SeverityError, File: "schema.json", Message: "2 pending migration(s), run `reviewsctl db migrate`"},
+			{Severity: datadir.SeverityWarning, File: "apps.json", Message: "entry 2 has no id and is ignored"},
+			{Severity: datadir.SeverityError, File: "12345_reviews.json", Message: "review a has score 9 outside 1-5"},
+			{Severity: datadir.SeverityError, File: "12345_reviews.json", Message: "review a appears more than once"},
+			{Severity: datadir.SeverityError, File: "12345_reviews.json", Message: `review a belongs to app "67890"`},
+			{
+				Severity: datadir.SeverityWarning,
+				File:     "12345_reviews.json",
+				Message:  "reviews are not in chronological order from review a on, run `reviewsctl db migrate`",
+			},
+			{
+				Severity: datadir.SeverityError,
+				File:     "67890_reviews.json",
+				Message:  "is not valid JSON: invalid character 'o' in literal null (expecting 'u')",
+			},
+		}, issues)
+	})
+
+	s.Run("should report temporary files left by interrupted writes", func() {
+		s.writeFile("schema.json", `{"schemaVersion": 2}`)
+		s.Require().NoError(os.MkdirAll(filepath.Join(s.tempDir, "workspaces", "default"), 0o755))
+		s.writeFile("workspaces/default/.12345_reviews.json.tmp-4242", `[{"id": "a"`)
+
+		issues, err := datadir.Verify(s.tempDir)
+
+		s.NoError(err)
+		s.Equal([]datadir.Issue{
+			{
+				Severity: datadir.SeverityWarning,
+				File:     "workspaces/default/.12345_reviews.json.tmp-4242",
+				Message:  "is left over from an interrupted write and can be deleted",
+			},
+		}, issues)
+	})
+}
+
+func TestVerifyTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyTestSuite))
+}
@@ -0,0 +1,59 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"appstorereviewsviewer/internal/domain/job"
+)
+
+// MemoryRepository keeps the most recent jobs in memory. Jobs only describe
+// work done by the running server, so they are not kept across restarts.
+type MemoryRepository struct {
+	limit int
+	mu    sync.Mutex
+	jobs  map[string]job.Job
+	// order lists job IDs from oldest to newest, to forget the oldest jobs
+	// once there are more than limit.
+	order []string
+}
+
+// NewMemoryRepository returns a repository keeping at most limit jobs, or
+// every job when limit is not positive.
+func NewMemoryRepository(limit int) *MemoryRepository {
+	return &MemoryRepository{limit: limit, jobs: make(map[string]job.Job)}
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id string) (*job.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", job.ErrNotFound, id)
+	}
+	return &stored, nil
+}
+
+func (r *MemoryRepository) Save(ctx context.Context, j *job.Job) error {
+	if j == nil {
+		return fmt.Errorf("job cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.jobs[j.ID]; !ok {
+		r.order = append(r.order, j.ID)
+	}
+	r.jobs[j.ID] = *j
+
+	if r.limit > 0 {
+		for len(r.order) > r.limit {
+			delete(r.jobs, r.order[0])
+			r.order = r.order[1:]
+		}
+	}
+	return nil
+}
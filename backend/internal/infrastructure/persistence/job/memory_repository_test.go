@@ -0,0 +1,63 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/job"
+	jobRepo "appstorereviewsviewer/internal/infrastructure/persistence/job"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobMemoryRepositoryTestSuite struct {
+	suite.Suite
+	repo *jobRepo.MemoryRepository
+}
+
+func (s *JobMemoryRepositoryTestSuite) SetupSubTest() {
+	s.repo = jobRepo.NewMemoryRepository(2)
+}
+
+func (s *JobMemoryRepositoryTestSuite) TestFindByID() {
+	s.Run("should return the last saved state of a job", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), &job.Job{ID: "j1", Status: job.StatusQueued}))
+		s.Require().NoError(s.repo.Save(context.Background(), &job.Job{ID: "j1", Status: job.StatusSucceeded, NewReviews: 3}))
+
+		found, err := s.repo.FindByID(context.Background(), "j1")
+
+		s.Require().NoError(err)
+		s.Equal(&job.Job{ID: "j1", Status: job.StatusSucceeded, NewReviews: 3}, found)
+	})
+
+	s.Run("should not share the stored job with callers", func() {
+		saved := &job.Job{ID: "j1", Status: job.StatusQueued}
+		s.Require().NoError(s.repo.Save(context.Background(), saved))
+		saved.Status = job.StatusRunning
+
+		found, err := s.repo.FindByID(context.Background(), "j1")
+
+		s.Require().NoError(err)
+		s.Equal(job.StatusQueued, found.Status)
+	})
+
+	s.Run("should return not found for unknown jobs", func() {
+		_, err := s.repo.FindByID(context.Background(), "missing")
+
+		s.ErrorIs(err, job.ErrNotFound)
+	})
+
+	s.Run("should forget the oldest jobs over the limit", func() {
+		for _, id := range []string{"j1", "j2", "j3"} {
+			s.Require().NoError(s.repo.Save(context.Background(), &job.Job{ID: id}))
+		}
+
+		_, err := s.repo.FindByID(context.Background(), "j1")
+		s.ErrorIs(err, job.ErrNotFound)
+		_, err = s.repo.FindByID(context.Background(), "j3")
+		s.NoError(err)
+	})
+}
+
+func TestJobMemoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(JobMemoryRepositoryTestSuite))
+}
@@ -0,0 +1,176 @@
+package replytemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+)
+
+// FileRepository keeps reply templates in reply_templates.json in the data
+// directory.
+type FileRepository struct {
+	dataDir string
+	// mu serialises the read-modify-write cycles of Save and Delete.
+	mu sync.Mutex
+}
+
+type TemplateData struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewFileRepository(dataDir string) (*FileRepository, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	return &FileRepository{
+		dataDir: dataDir,
+	}, nil
+}
+
+// FindAll returns the templates ordered by name.
+func (r *FileRepository) FindAll(ctx context.Context) ([]*replytemplate.Template, error) {
+	templatesData, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*replytemplate.Template, len(templatesData))
+	for i, templateData := range templatesData {
+		templates[i] = templateData.toTemplate()
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name == templates[j].Name {
+			return templates[i].ID < templates[j].ID
+		}
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+func (r *FileRepository) FindByID(ctx context.Context, id string) (*replytemplate.Template, error) {
+	templatesData, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, templateData := range templatesData {
+		if templateData.ID == id {
+			return templateData.toTemplate(), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", replytemplate.ErrNotFound, id)
+}
+
+func (r *FileRepository) Save(ctx context.Context, template *replytemplate.Template) error {
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templatesData, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	templateData := TemplateData{
+		ID:        template.ID,
+		Name:      template.Name,
+		Content:   template.Content,
+		CreatedAt: template.CreatedAt,
+		UpdatedAt: template.UpdatedAt,
+	}
+
+	replaced := false
+	for i := range templatesData {
+		if templatesData[i].ID == template.ID {
+			templatesData[i] = templateData
+			replaced = true
+		}
+	}
+	if !replaced {
+		templatesData = append(templatesData, templateData)
+	}
+
+	return r.write(templatesData)
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templatesData, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]TemplateData, 0, len(templatesData))
+	for _, templateData := range templatesData {
+		if templateData.ID != id {
+			remaining = append(remaining, templateData)
+		}
+	}
+
+	if len(remaining) == len(templatesData) {
+		return fmt.Errorf("%w: %s", replytemplate.ErrNotFound, id)
+	}
+
+	return r.write(remaining)
+}
+
+func (r *FileRepository) read() ([]TemplateData, error) {
+	data, err := os.ReadFile(r.getFilePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var templatesData []TemplateData
+	if err := json.Unmarshal(data, &templatesData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reply templates: %w", err)
+	}
+	return templatesData, nil
+}
+
+func (r *FileRepository) write(templatesData []TemplateData) error {
+	data, err := json.MarshalIndent(templatesData, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal reply templates: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(r.getFilePath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+func (d TemplateData) toTemplate() *replytemplate.Template {
+	return &replytemplate.Template{
+		ID:        d.ID,
+		Name:      d.Name,
+		Content:   d.Content,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+func (r *FileRepository) getFilePath() string {
+	return filepath.Join(r.dataDir, "reply_templates.json")
+}
@@ -0,0 +1,97 @@
+package replytemplate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	templateRepo "appstorereviewsviewer/internal/infrastructure/persistence/replytemplate"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateFileRepositoryTestSuite struct {
+	suite.Suite
+	tempDir string
+	repo    *templateRepo.FileRepository
+}
+
+func (s *TemplateFileRepositoryTestSuite) SetupSubTest() {
+	s.tempDir = s.T().TempDir()
+
+	var err error
+	s.repo, err = templateRepo.NewFileRepository(s.tempDir)
+	s.Require().NoError(err)
+}
+
+func (s *TemplateFileRepositoryTestSuite) template(id, name string) *replytemplate.Template {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &replytemplate.Template{ID: id, Name: name, Content: "Thanks {{author}}!", CreatedAt: now, UpdatedAt: now}
+}
+
+func (s *TemplateFileRepositoryTestSuite) TestFindAll() {
+	s.Run("should return no templates when the file does not exist", func() {
+		templates, err := s.repo.FindAll(context.Background())
+
+		s.NoError(err)
+		s.Empty(templates)
+	})
+
+	s.Run("should return templates ordered by name", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.template("t1", "Thanks")))
+		s.Require().NoError(s.repo.Save(context.Background(), s.template("t2", "Bug report")))
+
+		templates, err := s.repo.FindAll(context.Background())
+
+		s.Require().NoError(err)
+		s.Require().Len(templates, 2)
+		s.Equal("Bug report", templates[0].Name)
+		s.Equal("Thanks", templates[1].Name)
+	})
+
+	s.Run("should return error when the file is corrupted", func() {
+		s.Require().NoError(os.WriteFile(filepath.Join(s.tempDir, "reply_templates.json"), []byte("{"), 0o644))
+
+		_, err := s.repo.FindAll(context.Background())
+
+		s.ErrorContains(err, "failed to unmarshal reply templates")
+	})
+}
+
+func (s *TemplateFileRepositoryTestSuite) TestSave() {
+	s.Run("should replace a template with the same ID", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.template("t1", "Thanks")))
+		updated := s.template("t1", "Thank you")
+		updated.Content = "Thank you {{author}}"
+
+		s.Require().NoError(s.repo.Save(context.Background(), updated))
+
+		found, err := s.repo.FindByID(context.Background(), "t1")
+		s.Require().NoError(err)
+		s.Equal(updated, found)
+	})
+}
+
+func (s *TemplateFileRepositoryTestSuite) TestDelete() {
+	s.Run("should delete a template", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), s.template("t1", "Thanks")))
+
+		s.Require().NoError(s.repo.Delete(context.Background(), "t1"))
+
+		_, err := s.repo.FindByID(context.Background(), "t1")
+		s.ErrorIs(err, replytemplate.ErrNotFound)
+	})
+
+	s.Run("should return not found for unknown templates", func() {
+		err := s.repo.Delete(context.Background(), "missing")
+
+		s.ErrorIs(err, replytemplate.ErrNotFound)
+	})
+}
+
+func TestTemplateFileRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TemplateFileRepositoryTestSuite))
+}
@@ -0,0 +1,50 @@
+package replytemplate
+
+import (
+	"context"
+
+	"appstorereviewsviewer/internal/domain/replytemplate"
+	"appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+)
+
+// WorkspaceRepository stores the reply templates of each workspace in the
+// workspace's directory, addressing the workspace the context names.
+type WorkspaceRepository struct {
+	partition *workspace.Partition[*FileRepository]
+}
+
+func NewWorkspaceRepository(dataDir string) *WorkspaceRepository {
+	return &WorkspaceRepository{partition: workspace.NewPartition(dataDir, NewFileRepository)}
+}
+
+func (r *WorkspaceRepository) FindAll(ctx context.Context) ([]*replytemplate.Template, error) {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindAll(ctx)
+}
+
+func (r *WorkspaceRepository) FindByID(ctx context.Context, id string) (*replytemplate.Template, error) {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindByID(ctx, id)
+}
+
+func (r *WorkspaceRepository) Save(ctx context.Context, template *replytemplate.Template) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Save(ctx, template)
+}
+
+func (r *WorkspaceRepository) Delete(ctx context.Context, id string) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Delete(ctx, id)
+}
@@ -0,0 +1,308 @@
+package review
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+const (
+	defaultAppStoreConnectBaseURL  = "https://api.appstoreconnect.apple.com"
+	defaultAppStoreConnectPageSize = 200
+	defaultAppStoreConnectMaxPages = 10
+
+	ascResponseType = "customerReviewResponses"
+)
+
+// AppStoreConnectSource reads reviews and their developer responses from the
+// App Store Connect customerReviews API, newest first, following the cursor
+// links until it reaches reviews older than requested. It also publishes and
+// deletes developer responses.
+type AppStoreConnectSource struct {
+	client      *http.Client
+	baseURL     string
+	tokens      TokenSource
+	territories []string
+	pageSize    int
+	maxPages    int
+}
+
+type AppStoreConnectOptions struct {
+	// BaseURL defaults to the public App Store Connect API.
+	BaseURL string
+	Timeout time.Duration
+	// Tokens authorizes the requests; nil sends them unauthenticated.
+	Tokens TokenSource
+	// Territories limits reviews to these App Store territories, given as
+	// ISO 3166-1 alpha-3 codes such as USA. Empty reads every territory.
+	Territories []string
+	// PageSize is the number of reviews asked for per page, at most 200.
+	PageSize int
+	// MaxPages bounds the pages read per fetch, defaulting to 10.
+	MaxPages int
+}
+
+type ascReviewsResponse struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Rating           int       `json:"rating"`
+			Title            string    `json:"title"`
+			Body             string    `json:"body"`
+			ReviewerNickname string    `json:"reviewerNickname"`
+			CreatedDate      time.Time `json:"createdDate"`
+		} `json:"attributes"`
+		Relationships struct {
+			Response struct {
+				Data *struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			} `json:"response"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Included []ascResponse `json:"included"`
+	Links    struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// ascResponse is a customerReviewResponses resource, the developer's reply.
+type ascResponse struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Attributes struct {
+		ResponseBody     string    `json:"responseBody"`
+		LastModifiedDate time.Time `json:"lastModifiedDate"`
+		State            string    `json:"state"`
+	} `json:"attributes"`
+}
+
+func (r ascResponse) toReply() *review.Reply {
+	state := review.ReplyPublished
+	if r.Attributes.State == "PENDING_PUBLISH" {
+		state = review.ReplyPending
+	}
+	return &review.Reply{
+		ID:        r.ID,
+		Content:   r.Attributes.ResponseBody,
+		State:     state,
+		UpdatedAt: r.Attributes.LastModifiedDate,
+	}
+}
+
+func NewAppStoreConnectSource(options AppStoreConnectOptions) *AppStoreConnectSource {
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = defaultAppStoreConnectBaseURL
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 || pageSize > defaultAppStoreConnectPageSize {
+		pageSize = defaultAppStoreConnectPageSize
+	}
+	maxPages := options.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultAppStoreConnectMaxPages
+	}
+
+	return &AppStoreConnectSource{
+		client: &http.Client{
+			Timeout: options.Timeout,
+		},
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		tokens:      options.Tokens,
+		territories: options.Territories,
+		pageSize:    pageSize,
+		maxPages:    maxPages,
+	}
+}
+
+// FindByAppIDSince returns the reviews of the app created at or after since,
+// with the developer's response when there is one.
+func (s *AppStoreConnectSource) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	query := url.Values{
+		"sort":    {"-createdDate"},
+		"limit":   {strconv.Itoa(s.pageSize)},
+		"include": {"response"},
+	}
+	if len(s.territories) > 0 {
+		query.Set("filter[territory]", strings.Join(s.territories, ","))
+	}
+	next := fmt.Sprintf("%s/v1/apps/%s/customerReviews?%s", s.baseURL, url.PathEscape(appID), query.Encode())
+
+	retrievedAt := time.Now()
+	var reviews []*review.Review
+
+	for range s.maxPages {
+		page, err := s.fetchPage(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		replies := make(map[string]*review.Reply, len(page.Included))
+		for _, included := range page.Included {
+			if included.Type == ascResponseType {
+				replies[included.ID] = included.toReply()
+			}
+		}
+
+		reachedSince := false
+		for _, item := range page.Data {
+			attributes := item.Attributes
+			if item.ID == "" || attributes.Rating < 1 || attributes.Rating > 5 || attributes.CreatedDate.IsZero() {
+				continue
+			}
+			if attributes.CreatedDate.Before(since) {
+				reachedSince = true
+				continue
+			}
+
+			r := &review.Review{
+				ID:          item.ID,
+				AppID:       appID,
+				Author:      attributes.ReviewerNickname,
+				Content:     strings.TrimSpace(attributes.Title + "\n" + attributes.Body),
+				Score:       attributes.Rating,
+				SubmittedAt: attributes.CreatedDate,
+				RetrievedAt: retrievedAt,
+				Source:      review.SourceAppStoreConnect,
+			}
+			if response := item.Relationships.Response.Data; response != nil {
+				r.Reply = replies[response.ID]
+			}
+			reviews = append(reviews, r)
+		}
+
+		next = page.Links.Next
+		if next == "" || reachedSince {
+			break
+		}
+		// The token must not be sent anywhere but the API.
+		if !strings.HasPrefix(next, s.baseURL+"/") {
+			return nil, fmt.Errorf("unexpected next page link %q", next)
+		}
+	}
+
+	return reviews, nil
+}
+
+// fetchPage reads one page of reviews. Later pages are addressed by the
+// absolute next link of the previous one, which carries the cursor.
+func (s *AppStoreConnectSource) fetchPage(ctx context.Context, endpoint string) (*ascReviewsResponse, error) {
+	var page ascReviewsResponse
+	if err := s.do(ctx, http.MethodGet, endpoint, nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// PublishReply creates the developer response to a review. App Store Connect
+// replaces an existing response with the new one.
+func (s *AppStoreConnectSource) PublishReply(ctx context.Context, reviewID, content string) (*review.Reply, error) {
+	var request struct {
+		Data struct {
+			Type       string `json:"type"`
+			Attributes struct {
+				ResponseBody string `json:"responseBody"`
+			} `json:"attributes"`
+			Relationships struct {
+				Review struct {
+					Data struct {
+						Type string `json:"type"`
+						ID   string `json:"id"`
+					} `json:"data"`
+				} `json:"review"`
+			} `json:"relationships"`
+		} `json:"data"`
+	}
+	request.Data.Type = ascResponseType
+	request.Data.Attributes.ResponseBody = content
+	request.Data.Relationships.Review.Data.Type = "customerReviews"
+	request.Data.Relationships.Review.Data.ID = reviewID
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reply: %w", err)
+	}
+
+	var response struct {
+		Data ascResponse `json:"data"`
+	}
+	if err := s.do(ctx, http.MethodPost, s.baseURL+"/v1/customerReviewResponses", body, &response); err != nil {
+		return nil, replyError(err)
+	}
+	return response.Data.toReply(), nil
+}
+
+// DeleteReply removes a developer response.
+func (s *AppStoreConnectSource) DeleteReply(ctx context.Context, replyID string) error {
+	return replyError(s.do(ctx, http.MethodDelete, s.baseURL+"/v1/customerReviewResponses/"+url.PathEscape(replyID), nil, nil))
+}
+
+// replyError classifies a rejected reply so that callers can tell requests
+// the API refused from an API that is unavailable: a missing review or
+// response, or one changed meanwhile, is a conflict and any other client
+// error but 429 is invalid. Other failures are left unclassified.
+func replyError(err error) error {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Temporary() {
+		return err
+	}
+	switch statusErr.StatusCode {
+	case http.StatusNotFound, http.StatusConflict:
+		return fault.Wrap(fault.KindConflict, err)
+	default:
+		return fault.Wrap(fault.KindValidation, err)
+	}
+}
+
+// do sends an authorized request and decodes a successful JSON response into
+// out unless it is nil.
+func (s *AppStoreConnectSource) do(ctx context.Context, method, endpoint string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.tokens != nil {
+		token, err := s.tokens.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to authorize request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call App Store Connect API: %w", err)
+	}
+	defer resp.Body.Close()
+	observeStatus(ctx, resp.StatusCode)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return &StatusError{Service: "App Store Connect API", StatusCode: resp.StatusCode}
+	}
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package review_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/fault"
+	"appstorereviewsviewer/internal/domain/review"
+	reviewRepo "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	"github.com/stretchr/testify/suite"
+)
+
+const ascReviewsPath = "/v1/apps/12345/customerReviews"
+
+// ascFirstPage links to the second page through %s, the stub server's URL.
+const ascFirstPage = `{
+	"data": [{
+		"type": "customerReviews",
+		"id": "asc1",
+		"attributes": {"rating": 5, "title": "Love it", "body": "Works great", "reviewerNickname": "Jane", "createdDate": "2025-01-08T10:00:00Z", "territory": "USA"},
+		"relationships": {"response": {"data": {"type": "customerReviewResponses", "id": "resp1"}}}
+	}, {
+		"type": "customerReviews",
+		"id": "asc2",
+		"attributes": {"rating": 1, "title": "", "body": "Crashes", "reviewerNickname": "Joe", "createdDate": "2025-01-07T10:00:00Z", "territory": "USA"},
+		"relationships": {"response": {"data": null}}
+	}],
+	"included": [{
+		"type": "customerReviewResponses",
+		"id": "resp1",
+		"attributes": {"responseBody": "Thanks Jane!", "lastModifiedDate": "2025-01-08T11:00:00Z", "state": "PUBLISHED"}
+	}],
+	"links": {"self": "%[1]s/v1/apps/12345/customerReviews", "next": "%[1]s/v1/apps/12345/customerReviews?cursor=Mg"}
+}`
+
+const ascSecondPage = `{
+	"data": [{
+		"type": "customerReviews",
+		"id": "asc3",
+		"attributes": {"rating": 3, "title": "Ok", "body": "", "reviewerNickname": "Ann", "createdDate": "2025-01-01T10:00:00Z", "territory": "GBR"}
+	}],
+	"links": {"self": "ignored"}
+}`
+
+type AppStoreConnectSourceTestSuite struct {
+	suite.Suite
+}
+
+// newSource serves the two fixture pages, or status when it is not 200, and
+// counts the pages requested.
+func (s *AppStoreConnectSourceTestSuite) newSource(status int) (*reviewRepo.AppStoreConnectSource, *atomic.Int32) {
+	var requests atomic.Int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		s.Equal(ascReviewsPath, r.URL.Path)
+		s.Equal("Bearer signed", r.Header.Get("Authorization"))
+		w.WriteHeader(status)
+
+		if r.URL.Query().Get("cursor") == "Mg" {
+			w.Write([]byte(ascSecondPage))
+			return
+		}
+		s.Equal("-createdDate", r.URL.Query().Get("sort"))
+		s.Equal("response", r.URL.Query().Get("include"))
+		s.Equal("USA,GBR", r.URL.Query().Get("filter[territory]"))
+		fmt.Fprintf(w, ascFirstPage, server.URL)
+	}))
+	s.T().Cleanup(server.Close)
+
+	return reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{
+		BaseURL:     server.URL,
+		Timeout:     time.Second,
+		Tokens:      staticToken("signed"),
+		Territories: []string{"USA", "GBR"},
+	}), &requests
+}
+
+func (s *AppStoreConnectSourceTestSuite) TestFindByAppIDSince() {
+	s.Run("should follow the cursor and attach developer responses", func() {
+		source, requests := s.newSource(http.StatusOK)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 3)
+		s.Equal(int32(2), requests.Load())
+
+		s.Equal("asc1", reviews[0].ID)
+		s.Equal("12345", reviews[0].AppID)
+		s.Equal("Jane", reviews[0].Author)
+		s.Equal("Love it\nWorks great", reviews[0].Content)
+		s.Equal(5, reviews[0].Score)
+		s.Equal(review.SourceAppStoreConnect, reviews[0].Source)
+		s.Equal(&review.Reply{ID: "resp1", Content: "Thanks Jane!", State: review.ReplyPublished, UpdatedAt: time.Date(2025, 1, 8, 11, 0, 0, 0, time.UTC)}, reviews[0].Reply)
+
+		s.Equal("Crashes", reviews[1].Content)
+		s.Nil(reviews[1].Reply)
+		s.Equal("Ok", reviews[2].Content)
+	})
+
+	s.Run("should stop paging once reviews are older than requested", func() {
+		source, requests := s.newSource(http.StatusOK)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "12345", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC))
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal("asc1", reviews[0].ID)
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should not follow next links to another host", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, ascFirstPage, "https://elsewhere.example.com")
+		}))
+		s.T().Cleanup(server.Close)
+		source := reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{BaseURL: server.URL, Timeout: time.Second})
+
+		_, err := source.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.ErrorContains(err, "unexpected next page link")
+	})
+
+	s.Run("should return error when the API fails", func() {
+		source, _ := s.newSource(http.StatusUnauthorized)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.EqualError(err, "App Store Connect API returned status: 401")
+		s.Nil(reviews)
+	})
+}
+
+func (s *AppStoreConnectSourceTestSuite) TestReplies() {
+	s.Run("should publish a response to the review", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.Equal(http.MethodPost, r.Method)
+			s.Equal("/v1/customerReviewResponses", r.URL.Path)
+			s.Equal("Bearer signed", r.Header.Get("Authorization"))
+
+			var request map[string]any
+			s.Require().NoError(json.NewDecoder(r.Body).Decode(&request))
+			s.Equal(map[string]any{"data": map[string]any{
+				"type":          "customerReviewResponses",
+				"attributes":    map[string]any{"responseBody": "Thanks!"},
+				"relationships": map[string]any{"review": map[string]any{"data": map[string]any{"type": "customerReviews", "id": "asc1"}}},
+			}}, request)
+
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data": {"type": "customerReviewResponses", "id": "resp9", "attributes": {"responseBody": "Thanks!", "lastModifiedDate": "2025-01-09T08:00:00Z", "state": "PENDING_PUBLISH"}}}`))
+		}))
+		s.T().Cleanup(server.Close)
+		source := reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{BaseURL: server.URL, Timeout: time.Second, Tokens: staticToken("signed")})
+
+		reply, err := source.PublishReply(context.Background(), "asc1", "Thanks!")
+
+		s.Require().NoError(err)
+		s.Equal(&review.Reply{
+			ID:        "resp9",
+			Content:   "Thanks!",
+			State:     review.ReplyPending,
+			UpdatedAt: time.Date(2025, 1, 9, 8, 0, 0, 0, time.UTC),
+		}, reply)
+	})
+
+	s.Run("should delete a response", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s.Equal(http.MethodDelete, r.Method)
+			s.Equal("/v1/customerReviewResponses/resp9", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		s.T().Cleanup(server.Close)
+		source := reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{BaseURL: server.URL, Timeout: time.Second})
+
+		s.NoError(source.DeleteReply(context.Background(), "resp9"))
+	})
+
+	s.Run("should return error when the API rejects the response", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		s.T().Cleanup(server.Close)
+		source := reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{BaseURL: server.URL, Timeout: time.Second})
+
+		_, err := source.PublishReply(context.Background(), "asc1", "Thanks!")
+
+		s.EqualError(err, "App Store Connect API returned status: 409")
+		s.Equal(fault.KindConflict, fault.KindOf(err))
+	})
+
+	s.Run("should classify rejected replies by status", func() {
+		status := http.StatusUnprocessableEntity
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		s.T().Cleanup(server.Close)
+		source := reviewRepo.NewAppStoreConnectSource(reviewRepo.AppStoreConnectOptions{BaseURL: server.URL, Timeout: time.Second})
+
+		_, err := source.PublishReply(context.Background(), "asc1", "Thanks!")
+		s.Equal(fault.KindValidation, fault.KindOf(err))
+
+		status = http.StatusNotFound
+		s.Equal(fault.KindConflict, fault.KindOf(source.DeleteReply(context.Background(), "resp9")))
+
+		for _, status = range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+			_, err = source.PublishReply(context.Background(), "asc1", "Thanks!")
+			s.Empty(fault.KindOf(err))
+		}
+	})
+}
+
+func TestAppStoreConnectSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(AppStoreConnectSourceTestSuite))
+}
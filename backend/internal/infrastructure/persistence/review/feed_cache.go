@@ -0,0 +1,143 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+)
+
+const (
+	feedCacheDirName = "feed_cache"
+	// legacyFeedCacheFileName held every entry in one file, which had to be
+	// rewritten whenever any feed changed.
+	legacyFeedCacheFileName = "feed_cache.json"
+)
+
+// FeedCache keeps the validators and parsed reviews of the last feed response
+// per URL so unchanged feeds can be fetched with a conditional request. Each
+// entry is stored in its own file in the data directory and survives
+// restarts; a missing or unreadable file only means the next request for that
+// URL is unconditional.
+type FeedCache struct {
+	dir     string
+	mu      sync.Mutex
+	entries map[string]feedCacheEntry
+}
+
+type feedCacheEntry struct {
+	ETag         string       `json:"etag,omitempty"`
+	LastModified string       `json:"lastModified,omitempty"`
+	Size         int64        `json:"size"`
+	Reviews      []ReviewData `json:"reviews"`
+}
+
+// feedCacheFile is the stored form of an entry, which names its URL as file
+// names are derived from a hash of it.
+type feedCacheFile struct {
+	URL string `json:"url"`
+	feedCacheEntry
+}
+
+func NewFeedCache(dataDir string) (*FeedCache, error) {
+	cache := &FeedCache{
+		dir:     filepath.Join(dataDir, feedCacheDirName),
+		entries: make(map[string]feedCacheEntry),
+	}
+
+	if err := os.MkdirAll(cache.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create feed cache directory: %w", err)
+	}
+
+	legacyPath := filepath.Join(dataDir, legacyFeedCacheFileName)
+	if err := os.Remove(legacyPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Warn("failed to remove legacy feed cache", "path", legacyPath, "error", err)
+	}
+
+	files, err := os.ReadDir(cache.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed cache: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(cache.dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read feed cache: %w", err)
+		}
+
+		var stored feedCacheFile
+		if err := json.Unmarshal(data, &stored); err != nil || cache.path(stored.URL) != path {
+			slog.Warn("ignoring unreadable feed cache entry", "path", path, "error", err)
+			continue
+		}
+		cache.entries[stored.URL] = stored.feedCacheEntry
+	}
+
+	return cache, nil
+}
+
+func (c *FeedCache) get(url string) (feedCacheEntry, bool) {
+	if c == nil {
+		return feedCacheEntry{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	return entry, ok
+}
+
+// put stores the entry for url, writing only that entry's file. Responses
+// without validators cannot be revalidated and drop any previous entry
+// instead.
+func (c *FeedCache) put(url string, entry feedCacheEntry) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.path(url)
+
+	if entry.ETag == "" && entry.LastModified == "" {
+		if _, ok := c.entries[url]; !ok {
+			return
+		}
+		delete(c.entries, url)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("failed to remove feed cache entry", "path", path, "error", err)
+		}
+		return
+	}
+
+	c.entries[url] = entry
+
+	data, err := json.Marshal(feedCacheFile{URL: url, feedCacheEntry: entry})
+	if err == nil {
+		err = atomicfile.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		slog.Warn("failed to write feed cache entry", "path", path, "error", err)
+	}
+}
+
+// path returns the file holding the entry for url.
+func (c *FeedCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -1,27 +1,96 @@
 package review
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
+	"appstorereviewsviewer/internal/domain/app"
 	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
 )
 
 type FileRepository struct {
 	dataDir string
+	// mu serialises the read-modify-write cycles of Save and Merge, which
+	// reloads, replies, imports and backfills run concurrently.
+	mu sync.Mutex
 }
 
 type ReviewData struct {
-	ID          string    `json:"id"`
-	AppID       string    `json:"app_id"`
-	Author      string    `json:"author"`
-	Content     string    `json:"content"`
-	Score       int       `json:"score"`
-	SubmittedAt time.Time `json:"submitted_at"`
-	RetrievedAt time.Time `json:"retrieved_at"`
+	ID          string     `json:"id"`
+	AppID       string     `json:"app_id"`
+	Author      string     `json:"author"`
+	Content     string     `json:"content"`
+	Score       int        `json:"score"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	RetrievedAt time.Time  `json:"retrieved_at"`
+	Source      string     `json:"source,omitempty"`
+	Version     string     `json:"version,omitempty"`
+	Reply       *ReplyData `json:"reply,omitempty"`
+}
+
+type ReplyData struct {
+	ID        string              `json:"id,omitempty"`
+	Content   string              `json:"content"`
+	State     string              `json:"state,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	History   []ReplyRevisionData `json:"history,omitempty"`
+}
+
+type ReplyRevisionData struct {
+	Content   string    `json:"content"`
+	State     string    `json:"state"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newReplyData(reply *review.Reply) *ReplyData {
+	if reply == nil {
+		return nil
+	}
+	data := &ReplyData{
+		ID:        reply.ID,
+		Content:   reply.Content,
+		State:     string(reply.State),
+		UpdatedAt: reply.UpdatedAt,
+	}
+	for _, revision := range reply.History {
+		data.History = append(data.History, ReplyRevisionData{
+			Content:   revision.Content,
+			State:     string(revision.State),
+			UpdatedAt: revision.UpdatedAt,
+		})
+	}
+	return data
+}
+
+func (d *ReplyData) toReply() *review.Reply {
+	if d == nil {
+		return nil
+	}
+	reply := &review.Reply{
+		ID:        d.ID,
+		Content:   d.Content,
+		State:     review.ReplyState(d.State),
+		UpdatedAt: d.UpdatedAt,
+	}
+	// Replies stored before reply states existed were read from the store.
+	if reply.State == "" {
+		reply.State = review.ReplyPublished
+	}
+	for _, revision := range d.History {
+		reply.History = append(reply.History, review.ReplyRevision{
+			Content:   revision.Content,
+			State:     review.ReplyState(revision.State),
+			UpdatedAt: revision.UpdatedAt,
+		})
+	}
+	return reply
 }
 
 func NewFileRepository(dataDir string) (*FileRepository, error) {
@@ -34,8 +103,11 @@ func NewFileRepository(dataDir string) (*FileRepository, error) {
 	}, nil
 }
 
-func (r *FileRepository) FindByAppIDSince(appID string, since time.Time) ([]*review.Review, error) {
-	filePath := r.getFilePath(appID)
+func (r *FileRepository) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	filePath, err := r.getFilePath(appID)
+	if err != nil {
+		return nil, err
+	}
 
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -53,29 +125,111 @@ func (r *FileRepository) FindByAppIDSince(appID string, since time.Time) ([]*rev
 	var filteredReviews []*review.Review
 	for _, reviewData := range reviewsData {
 		if reviewData.SubmittedAt.After(since) || reviewData.SubmittedAt.Equal(since) {
-			review := &review.Review{
-				ID:          reviewData.ID,
-				AppID:       reviewData.AppID,
-				Author:      reviewData.Author,
-				Content:     reviewData.Content,
-				Score:       reviewData.Score,
-				SubmittedAt: reviewData.SubmittedAt,
-				RetrievedAt: reviewData.RetrievedAt,
-			}
-			filteredReviews = append(filteredReviews, review)
+			filteredReviews = append(filteredReviews, reviewData.toReview())
 		}
 	}
 
 	return filteredReviews, nil
 }
 
-func (r *FileRepository) Save(reviews ...*review.Review) error {
+func (r *FileRepository) StreamByAppIDBetween(ctx context.Context, appID string, since, until time.Time, fn func(*review.Review) error) error {
+	filePath, err := r.getFilePath(appID)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to unmarshal reviews: %w", err)
+	}
+
+	for decoder.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var reviewData ReviewData
+		if err := decoder.Decode(&reviewData); err != nil {
+			return fmt.Errorf("failed to unmarshal reviews: %w", err)
+		}
+
+		if reviewData.SubmittedAt.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !reviewData.SubmittedAt.Before(until) {
+			continue
+		}
+
+		if err := fn(reviewData.toReview()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *FileRepository) Save(ctx context.Context, reviews ...*review.Review) error {
 	if len(reviews) == 0 {
 		return nil
 	}
 
-	appID := reviews[0].AppID
-	filePath := r.getFilePath(appID)
+	return r.update(reviews[0].AppID, func(reviewMap map[string]ReviewData) (bool, error) {
+		for _, review := range reviews {
+			reviewMap[review.ID] = newReviewData(review)
+		}
+		return true, nil
+	})
+}
+
+func (r *FileRepository) Merge(ctx context.Context, reviews ...*review.Review) (newReviews, updatedReviews int, err error) {
+	if len(reviews) == 0 {
+		return 0, 0, nil
+	}
+
+	err = r.update(reviews[0].AppID, func(reviewMap map[string]ReviewData) (bool, error) {
+		for _, fetched := range reviews {
+			var stored *review.Review
+			if data, ok := reviewMap[fetched.ID]; ok {
+				stored = data.toReview()
+			}
+			if !fetched.Merge(stored) {
+				continue
+			}
+			if stored == nil {
+				newReviews++
+			} else {
+				updatedReviews++
+			}
+			reviewMap[fetched.ID] = newReviewData(fetched)
+		}
+		return newReviews+updatedReviews > 0, nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return newReviews, updatedReviews, nil
+}
+
+// update lets fn change the stored reviews of appID, keyed by ID, and writes
+// them back when fn reports a change. Updates are serialised so that none
+// overwrites another.
+func (r *FileRepository) update(appID string, fn func(reviewMap map[string]ReviewData) (bool, error)) error {
+	filePath, err := r.getFilePath(appID)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	var existingReviews []ReviewData
 	if data, err := os.ReadFile(filePath); err == nil {
@@ -89,17 +243,9 @@ func (r *FileRepository) Save(reviews ...*review.Review) error {
 		reviewMap[review.ID] = review
 	}
 
-	for _, review := range reviews {
-		reviewData := ReviewData{
-			ID:          review.ID,
-			AppID:       review.AppID,
-			Author:      review.Author,
-			Content:     review.Content,
-			Score:       review.Score,
-			SubmittedAt: review.SubmittedAt,
-			RetrievedAt: review.RetrievedAt,
-		}
-		reviewMap[review.ID] = reviewData
+	changed, err := fn(reviewMap)
+	if err != nil || !changed {
+		return err
 	}
 
 	var allReviews []ReviewData
@@ -107,18 +253,61 @@ func (r *FileRepository) Save(reviews ...*review.Review) error {
 		allReviews = append(allReviews, review)
 	}
 
+	// Keep the file in chronological order so streaming reads are too.
+	sort.Slice(allReviews, func(i, j int) bool {
+		if allReviews[i].SubmittedAt.Equal(allReviews[j].SubmittedAt) {
+			return allReviews[i].ID < allReviews[j].ID
+		}
+		return allReviews[i].SubmittedAt.Before(allReviews[j].SubmittedAt)
+	})
+
 	data, err := json.MarshalIndent(allReviews, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal reviews: %w", err)
 	}
 
-	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+	if err := atomicfile.WriteFile(filePath, data, 0o644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return nil
 }
 
-func (r *FileRepository) getFilePath(appID string) string {
-	return filepath.Join(r.dataDir, fmt.Sprintf("%s_reviews.json", appID))
+func newReviewData(review *review.Review) ReviewData {
+	return ReviewData{
+		ID:          review.ID,
+		AppID:       review.AppID,
+		Author:      review.Author,
+		Content:     review.Content,
+		Score:       review.Score,
+		SubmittedAt: review.SubmittedAt,
+		RetrievedAt: review.RetrievedAt,
+		Source:      review.Source,
+		Version:     review.Version,
+		Reply:       newReplyData(review.Reply),
+	}
+}
+
+func (d ReviewData) toReview() *review.Review {
+	return &review.Review{
+		ID:          d.ID,
+		AppID:       d.AppID,
+		Author:      d.Author,
+		Content:     d.Content,
+		Score:       d.Score,
+		SubmittedAt: d.SubmittedAt,
+		RetrievedAt: d.RetrievedAt,
+		Source:      d.Source,
+		Version:     d.Version,
+		Reply:       d.Reply.toReply(),
+	}
+}
+
+// getFilePath returns the file holding the reviews of appID, rejecting IDs
+// that would resolve outside the data directory.
+func (r *FileRepository) getFilePath(appID string) (string, error) {
+	if err := app.ValidateID(appID); err != nil {
+		return "", err
+	}
+	return filepath.Join(r.dataDir, fmt.Sprintf("%s_reviews.json", appID)), nil
 }
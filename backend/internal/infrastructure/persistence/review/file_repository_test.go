@@ -1,11 +1,15 @@
 package review_test
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"appstorereviewsviewer/internal/domain/app"
 	"appstorereviewsviewer/internal/domain/review"
 	reviewRepo "appstorereviewsviewer/internal/infrastructure/persistence/review"
 	"github.com/stretchr/testify/suite"
@@ -58,7 +62,7 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 	s.Run("should return empty slice when no reviews file exists", func() {
 		since := time.Now().Add(-24 * time.Hour)
 
-		reviews, err := s.repo.FindByAppIDSince("12345", since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", since)
 
 		s.NoError(err)
 		s.Empty(reviews)
@@ -77,11 +81,11 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(testReview)
+		err := s.repo.Save(context.Background(), testReview)
 		s.Require().NoError(err)
 
 		since := now.Add(-24 * time.Hour)
-		reviews, err := s.repo.FindByAppIDSince(appID, since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, since)
 
 		s.NoError(err)
 		s.Len(reviews, 1)
@@ -116,11 +120,11 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(oldReview, newReview)
+		err := s.repo.Save(context.Background(), oldReview, newReview)
 		s.Require().NoError(err)
 
 		since := now.Add(-24 * time.Hour)
-		reviews, err := s.repo.FindByAppIDSince(appID, since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, since)
 
 		s.NoError(err)
 		s.Len(reviews, 1)
@@ -141,11 +145,11 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(exactTimeReview)
+		err := s.repo.Save(context.Background(), exactTimeReview)
 		s.Require().NoError(err)
 
 		since := now.Add(-24 * time.Hour)
-		reviews, err := s.repo.FindByAppIDSince(appID, since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, since)
 
 		s.NoError(err)
 		s.Len(reviews, 1)
@@ -159,7 +163,7 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 		s.Require().NoError(err)
 
 		since := time.Now().Add(-24 * time.Hour)
-		reviews, err := s.repo.FindByAppIDSince(appID, since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, since)
 
 		s.NoError(err)
 		s.Empty(reviews)
@@ -172,7 +176,7 @@ func (s *ReviewFileRepositoryTestSuite) TestFindByAppIDSince() {
 		s.Require().NoError(err)
 
 		since := time.Now().Add(-24 * time.Hour)
-		reviews, err := s.repo.FindByAppIDSince(appID, since)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, since)
 
 		s.Error(err)
 		s.Nil(reviews)
@@ -193,12 +197,12 @@ func (s *ReviewFileRepositoryTestSuite) TestSave() {
 			RetrievedAt: time.Now(),
 		}
 
-		err := s.repo.Save(testReview)
+		err := s.repo.Save(context.Background(), testReview)
 
 		s.NoError(err)
 		s.FileExists(filepath.Join(s.tempDir, appID+"_reviews.json"))
 
-		reviews, err := s.repo.FindByAppIDSince(appID, time.Now().Add(-24*time.Hour))
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, time.Now().Add(-24*time.Hour))
 		s.NoError(err)
 		s.Len(reviews, 1)
 		s.Equal("review1", reviews[0].ID)
@@ -228,16 +232,43 @@ func (s *ReviewFileRepositoryTestSuite) TestSave() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(review1, review2)
+		err := s.repo.Save(context.Background(), review1, review2)
 
 		s.NoError(err)
-		reviews, err := s.repo.FindByAppIDSince(appID, time.Now().Add(-24*time.Hour))
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, time.Now().Add(-24*time.Hour))
 		s.NoError(err)
 		s.Len(reviews, 2)
 	})
 
+	s.Run("should keep every review saved concurrently", func() {
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.NoError(s.repo.Save(context.Background(), &review.Review{ID: fmt.Sprintf("r%d", i), AppID: "12345", SubmittedAt: time.Now()}))
+			}()
+		}
+		wg.Wait()
+
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.NoError(err)
+		s.Len(reviews, 20)
+	})
+
+	s.Run("should reject app IDs that leave the data directory", func() {
+		err := s.repo.Save(context.Background(), &review.Review{ID: "r1", AppID: "../b/123"})
+		s.ErrorIs(err, app.ErrInvalidID)
+
+		_, err = s.repo.FindByAppIDSince(context.Background(), "../b/123", time.Time{})
+		s.ErrorIs(err, app.ErrInvalidID)
+
+		err = s.repo.StreamByAppIDBetween(context.Background(), `..\b`, time.Time{}, time.Time{}, func(*review.Review) error { return nil })
+		s.ErrorIs(err, app.ErrInvalidID)
+	})
+
 	s.Run("should do nothing when no reviews provided", func() {
-		err := s.repo.Save()
+		err := s.repo.Save(context.Background())
 
 		s.NoError(err)
 	})
@@ -266,19 +297,45 @@ func (s *ReviewFileRepositoryTestSuite) TestSave() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(originalReview)
+		err := s.repo.Save(context.Background(), originalReview)
 		s.NoError(err)
 
-		err = s.repo.Save(updatedReview)
+		err = s.repo.Save(context.Background(), updatedReview)
 		s.NoError(err)
 
-		reviews, err := s.repo.FindByAppIDSince(appID, time.Now().Add(-24*time.Hour))
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, time.Now().Add(-24*time.Hour))
 		s.NoError(err)
 		s.Len(reviews, 1)
 		s.Equal("Updated review!", reviews[0].Content)
 		s.Equal(4, reviews[0].Score)
 	})
 
+	s.Run("should keep the developer reply of a review with its history", func() {
+		now := time.Now().UTC().Truncate(time.Second)
+		reply := &review.Reply{
+			ID:        "resp1",
+			Content:   "Thanks!",
+			State:     review.ReplyPublished,
+			UpdatedAt: now,
+			History:   []review.ReplyRevision{{Content: "Thanks", State: review.ReplyPending, UpdatedAt: now.Add(-time.Minute)}},
+		}
+
+		err := s.repo.Save(context.Background(), &review.Review{
+			ID:          "review1",
+			AppID:       "12345",
+			Score:       5,
+			SubmittedAt: now.Add(-time.Hour),
+			RetrievedAt: now,
+			Reply:       reply,
+		})
+		s.NoError(err)
+
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal(reply, reviews[0].Reply)
+	})
+
 	s.Run("should preserve existing reviews when adding new ones", func() {
 		appID := "12345"
 		now := time.Now()
@@ -303,13 +360,13 @@ func (s *ReviewFileRepositoryTestSuite) TestSave() {
 			RetrievedAt: now,
 		}
 
-		err := s.repo.Save(existingReview)
+		err := s.repo.Save(context.Background(), existingReview)
 		s.NoError(err)
 
-		err = s.repo.Save(newReview)
+		err = s.repo.Save(context.Background(), newReview)
 		s.NoError(err)
 
-		reviews, err := s.repo.FindByAppIDSince(appID, time.Now().Add(-48*time.Hour))
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), appID, time.Now().Add(-48*time.Hour))
 		s.NoError(err)
 		s.Len(reviews, 2)
 
@@ -337,13 +394,209 @@ func (s *ReviewFileRepositoryTestSuite) TestSave() {
 			RetrievedAt: time.Now(),
 		}
 
-		err = s.repo.Save(testReview)
+		err = s.repo.Save(context.Background(), testReview)
 
 		s.Error(err)
 		s.Contains(err.Error(), "failed to unmarshal existing reviews")
 	})
 }
 
+func (s *ReviewFileRepositoryTestSuite) TestMerge() {
+	submittedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reply := &review.Reply{ID: "resp1", Content: "Thanks!", State: review.ReplyPublished, UpdatedAt: submittedAt}
+
+	s.Run("should count updated reviews and skip unchanged ones", func() {
+		s.Require().NoError(s.repo.Save(context.Background(),
+			&review.Review{ID: "r1", AppID: "12345", Author: "John", Content: "Good", Score: 4, SubmittedAt: submittedAt},
+			&review.Review{ID: "r2", AppID: "12345", Author: "Jane", Content: "Fine", Score: 3, SubmittedAt: submittedAt},
+		))
+
+		newReviews, updatedReviews, err := s.repo.Merge(context.Background(),
+			&review.Review{ID: "r1", AppID: "12345", Author: "John", Content: "Good", Score: 4, SubmittedAt: submittedAt},
+			&review.Review{ID: "r2", AppID: "12345", Author: "Jane", Content: "Much better now", Score: 5, SubmittedAt: submittedAt},
+			&review.Review{ID: "r3", AppID: "12345", Author: "Ann", Content: "New", Score: 5, SubmittedAt: submittedAt},
+		)
+
+		s.Require().NoError(err)
+		s.Equal(1, newReviews)
+		s.Equal(1, updatedReviews)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 3)
+		s.Equal("Much better now", reviews[1].Content)
+	})
+
+	s.Run("should keep stored replies sources do not report and count changed replies", func() {
+		s.Require().NoError(s.repo.Save(context.Background(),
+			&review.Review{ID: "r1", AppID: "12345", Score: 4, SubmittedAt: submittedAt, Reply: reply},
+			&review.Review{ID: "r2", AppID: "12345", Score: 3, SubmittedAt: submittedAt},
+		))
+
+		newReviews, updatedReviews, err := s.repo.Merge(context.Background(),
+			&review.Review{ID: "r1", AppID: "12345", Score: 4, SubmittedAt: submittedAt},
+			&review.Review{ID: "r2", AppID: "12345", Score: 3, SubmittedAt: submittedAt, Reply: &review.Reply{Content: "Hi", UpdatedAt: submittedAt}},
+		)
+
+		s.Require().NoError(err)
+		s.Zero(newReviews)
+		s.Equal(1, updatedReviews)
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		s.Equal(reply, reviews[0].Reply)
+		s.Equal("Hi", reviews[1].Reply.Content)
+	})
+
+	s.Run("should keep replies saved while a reload merges the same reviews", func() {
+		for i := range 20 {
+			s.Require().NoError(s.repo.Save(context.Background(), &review.Review{ID: fmt.Sprintf("r%d", i), AppID: "12345", SubmittedAt: submittedAt}))
+		}
+
+		var wg sync.WaitGroup
+		for i := range 20 {
+			id := fmt.Sprintf("r%d", i)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				replied := &review.Review{ID: id, AppID: "12345", SubmittedAt: submittedAt, Reply: &review.Reply{Content: "Thanks " + id, UpdatedAt: submittedAt}}
+				s.NoError(s.repo.Save(context.Background(), replied))
+			}()
+			go func() {
+				defer wg.Done()
+				_, _, err := s.repo.Merge(context.Background(), &review.Review{ID: id, AppID: "12345", Content: "Edited", SubmittedAt: submittedAt})
+				s.NoError(err)
+			}()
+		}
+		wg.Wait()
+
+		reviews, err := s.repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 20)
+		for _, r := range reviews {
+			s.Require().NotNil(r.Reply, r.ID)
+			s.Equal("Thanks "+r.ID, r.Reply.Content)
+		}
+	})
+
+	s.Run("should not write when nothing changed", func() {
+		stored := &review.Review{ID: "r1", AppID: "12345", Score: 4, SubmittedAt: submittedAt}
+		s.Require().NoError(s.repo.Save(context.Background(), stored))
+		filePath := filepath.Join(s.tempDir, "12345_reviews.json")
+		old := time.Now().Add(-time.Hour)
+		s.Require().NoError(os.Chtimes(filePath, old, old))
+
+		newReviews, updatedReviews, err := s.repo.Merge(context.Background(), &review.Review{ID: "r1", AppID: "12345", Score: 4, SubmittedAt: submittedAt})
+
+		s.Require().NoError(err)
+		s.Zero(newReviews + updatedReviews)
+		info, err := os.Stat(filePath)
+		s.Require().NoError(err)
+		s.True(info.ModTime().Equal(old))
+	})
+}
+
+func (s *ReviewFileRepositoryTestSuite) TestStreamByAppIDBetween() {
+	s.Run("should do nothing when no reviews file exists", func() {
+		called := false
+
+		err := s.repo.StreamByAppIDBetween(context.Background(), "12345", time.Time{}, time.Time{}, func(*review.Review) error {
+			called = true
+			return nil
+		})
+
+		s.NoError(err)
+		s.False(called)
+	})
+
+	s.Run("should stream reviews in the time window in chronological order", func() {
+		appID := "12345"
+		now := time.Now()
+		err := s.repo.Save(context.Background(),
+			&review.Review{ID: "newest", AppID: appID, SubmittedAt: now.Add(-1 * time.Hour)},
+			&review.Review{ID: "oldest", AppID: appID, SubmittedAt: now.Add(-72 * time.Hour)},
+			&review.Review{ID: "middle", AppID: appID, SubmittedAt: now.Add(-24 * time.Hour)},
+		)
+		s.Require().NoError(err)
+
+		var streamedIDs []string
+		err = s.repo.StreamByAppIDBetween(context.Background(), appID, now.Add(-48*time.Hour), now.Add(-1*time.Hour), func(review *review.Review) error {
+			streamedIDs = append(streamedIDs, review.ID)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal([]string{"middle"}, streamedIDs)
+	})
+
+	s.Run("should stream all reviews when window is unbounded", func() {
+		appID := "12345"
+		now := time.Now()
+		err := s.repo.Save(context.Background(),
+			&review.Review{ID: "second", AppID: appID, SubmittedAt: now.Add(-1 * time.Hour)},
+			&review.Review{ID: "first", AppID: appID, SubmittedAt: now.Add(-2 * time.Hour)},
+		)
+		s.Require().NoError(err)
+
+		var streamedIDs []string
+		err = s.repo.StreamByAppIDBetween(context.Background(), appID, time.Time{}, time.Time{}, func(review *review.Review) error {
+			streamedIDs = append(streamedIDs, review.ID)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal([]string{"first", "second"}, streamedIDs)
+	})
+
+	s.Run("should stop and return the callback error", func() {
+		appID := "12345"
+		err := s.repo.Save(context.Background(),
+			&review.Review{ID: "review1", AppID: appID, SubmittedAt: time.Now().Add(-2 * time.Hour)},
+			&review.Review{ID: "review2", AppID: appID, SubmittedAt: time.Now().Add(-1 * time.Hour)},
+		)
+		s.Require().NoError(err)
+
+		calls := 0
+		err = s.repo.StreamByAppIDBetween(context.Background(), appID, time.Time{}, time.Time{}, func(*review.Review) error {
+			calls++
+			return os.ErrClosed
+		})
+
+		s.ErrorIs(err, os.ErrClosed)
+		s.Equal(1, calls)
+	})
+
+	s.Run("should stop when the context is cancelled", func() {
+		appID := "12345"
+		err := s.repo.Save(context.Background(),
+			&review.Review{ID: "review1", AppID: appID, SubmittedAt: time.Now().Add(-2 * time.Hour)},
+			&review.Review{ID: "review2", AppID: appID, SubmittedAt: time.Now().Add(-1 * time.Hour)},
+		)
+		s.Require().NoError(err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err = s.repo.StreamByAppIDBetween(ctx, appID, time.Time{}, time.Time{}, func(*review.Review) error {
+			calls++
+			cancel()
+			return nil
+		})
+
+		s.ErrorIs(err, context.Canceled)
+		s.Equal(1, calls)
+	})
+
+	s.Run("should return error when file contains invalid JSON", func() {
+		appID := "12345"
+		filePath := filepath.Join(s.tempDir, appID+"_reviews.json")
+		err := os.WriteFile(filePath, []byte("invalid json"), 0o644)
+		s.Require().NoError(err)
+
+		err = s.repo.StreamByAppIDBetween(context.Background(), appID, time.Time{}, time.Time{}, func(*review.Review) error { return nil })
+
+		s.Error(err)
+		s.Contains(err.Error(), "failed to unmarshal reviews")
+	})
+}
+
 func TestReviewFileRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(ReviewFileRepositoryTestSuite))
 }
@@ -0,0 +1,231 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+const (
+	defaultGooglePlayBaseURL  = "https://androidpublisher.googleapis.com"
+	defaultGooglePlayPageSize = 100
+	defaultGooglePlayMaxPages = 10
+)
+
+// TokenSource provides OAuth access tokens for authenticated APIs.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// GooglePlaySource reads reviews from the Google Play Developer API
+// reviews.list endpoint, following page tokens until it reaches reviews older
+// than requested. The API only returns reviews created or modified in the last
+// week, so apps must be fetched at least that often to miss none.
+type GooglePlaySource struct {
+	client   *http.Client
+	baseURL  string
+	tokens   TokenSource
+	pageSize int
+	maxPages int
+}
+
+type GooglePlayOptions struct {
+	// BaseURL defaults to the public Google Play Developer API.
+	BaseURL string
+	Timeout time.Duration
+	// Tokens authorizes the requests; nil sends them unauthenticated.
+	Tokens TokenSource
+	// PageSize is the number of reviews asked for per page, at most 100.
+	PageSize int
+	// MaxPages bounds the pages read per fetch, defaulting to 10.
+	MaxPages int
+}
+
+type playReviewsResponse struct {
+	Reviews         []playReview `json:"reviews"`
+	TokenPagination struct {
+		NextPageToken string `json:"nextPageToken"`
+	} `json:"tokenPagination"`
+}
+
+type playReview struct {
+	ReviewID   string `json:"reviewId"`
+	AuthorName string `json:"authorName"`
+	Comments   []struct {
+		UserComment *struct {
+			Text         string        `json:"text"`
+			LastModified playTimestamp `json:"lastModified"`
+			StarRating   int           `json:"starRating"`
+			AppVersion   string        `json:"appVersionName"`
+		} `json:"userComment"`
+		DeveloperComment *struct {
+			Text         string        `json:"text"`
+			LastModified playTimestamp `json:"lastModified"`
+		} `json:"developerComment"`
+	} `json:"comments"`
+}
+
+// playTimestamp is the API's Timestamp, whose seconds are an int64 encoded as
+// a JSON string.
+type playTimestamp struct {
+	Seconds json.Number `json:"seconds"`
+	Nanos   int64       `json:"nanos"`
+}
+
+func (t playTimestamp) time() (time.Time, error) {
+	seconds, err := strconv.ParseInt(string(t.Seconds), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp seconds %q", t.Seconds)
+	}
+	return time.Unix(seconds, t.Nanos).UTC(), nil
+}
+
+func NewGooglePlaySource(options GooglePlayOptions) *GooglePlaySource {
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGooglePlayBaseURL
+	}
+	pageSize := options.PageSize
+	if pageSize <= 0 || pageSize > defaultGooglePlayPageSize {
+		pageSize = defaultGooglePlayPageSize
+	}
+	maxPages := options.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultGooglePlayMaxPages
+	}
+
+	return &GooglePlaySource{
+		client: &http.Client{
+			Timeout: options.Timeout,
+		},
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		tokens:   options.Tokens,
+		pageSize: pageSize,
+		maxPages: maxPages,
+	}
+}
+
+// FindByAppIDSince returns the reviews of the package appID last modified at
+// or after since, with the developer's reply when there is one.
+func (s *GooglePlaySource) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	retrievedAt := time.Now()
+	var reviews []*review.Review
+	pageToken := ""
+
+	for range s.maxPages {
+		page, err := s.fetchPage(ctx, appID, pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		reachedSince := false
+		for _, item := range page.Reviews {
+			r, err := item.toReview(appID, retrievedAt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse review %s: %w", item.ReviewID, err)
+			}
+			if r == nil {
+				continue
+			}
+			if r.SubmittedAt.Before(since) {
+				reachedSince = true
+				continue
+			}
+			reviews = append(reviews, r)
+		}
+
+		// Reviews come newest first, so later pages are older still.
+		pageToken = page.TokenPagination.NextPageToken
+		if pageToken == "" || reachedSince {
+			break
+		}
+	}
+
+	return reviews, nil
+}
+
+func (s *GooglePlaySource) fetchPage(ctx context.Context, packageName, pageToken string) (*playReviewsResponse, error) {
+	query := url.Values{"maxResults": {strconv.Itoa(s.pageSize)}}
+	if pageToken != "" {
+		query.Set("token", pageToken)
+	}
+	endpoint := fmt.Sprintf("%s/androidpublisher/v3/applications/%s/reviews?%s", s.baseURL, url.PathEscape(packageName), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.tokens != nil {
+		token, err := s.tokens.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authorize request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	defer resp.Body.Close()
+	observeStatus(ctx, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{Service: "Google Play API", StatusCode: resp.StatusCode}
+	}
+
+	var page playReviewsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+	return &page, nil
+}
+
+// toReview maps a Play review to the domain, returning nil for reviews
+// without a user comment or a valid rating.
+func (p playReview) toReview(appID string, retrievedAt time.Time) (*review.Review, error) {
+	r := &review.Review{
+		ID:          p.ReviewID,
+		AppID:       appID,
+		Author:      p.AuthorName,
+		RetrievedAt: retrievedAt,
+		Source:      review.SourceGooglePlay,
+	}
+
+	for _, comment := range p.Comments {
+		switch {
+		case comment.UserComment != nil:
+			submittedAt, err := comment.UserComment.LastModified.time()
+			if err != nil {
+				return nil, err
+			}
+			// Titled reviews come as "title\tbody", untitled ones with a
+			// leading tab.
+			r.Content = strings.ReplaceAll(strings.TrimSpace(comment.UserComment.Text), "\t", "\n")
+			r.Score = comment.UserComment.StarRating
+			r.SubmittedAt = submittedAt
+			r.Version = comment.UserComment.AppVersion
+		case comment.DeveloperComment != nil:
+			updatedAt, err := comment.DeveloperComment.LastModified.time()
+			if err != nil {
+				return nil, err
+			}
+			r.Reply = &review.Reply{Content: comment.DeveloperComment.Text, State: review.ReplyPublished, UpdatedAt: updatedAt}
+		}
+	}
+
+	if r.ID == "" || r.Score < 1 || r.Score > 5 || r.SubmittedAt.IsZero() {
+		return nil, nil
+	}
+	return r, nil
+}
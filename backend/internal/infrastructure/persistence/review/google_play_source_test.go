@@ -0,0 +1,126 @@
+package review_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+	reviewRepo "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	"github.com/stretchr/testify/suite"
+)
+
+const playReviewsPath = "/androidpublisher/v3/applications/com.example.app/reviews"
+
+// playPages are the reviews.list responses of the fixture server keyed by the
+// page token they answer.
+var playPages = map[string]string{
+	"": `{
+		"reviews": [{
+			"reviewId": "gp1",
+			"authorName": "Jane",
+			"comments": [
+				{"userComment": {"text": "Love it\tWorks great", "lastModified": {"seconds": "1736330400", "nanos": 0}, "starRating": 5, "appVersionName": "3.4.0"}},
+				{"developerComment": {"text": "Thanks Jane!", "lastModified": {"seconds": "1736334000"}}}
+			]
+		}, {
+			"reviewId": "gp2",
+			"authorName": "Joe",
+			"comments": [{"userComment": {"text": "\tCrashes on start", "lastModified": {"seconds": "1736244000"}, "starRating": 1}}]
+		}],
+		"tokenPagination": {"nextPageToken": "page2"}
+	}`,
+	"page2": `{
+		"reviews": [{
+			"reviewId": "gp3",
+			"authorName": "Ann",
+			"comments": [{"userComment": {"text": "\tOk", "lastModified": {"seconds": "1735725600"}, "starRating": 3}}]
+		}],
+		"tokenPagination": {"nextPageToken": "page3"}
+	}`,
+	"page3": `{"reviews": []}`,
+}
+
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+type GooglePlaySourceTestSuite struct {
+	suite.Suite
+}
+
+// newSource serves playPages and counts the pages requested.
+func (s *GooglePlaySourceTestSuite) newSource(status int) (*reviewRepo.GooglePlaySource, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		s.Equal(playReviewsPath, r.URL.Path)
+		s.Equal("Bearer secret", r.Header.Get("Authorization"))
+		s.Equal("50", r.URL.Query().Get("maxResults"))
+		w.WriteHeader(status)
+		w.Write([]byte(playPages[r.URL.Query().Get("token")]))
+	}))
+	s.T().Cleanup(server.Close)
+
+	return reviewRepo.NewGooglePlaySource(reviewRepo.GooglePlayOptions{
+		BaseURL:  server.URL,
+		Timeout:  time.Second,
+		Tokens:   staticToken("secret"),
+		PageSize: 50,
+	}), &requests
+}
+
+func (s *GooglePlaySourceTestSuite) TestFindByAppIDSince() {
+	s.Run("should read every page with replies", func() {
+		source, requests := s.newSource(http.StatusOK)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 3)
+		s.Equal(int32(3), requests.Load())
+
+		s.Equal("gp1", reviews[0].ID)
+		s.Equal("com.example.app", reviews[0].AppID)
+		s.Equal("Jane", reviews[0].Author)
+		s.Equal("Love it\nWorks great", reviews[0].Content)
+		s.Equal(5, reviews[0].Score)
+		s.Equal("3.4.0", reviews[0].Version)
+		s.Equal(time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC), reviews[0].SubmittedAt)
+		s.Equal(review.SourceGooglePlay, reviews[0].Source)
+		s.Equal(&review.Reply{Content: "Thanks Jane!", State: review.ReplyPublished, UpdatedAt: time.Date(2025, 1, 8, 11, 0, 0, 0, time.UTC)}, reviews[0].Reply)
+
+		s.Equal("Crashes on start", reviews[1].Content)
+		s.Nil(reviews[1].Reply)
+		s.Equal("gp3", reviews[2].ID)
+	})
+
+	s.Run("should stop paging once reviews are older than requested", func() {
+		source, requests := s.newSource(http.StatusOK)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC))
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal("gp1", reviews[0].ID)
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should return error when the API fails", func() {
+		source, _ := s.newSource(http.StatusForbidden)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.EqualError(err, "Google Play API returned status: 403")
+		s.Nil(reviews)
+	})
+}
+
+func TestGooglePlaySourceTestSuite(t *testing.T) {
+	suite.Run(t, new(GooglePlaySourceTestSuite))
+}
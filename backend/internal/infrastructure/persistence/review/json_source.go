@@ -0,0 +1,111 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+)
+
+// SourceJSON names the generic JSON HTTP review source.
+const SourceJSON = "json"
+
+// JSONSource reads reviews from an HTTP endpoint answering with JSON, for
+// stores and in-house systems without a dedicated source. The response is
+// either an array of reviews or an object with a "reviews" array, using the
+// field names of the JSON Lines export.
+type JSONSource struct {
+	client      *http.Client
+	urlTemplate string
+}
+
+type JSONSourceOptions struct {
+	// URL is the endpoint of an app's reviews with {id} standing for the
+	// app's ID in the source.
+	URL     string
+	Timeout time.Duration
+}
+
+type jsonSourceReview struct {
+	ID          string    `json:"id"`
+	Author      string    `json:"author"`
+	Content     string    `json:"content"`
+	Score       int       `json:"score"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+func NewJSONSource(options JSONSourceOptions) *JSONSource {
+	return &JSONSource{
+		client: &http.Client{
+			Timeout: options.Timeout,
+		},
+		urlTemplate: options.URL,
+	}
+}
+
+func (s *JSONSource) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	endpoint := strings.ReplaceAll(s.urlTemplate, "{id}", url.PathEscape(appID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	defer resp.Body.Close()
+	observeStatus(ctx, resp.StatusCode)
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{Service: "review source", StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var items []jsonSourceReview
+	if err := json.Unmarshal(body, &items); err != nil {
+		var wrapped struct {
+			Reviews []jsonSourceReview `json:"reviews"`
+		}
+		if wrappedErr := json.Unmarshal(body, &wrapped); wrappedErr != nil {
+			return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+		}
+		items = wrapped.Reviews
+	}
+
+	retrievedAt := time.Now()
+	reviews := make([]*review.Review, 0, len(items))
+	for _, item := range items {
+		if item.ID == "" || item.Score < 1 || item.Score > 5 || item.SubmittedAt.IsZero() {
+			continue
+		}
+		if item.SubmittedAt.Before(since) {
+			continue
+		}
+		reviews = append(reviews, &review.Review{
+			ID:          item.ID,
+			AppID:       appID,
+			Author:      item.Author,
+			Content:     item.Content,
+			Score:       item.Score,
+			SubmittedAt: item.SubmittedAt,
+			RetrievedAt: retrievedAt,
+			Source:      SourceJSON,
+		})
+	}
+
+	return reviews, nil
+}
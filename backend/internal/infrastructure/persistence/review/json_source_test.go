@@ -0,0 +1,92 @@
+package review_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	reviewRepo "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSourceTestSuite struct {
+	suite.Suite
+}
+
+func (s *JSONSourceTestSuite) newSource(status int, body string) *reviewRepo.JSONSource {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/apps/com.example.app/reviews", r.URL.Path)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	s.T().Cleanup(server.Close)
+
+	return reviewRepo.NewJSONSource(reviewRepo.JSONSourceOptions{
+		URL:     server.URL + "/apps/{id}/reviews",
+		Timeout: time.Second,
+	})
+}
+
+func (s *JSONSourceTestSuite) TestFindByAppIDSince() {
+	s.Run("should read a top-level array of reviews", func() {
+		source := s.newSource(http.StatusOK, `[
+			{"id": "r1", "author": "John", "content": "Great", "score": 5, "submittedAt": "2025-01-02T10:00:00Z"},
+			{"id": "r2", "author": "Jane", "content": "Old", "score": 3, "submittedAt": "2024-12-01T10:00:00Z"}
+		]`)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal("r1", reviews[0].ID)
+		s.Equal("com.example.app", reviews[0].AppID)
+		s.Equal("John", reviews[0].Author)
+		s.Equal(5, reviews[0].Score)
+		s.Equal(reviewRepo.SourceJSON, reviews[0].Source)
+	})
+
+	s.Run("should read reviews wrapped in an object", func() {
+		source := s.newSource(http.StatusOK, `{"reviews": [{"id": "r1", "score": 4, "submittedAt": "2025-01-02T10:00:00Z"}]}`)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+	})
+
+	s.Run("should skip reviews without an id, a valid score or a date", func() {
+		source := s.newSource(http.StatusOK, `[
+			{"score": 5, "submittedAt": "2025-01-02T10:00:00Z"},
+			{"id": "r2", "score": 9, "submittedAt": "2025-01-02T10:00:00Z"},
+			{"id": "r3", "score": 4}
+		]`)
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.Require().NoError(err)
+		s.Empty(reviews)
+	})
+
+	s.Run("should return error when the source fails", func() {
+		source := s.newSource(http.StatusBadGateway, "")
+
+		reviews, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.EqualError(err, "review source returned status: 502")
+		s.Nil(reviews)
+	})
+
+	s.Run("should return error when the response is not JSON", func() {
+		source := s.newSource(http.StatusOK, "<html></html>")
+
+		_, err := source.FindByAppIDSince(context.Background(), "com.example.app", time.Time{})
+
+		s.ErrorContains(err, "failed to parse JSON response")
+	})
+}
+
+func TestJSONSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONSourceTestSuite))
+}
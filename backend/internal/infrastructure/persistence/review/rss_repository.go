@@ -1,20 +1,58 @@
 package review
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/infrastructure/ratelimit"
 )
 
+const defaultFeedBaseURL = "https://itunes.apple.com"
+
 type RSSRepository struct {
-	client *http.Client
+	client  *http.Client
+	baseURL string
+	limiter *ratelimit.TokenBucket
+	retry   RetryPolicy
+	cache   *FeedCache
+	stats   struct {
+		requests        atomic.Int64
+		notModified     atomic.Int64
+		bytesDownloaded atomic.Int64
+		bytesSaved      atomic.Int64
+	}
+}
+
+type RSSOptions struct {
+	// BaseURL is the scheme and host of the feed, defaulting to the App Store.
+	BaseURL string
+	Timeout time.Duration
+	// Limiter is shared by all requests, retries included, so concurrent
+	// reloads stay within the feed host's rate limits; nil disables limiting.
+	Limiter *ratelimit.TokenBucket
+	Retry   RetryPolicy
+	// Cache enables conditional requests so unchanged feeds are neither
+	// downloaded nor parsed again; nil always fetches the full feed.
+	Cache *FeedCache
+}
+
+// RetryPolicy controls how failed feed requests are retried. Delays grow
+// exponentially from BaseDelay with full jitter and never exceed MaxDelay.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
 }
 
 type AppStoreResponse struct {
@@ -41,43 +79,95 @@ type AppStoreEntry struct {
 	Updated struct {
 		Label string `json:"label"`
 	} `json:"updated"`
+	Version struct {
+		Label string `json:"label"`
+	} `json:"im:version"`
 }
 
-func NewRSSRepository() *RSSRepository {
+// NewRSSRepository returns a repository reading the App Store RSS feed.
+// Network errors, 429 and 5xx responses are retried according to the retry
+// policy.
+func NewRSSRepository(options RSSOptions) *RSSRepository {
+	baseURL := strings.TrimRight(options.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = defaultFeedBaseURL
+	}
+
 	return &RSSRepository{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: options.Timeout,
 		},
+		baseURL: baseURL,
+		limiter: options.Limiter,
+		retry:   options.Retry,
+		cache:   options.Cache,
 	}
 }
 
-func (r *RSSRepository) FindByAppIDSince(appID string, since time.Time) ([]*review.Review, error) {
-	url := fmt.Sprintf("https://itunes.apple.com/us/rss/customerreviews/id=%s/sortBy=mostRecent/page=1/json", appID)
+func (r *RSSRepository) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	url := fmt.Sprintf("%s/us/rss/customerreviews/id=%s/sortBy=mostRecent/page=1/json", r.baseURL, appID)
 
-	resp, err := r.client.Get(url)
+	cached, isCached := r.cache.get(url)
+	resp, err := r.fetch(ctx, url, cached)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch RSS feed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("RSS feed returned status: %d", resp.StatusCode)
+	r.stats.requests.Add(1)
+	if resp.notModified && isCached {
+		r.stats.notModified.Add(1)
+		r.stats.bytesSaved.Add(cached.Size)
+		return reviewsSince(cached.Reviews, since), nil
+	}
+	if resp.notModified {
+		// There is nothing to serve a 304 from, so ask for the full feed.
+		resp, err = r.fetch(ctx, url, feedCacheEntry{})
+		if err != nil {
+			return nil, err
+		}
+		r.stats.requests.Add(1)
+		if resp.notModified {
+			return nil, errors.New("RSS feed answered an unconditional request with 304 Not Modified")
+		}
 	}
+	r.stats.bytesDownloaded.Add(int64(len(resp.body)))
 
-	body, err := io.ReadAll(resp.Body)
+	reviewsData, err := parseFeed(appID, resp.body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
+	}
+
+	r.cache.put(url, feedCacheEntry{
+		ETag:         resp.etag,
+		LastModified: resp.lastModified,
+		Size:         int64(len(resp.body)),
+		Reviews:      reviewsData,
+	})
+
+	return reviewsSince(reviewsData, since), nil
+}
+
+// FetchStats reports how many feed requests were made and how many of them
+// were answered from the feed cache.
+func (r *RSSRepository) FetchStats() review.FetchStats {
+	return review.FetchStats{
+		Requests:        r.stats.requests.Load(),
+		NotModified:     r.stats.notModified.Load(),
+		BytesDownloaded: r.stats.bytesDownloaded.Load(),
+		BytesSaved:      r.stats.bytesSaved.Load(),
 	}
+}
 
+func parseFeed(appID string, body []byte) ([]ReviewData, error) {
 	var appStoreResp AppStoreResponse
 	if err := json.Unmarshal(body, &appStoreResp); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
 	}
 
-	reviews := make([]*review.Review, 0)
+	reviewsData := make([]ReviewData, 0)
 
 	if len(appStoreResp.Feed.Entry) == 0 {
-		return reviews, nil
+		return reviewsData, nil
 	}
 
 	var entries []AppStoreEntry
@@ -98,26 +188,195 @@ func (r *RSSRepository) FindByAppIDSince(appID string, since time.Time) ([]*revi
 
 		reviewID := strings.TrimPrefix(entry.ID.Label, "https://itunes.apple.com/us/reviews/")
 
-		reviewItem := &review.Review{
+		reviewsData = append(reviewsData, ReviewData{
 			ID:          reviewID,
 			AppID:       appID,
 			Author:      entry.Author.Name.Label,
 			Content:     entry.Content.Label,
 			Score:       score,
 			SubmittedAt: updatedTime,
-			RetrievedAt: time.Now(),
-		}
+			Version:     entry.Version.Label,
+		})
+	}
+
+	return reviewsData, nil
+}
 
-		if reviewItem.SubmittedAt.Before(since) {
+// reviewsSince returns the reviews submitted at or after since, stamped with
+// the current time as they were just confirmed by the feed.
+func reviewsSince(reviewsData []ReviewData, since time.Time) []*review.Review {
+	retrievedAt := time.Now()
+
+	reviews := make([]*review.Review, 0, len(reviewsData))
+	for _, reviewData := range reviewsData {
+		if reviewData.SubmittedAt.Before(since) {
 			continue
 		}
-
+		reviewItem := reviewData.toReview()
+		reviewItem.RetrievedAt = retrievedAt
 		reviews = append(reviews, reviewItem)
 	}
 
-	return reviews, nil
+	return reviews
+}
+
+type feedResponse struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+// fetch gets url, retrying temporary failures until the retry policy is
+// exhausted. The validators of cached make the request conditional.
+func (r *RSSRepository) fetch(ctx context.Context, url string, cached feedCacheEntry) (*feedResponse, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := r.fetchOnce(ctx, url, cached)
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= r.retry.MaxRetries || ctx.Err() != nil || !isRetryable(err) {
+			return nil, err
+		}
+
+		delay, ok := r.retryDelay(attempt, err)
+		if !ok {
+			return nil, err
+		}
+
+		slog.Warn("retrying RSS feed request", "url", url, "attempt", attempt+1, "delay", delay, "error", err)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *RSSRepository) fetchOnce(ctx context.Context, url string, cached feedCacheEntry) (*feedResponse, error) {
+	if err := r.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RSS feed: %w", err)
+	}
+	defer resp.Body.Close()
+	observeStatus(ctx, resp.StatusCode)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return &feedResponse{notModified: true}, nil
+	default:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &feedResponse{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// retryDelay returns the jittered backoff for the given attempt, or the
+// server's Retry-After when that is longer. It reports false when the server
+// asks to wait longer than the policy allows.
+func (r *RSSRepository) retryDelay(attempt int, err error) (time.Duration, bool) {
+	ceiling := r.retry.MaxDelay
+	if shifted := r.retry.BaseDelay << attempt; shifted > 0 && shifted < ceiling {
+		ceiling = shifted
+	}
+
+	var delay time.Duration
+	if ceiling > 0 {
+		delay = rand.N(ceiling + 1)
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > delay {
+		if statusErr.RetryAfter > r.retry.MaxDelay {
+			return 0, false
+		}
+		delay = statusErr.RetryAfter
+	}
+
+	return delay, true
+}
+
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Temporary()
+	}
+	return true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date. Missing or malformed values yield zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *RSSRepository) StreamByAppIDBetween(ctx context.Context, appID string, since, until time.Time, fn func(*review.Review) error) error {
+	reviews, err := r.FindByAppIDSince(ctx, appID, since)
+	if err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		if !until.IsZero() && !review.SubmittedAt.Before(until) {
+			continue
+		}
+		if err := fn(review); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (r *RSSRepository) Save(reviews ...*review.Review) error {
+func (r *RSSRepository) Save(ctx context.Context, reviews ...*review.Review) error {
 	return errors.New("this repository is read-only")
 }
@@ -0,0 +1,327 @@
+package review_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	reviewRepo "appstorereviewsviewer/internal/infrastructure/persistence/review"
+	"github.com/stretchr/testify/suite"
+)
+
+const feedPath = "/us/rss/customerreviews/id=12345/sortBy=mostRecent/page=1/json"
+
+const feedBody = `{"feed":{"entry":[{
+	"id":{"label":"https://itunes.apple.com/us/reviews/r1"},
+	"author":{"name":{"label":"John Doe"}},
+	"content":{"label":"Great app!"},
+	"im:rating":{"label":"5"},
+	"im:version":{"label":"2.1.0"},
+	"updated":{"label":"2025-01-01T12:00:00-07:00"}
+}]}}`
+
+type RSSRepositoryTestSuite struct {
+	suite.Suite
+	repo *reviewRepo.RSSRepository
+}
+
+func (s *RSSRepositoryTestSuite) SetupSubTest() {
+	s.repo = reviewRepo.NewRSSRepository(reviewRepo.RSSOptions{Timeout: time.Second})
+}
+
+// newFeedServer serves the given responses in order, repeating the last one,
+// and counts the requests it receives.
+func (s *RSSRepositoryTestSuite) newFeedServer(responses ...func(w http.ResponseWriter)) (*reviewRepo.RSSRepository, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(feedPath, r.URL.Path)
+		n := int(requests.Add(1))
+		responses[min(n, len(responses))-1](w)
+	}))
+	s.T().Cleanup(server.Close)
+
+	repo := reviewRepo.NewRSSRepository(reviewRepo.RSSOptions{
+		BaseURL: server.URL,
+		Timeout: time.Second,
+		Retry: reviewRepo.RetryPolicy{
+			MaxRetries: 2,
+			BaseDelay:  time.Millisecond,
+			MaxDelay:   10 * time.Millisecond,
+		},
+	})
+	return repo, &requests
+}
+
+func respond(status int, header ...string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for i := 0; i+1 < len(header); i += 2 {
+			w.Header().Set(header[i], header[i+1])
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(feedBody))
+		}
+	}
+}
+
+func (s *RSSRepositoryTestSuite) TestFindByAppIDSince() {
+	s.Run("should not fetch when the context is already cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		reviews, err := s.repo.FindByAppIDSince(ctx, "12345", time.Time{})
+
+		s.ErrorIs(err, context.Canceled)
+		s.Nil(reviews)
+	})
+
+	s.Run("should parse the reviews of the feed", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusOK))
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Require().Len(reviews, 1)
+		s.Equal("r1", reviews[0].ID)
+		s.Equal(5, reviews[0].Score)
+		s.Equal("2.1.0", reviews[0].Version)
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should retry server errors and rate limiting", func() {
+		repo, requests := s.newFeedServer(
+			respond(http.StatusServiceUnavailable),
+			respond(http.StatusTooManyRequests, "Retry-After", "0"),
+			respond(http.StatusOK),
+		)
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+		s.Equal(int32(3), requests.Load())
+	})
+
+	s.Run("should give up after the maximum number of retries", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusBadGateway))
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		var statusErr *reviewRepo.StatusError
+		s.Require().ErrorAs(err, &statusErr)
+		s.Equal(http.StatusBadGateway, statusErr.StatusCode)
+		s.Nil(reviews)
+		s.Equal(int32(3), requests.Load())
+	})
+
+	s.Run("should not retry client errors", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusNotFound))
+
+		_, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.EqualError(err, "RSS feed returned status: 404")
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should not retry when Retry-After exceeds the maximum delay", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusTooManyRequests, "Retry-After", "120"))
+
+		_, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		var statusErr *reviewRepo.StatusError
+		s.Require().ErrorAs(err, &statusErr)
+		s.Equal(120*time.Second, statusErr.RetryAfter)
+		s.Equal(int32(1), requests.Load())
+	})
+
+	s.Run("should read Retry-After given as an HTTP date", func() {
+		retryAt := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		repo, _ := s.newFeedServer(respond(http.StatusServiceUnavailable, "Retry-After", retryAt))
+
+		_, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		var statusErr *reviewRepo.StatusError
+		s.Require().ErrorAs(err, &statusErr)
+		s.InDelta(time.Hour, statusErr.RetryAfter, float64(5*time.Second))
+	})
+
+	s.Run("should fetch the full feed when a 304 has nothing cached", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusNotModified), respond(http.StatusOK))
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+		s.Equal(int32(2), requests.Load())
+		s.Equal(int64(2), repo.FetchStats().Requests)
+		s.Equal(int64(0), repo.FetchStats().NotModified)
+	})
+
+	s.Run("should fail when an unconditional request is answered with 304", func() {
+		repo, requests := s.newFeedServer(respond(http.StatusNotModified))
+
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.EqualError(err, "RSS feed answered an unconditional request with 304 Not Modified")
+		s.Nil(reviews)
+		s.Equal(int32(2), requests.Load())
+	})
+}
+
+func (s *RSSRepositoryTestSuite) TestConditionalRequests() {
+	// conditionalServer answers 304 when the request carries the validators
+	// of the feed and counts the full and conditional responses.
+	conditionalServer := func() (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+		var full, notModified atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == "Wed, 01 Jan 2025 12:00:00 GMT" {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			full.Add(1)
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", "Wed, 01 Jan 2025 12:00:00 GMT")
+			w.Write([]byte(feedBody))
+		}))
+		s.T().Cleanup(server.Close)
+		return server, &full, &notModified
+	}
+
+	newRepo := func(baseURL, dataDir string) *reviewRepo.RSSRepository {
+		cache, err := reviewRepo.NewFeedCache(dataDir)
+		s.Require().NoError(err)
+		return reviewRepo.NewRSSRepository(reviewRepo.RSSOptions{BaseURL: baseURL, Timeout: time.Second, Cache: cache})
+	}
+
+	s.Run("should serve unchanged feeds from the cache", func() {
+		server, full, notModified := conditionalServer()
+		repo := newRepo(server.URL, s.T().TempDir())
+
+		first, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		second, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+
+		s.Equal(int32(1), full.Load())
+		s.Equal(int32(1), notModified.Load())
+		s.Require().Len(second, 1)
+		s.Equal(first[0].ID, second[0].ID)
+		s.Equal(first[0].Content, second[0].Content)
+		s.True(first[0].SubmittedAt.Equal(second[0].SubmittedAt))
+
+		stats := repo.FetchStats()
+		s.Equal(int64(2), stats.Requests)
+		s.Equal(int64(1), stats.NotModified)
+		s.Equal(int64(len(feedBody)), stats.BytesDownloaded)
+		s.Equal(int64(len(feedBody)), stats.BytesSaved)
+	})
+
+	s.Run("should filter cached reviews by the requested time", func() {
+		server, _, notModified := conditionalServer()
+		repo := newRepo(server.URL, s.T().TempDir())
+
+		_, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		reviews, err := repo.FindByAppIDSince(context.Background(), "12345", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		s.Require().NoError(err)
+		s.Equal(int32(1), notModified.Load())
+		s.Empty(reviews)
+	})
+
+	s.Run("should keep validators across restarts", func() {
+		server, full, notModified := conditionalServer()
+		dataDir := s.T().TempDir()
+
+		_, err := newRepo(server.URL, dataDir).FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+		reviews, err := newRepo(server.URL, dataDir).FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+		s.Equal(int32(1), full.Load())
+		s.Equal(int32(1), notModified.Load())
+	})
+
+	s.Run("should ignore an unreadable cache file", func() {
+		server, full, _ := conditionalServer()
+		dataDir := s.T().TempDir()
+		s.Require().NoError(os.MkdirAll(filepath.Join(dataDir, "feed_cache"), 0o755))
+		s.Require().NoError(os.WriteFile(filepath.Join(dataDir, "feed_cache", "entry.json"), []byte("{"), 0o644))
+
+		reviews, err := newRepo(server.URL, dataDir).FindByAppIDSince(context.Background(), "12345", time.Time{})
+
+		s.Require().NoError(err)
+		s.Len(reviews, 1)
+		s.Equal(int32(1), full.Load())
+	})
+
+	s.Run("should only write the cache entry of the fetched feed", func() {
+		var version atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, version.Add(1)))
+			w.Write([]byte(feedBody))
+		}))
+		s.T().Cleanup(server.Close)
+		dataDir := s.T().TempDir()
+		repo := newRepo(server.URL, dataDir)
+
+		for _, appID := range []string{"12345", "67890"} {
+			_, err := repo.FindByAppIDSince(context.Background(), appID, time.Time{})
+			s.Require().NoError(err)
+		}
+		entries, err := filepath.Glob(filepath.Join(dataDir, "feed_cache", "*.json"))
+		s.Require().NoError(err)
+		s.Require().Len(entries, 2)
+		past := time.Now().Add(-time.Hour)
+		for _, entry := range entries {
+			s.Require().NoError(os.Chtimes(entry, past, past))
+		}
+
+		_, err = repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+		s.Require().NoError(err)
+
+		written := 0
+		for _, entry := range entries {
+			info, err := os.Stat(entry)
+			s.Require().NoError(err)
+			if info.ModTime().After(past) {
+				written++
+			}
+		}
+		s.Equal(1, written)
+	})
+
+	s.Run("should not send validators without a cache", func() {
+		server, full, notModified := conditionalServer()
+		repo := reviewRepo.NewRSSRepository(reviewRepo.RSSOptions{BaseURL: server.URL, Timeout: time.Second})
+
+		for range 2 {
+			_, err := repo.FindByAppIDSince(context.Background(), "12345", time.Time{})
+			s.Require().NoError(err)
+		}
+
+		s.Equal(int32(2), full.Load())
+		s.Equal(int32(0), notModified.Load())
+		s.Equal(int64(0), repo.FetchStats().NotModified)
+	})
+}
+
+func (s *RSSRepositoryTestSuite) TestSave() {
+	s.Run("should reject writes", func() {
+		err := s.repo.Save(context.Background())
+
+		s.EqualError(err, "this repository is read-only")
+	})
+}
+
+func TestRSSRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RSSRepositoryTestSuite))
+}
@@ -0,0 +1,46 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// StatusError is returned when a source answers with an unexpected status.
+type StatusError struct {
+	// Service names who answered, e.g. "Google Play API"; empty for the
+	// App Store RSS feed.
+	Service    string
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	service := e.Service
+	if service == "" {
+		service = "RSS feed"
+	}
+	return fmt.Sprintf("%s returned status: %d", service, e.StatusCode)
+}
+
+// Temporary reports whether the request may succeed when retried.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+type statusObserverKey struct{}
+
+// WithStatusObserver returns a context under which the sources report the
+// status of every response they get to observe, e.g. 304 when the RSS feed
+// is answered from the feed cache. Fetches reading several pages report
+// each of them.
+func WithStatusObserver(ctx context.Context, observe func(statusCode int)) context.Context {
+	return context.WithValue(ctx, statusObserverKey{}, observe)
+}
+
+func observeStatus(ctx context.Context, statusCode int) {
+	if observe, ok := ctx.Value(statusObserverKey{}).(func(int)); ok {
+		observe(statusCode)
+	}
+}
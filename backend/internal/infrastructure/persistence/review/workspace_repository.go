@@ -0,0 +1,56 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"appstorereviewsviewer/internal/domain/review"
+	"appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+)
+
+// WorkspaceRepository stores the reviews of each workspace in the
+// workspace's directory, addressing the workspace the context names.
+type WorkspaceRepository struct {
+	partition *workspace.Partition[*FileRepository]
+}
+
+func NewWorkspaceRepository(dataDir string) *WorkspaceRepository {
+	return &WorkspaceRepository{partition: workspace.NewPartition(dataDir, NewFileRepository)}
+}
+
+func (r *WorkspaceRepository) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.FindByAppIDSince(ctx, appID, since)
+}
+
+func (r *WorkspaceRepository) StreamByAppIDBetween(
+	ctx context.Context,
+	appID string,
+	since, until time.Time,
+	fn func(*review.Review) error,
+) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.StreamByAppIDBetween(ctx, appID, since, until, fn)
+}
+
+func (r *WorkspaceRepository) Save(ctx context.Context, reviews ...*review.Review) error {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.Save(ctx, reviews...)
+}
+
+func (r *WorkspaceRepository) Merge(ctx context.Context, reviews ...*review.Review) (int, int, error) {
+	repo, err := r.partition.For(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	return repo.Merge(ctx, reviews...)
+}
@@ -0,0 +1,114 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"appstorereviewsviewer/internal/domain/workspace"
+	"appstorereviewsviewer/internal/infrastructure/persistence/atomicfile"
+)
+
+// FileRepository keeps the list of workspaces in workspaces.json in the
+// data directory. Their data lives in Dir.
+type FileRepository struct {
+	dataDir string
+	// mu serialises the read-modify-write cycle of Save.
+	mu sync.Mutex
+}
+
+type WorkspaceData struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewFileRepository(dataDir string) (*FileRepository, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	return &FileRepository{
+		dataDir: dataDir,
+	}, nil
+}
+
+// FindAll returns the workspaces ordered by ID.
+func (r *FileRepository) FindAll(ctx context.Context) ([]*workspace.Workspace, error) {
+	workspacesData, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]*workspace.Workspace, len(workspacesData))
+	for i, workspaceData := range workspacesData {
+		workspaces[i] = &workspace.Workspace{ID: workspaceData.ID, Name: workspaceData.Name}
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].ID < workspaces[j].ID })
+
+	return workspaces, nil
+}
+
+func (r *FileRepository) Save(ctx context.Context, w *workspace.Workspace) error {
+	if w == nil {
+		return fmt.Errorf("workspace cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workspacesData, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range workspacesData {
+		if workspacesData[i].ID == w.ID {
+			workspacesData[i].Name = w.Name
+			replaced = true
+		}
+	}
+	if !replaced {
+		workspacesData = append(workspacesData, WorkspaceData{ID: w.ID, Name: w.Name})
+	}
+
+	data, err := json.MarshalIndent(workspacesData, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspaces: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(r.getFilePath(), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// read returns the stored workspaces, adding the default workspace when it
+// has not been stored yet.
+func (r *FileRepository) read() ([]WorkspaceData, error) {
+	var workspacesData []WorkspaceData
+
+	data, err := os.ReadFile(r.getFilePath())
+	if err == nil {
+		if err := json.Unmarshal(data, &workspacesData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal workspaces: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	for _, workspaceData := range workspacesData {
+		if workspaceData.ID == workspace.DefaultID {
+			return workspacesData, nil
+		}
+	}
+	return append(workspacesData, WorkspaceData{ID: workspace.DefaultID, Name: "Default"}), nil
+}
+
+func (r *FileRepository) getFilePath() string {
+	return filepath.Join(r.dataDir, "workspaces.json")
+}
@@ -0,0 +1,83 @@
+package workspace_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/workspace"
+	persistenceapp "appstorereviewsviewer/internal/infrastructure/persistence/app"
+	workspaceRepo "appstorereviewsviewer/internal/infrastructure/persistence/workspace"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkspaceFileRepositoryTestSuite struct {
+	suite.Suite
+	tempDir string
+	repo    *workspaceRepo.FileRepository
+}
+
+func (s *WorkspaceFileRepositoryTestSuite) SetupSubTest() {
+	s.tempDir = s.T().TempDir()
+
+	var err error
+	s.repo, err = workspaceRepo.NewFileRepository(s.tempDir)
+	s.Require().NoError(err)
+}
+
+func (s *WorkspaceFileRepositoryTestSuite) TestFindAll() {
+	s.Run("should return the default workspace when the file does not exist", func() {
+		workspaces, err := s.repo.FindAll(context.Background())
+
+		s.NoError(err)
+		s.Equal([]*workspace.Workspace{{ID: "default", Name: "Default"}}, workspaces)
+	})
+
+	s.Run("should return saved workspaces ordered by ID", func() {
+		s.Require().NoError(s.repo.Save(context.Background(), &workspace.Workspace{ID: "team-b", Name: "Team B"}))
+		s.Require().NoError(s.repo.Save(context.Background(), &workspace.Workspace{ID: "team-a", Name: "Team A"}))
+		s.Require().NoError(s.repo.Save(context.Background(), &workspace.Workspace{ID: "team-a", Name: "Renamed"}))
+
+		workspaces, err := s.repo.FindAll(context.Background())
+
+		s.Require().NoError(err)
+		s.Equal([]*workspace.Workspace{
+			{ID: "default", Name: "Default"},
+			{ID: "team-a", Name: "Renamed"},
+			{ID: "team-b", Name: "Team B"},
+		}, workspaces)
+		s.FileExists(filepath.Join(s.tempDir, "workspaces.json"))
+	})
+}
+
+func (s *WorkspaceFileRepositoryTestSuite) TestPartition() {
+	s.Run("should keep the data of each workspace apart", func() {
+		apps := persistenceapp.NewWorkspaceRepository(s.tempDir)
+		teamA := workspace.WithID(context.Background(), "team-a")
+		tracked, err := app.NewApp("12345")
+		s.Require().NoError(err)
+
+		s.Require().NoError(apps.Save(teamA, tracked))
+
+		teamAApps, err := apps.FindAll(teamA)
+		s.Require().NoError(err)
+		s.Len(teamAApps, 1)
+		defaultApps, err := apps.FindAll(context.Background())
+		s.Require().NoError(err)
+		s.Empty(defaultApps)
+		s.FileExists(filepath.Join(s.tempDir, "workspaces", "team-a", "apps.json"))
+	})
+
+	s.Run("should reject workspace IDs that are not safe as directory names", func() {
+		apps := persistenceapp.NewWorkspaceRepository(s.tempDir)
+
+		_, err := apps.FindAll(workspace.WithID(context.Background(), "../other"))
+
+		s.ErrorIs(err, workspace.ErrInvalidID)
+	})
+}
+
+func TestWorkspaceFileRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkspaceFileRepositoryTestSuite))
+}
@@ -0,0 +1,58 @@
+package workspace
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"appstorereviewsviewer/internal/domain/workspace"
+)
+
+// DirName is the directory of the data directory holding one directory of
+// data per workspace.
+const DirName = "workspaces"
+
+// Dir returns the directory holding the data of a workspace.
+func Dir(dataDir, workspaceID string) string {
+	return filepath.Join(dataDir, DirName, workspaceID)
+}
+
+// Partition opens one repository per workspace in the workspace's directory
+// and hands out the one for the workspace a context addresses. Repositories
+// are opened once and reused, so the mutexes file repositories serialise
+// their writes with cover every request to a workspace. It is safe for
+// concurrent use.
+type Partition[R any] struct {
+	dataDir string
+	open    func(dir string) (R, error)
+
+	mu    sync.Mutex
+	repos map[string]R
+}
+
+func NewPartition[R any](dataDir string, open func(dir string) (R, error)) *Partition[R] {
+	return &Partition[R]{dataDir: dataDir, open: open, repos: make(map[string]R)}
+}
+
+// For returns the repository of the workspace addressed by ctx.
+func (p *Partition[R]) For(ctx context.Context) (R, error) {
+	id := workspace.IDFromContext(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if repo, ok := p.repos[id]; ok {
+		return repo, nil
+	}
+
+	var zero R
+	if err := workspace.ValidateID(id); err != nil {
+		return zero, err
+	}
+	repo, err := p.open(Dir(p.dataDir, id))
+	if err != nil {
+		return zero, err
+	}
+	p.repos[id] = repo
+	return repo, nil
+}
@@ -0,0 +1,7 @@
+package ratelimit
+
+import "time"
+
+func NewTokenBucketWithClock(rate float64, burst int, now func() time.Time) *TokenBucket {
+	return newTokenBucket(rate, burst, now)
+}
@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket allows bursts of up to burst events and refills at rate events
+// per second. It is safe for concurrent use. A nil *TokenBucket never limits.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return newTokenBucket(rate, burst, time.Now)
+}
+
+func newTokenBucket(rate float64, burst int, now func() time.Time) *TokenBucket {
+	burst = max(burst, 1)
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now(),
+		now:    now,
+	}
+}
+
+// Allow takes a token if one is available without waiting.
+func (b *TokenBucket) Allow() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Wait takes a token, blocking until one is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b == nil {
+		return ctx.Err()
+	}
+
+	b.mu.Lock()
+	b.refill()
+	b.tokens--
+	delay := b.delay()
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reserved token back so cancelled callers do not slow
+		// down the ones still waiting.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// RetryAfter reports how long until a token becomes available.
+func (b *TokenBucket) RetryAfter() time.Duration {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.tokens--
+	delay := b.delay()
+	b.tokens++
+	return delay
+}
+
+func (b *TokenBucket) refill() {
+	now := b.now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	if elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+	}
+}
+
+// delay returns how long the current deficit takes to refill. It must be
+// called with the lock held.
+func (b *TokenBucket) delay() time.Duration {
+	if b.tokens >= 0 {
+		return 0
+	}
+	if b.rate <= 0 {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
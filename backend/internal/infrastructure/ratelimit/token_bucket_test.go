@@ -0,0 +1,92 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/infrastructure/ratelimit"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenBucketTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *TokenBucketTestSuite) SetupSubTest() {
+	s.now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *TokenBucketTestSuite) clock() time.Time {
+	return s.now
+}
+
+func (s *TokenBucketTestSuite) TestAllow() {
+	s.Run("should allow a burst and then refill at the rate", func() {
+		bucket := ratelimit.NewTokenBucketWithClock(2, 3, s.clock)
+
+		s.True(bucket.Allow())
+		s.True(bucket.Allow())
+		s.True(bucket.Allow())
+		s.False(bucket.Allow())
+
+		s.now = s.now.Add(500 * time.Millisecond)
+		s.True(bucket.Allow())
+		s.False(bucket.Allow())
+
+		s.now = s.now.Add(10 * time.Second)
+		s.True(bucket.Allow())
+		s.True(bucket.Allow())
+		s.True(bucket.Allow())
+		s.False(bucket.Allow())
+	})
+
+	s.Run("should never limit a nil bucket", func() {
+		var bucket *ratelimit.TokenBucket
+
+		s.True(bucket.Allow())
+		s.NoError(bucket.Wait(context.Background()))
+		s.Zero(bucket.RetryAfter())
+	})
+}
+
+func (s *TokenBucketTestSuite) TestRetryAfter() {
+	s.Run("should report the time until the next token", func() {
+		bucket := ratelimit.NewTokenBucketWithClock(4, 1, s.clock)
+		s.Zero(bucket.RetryAfter())
+
+		s.True(bucket.Allow())
+
+		s.Equal(250*time.Millisecond, bucket.RetryAfter())
+	})
+}
+
+func (s *TokenBucketTestSuite) TestWait() {
+	s.Run("should wait for a token to refill", func() {
+		bucket := ratelimit.NewTokenBucket(50, 1)
+		s.Require().NoError(bucket.Wait(context.Background()))
+
+		start := time.Now()
+		s.Require().NoError(bucket.Wait(context.Background()))
+
+		s.GreaterOrEqual(time.Since(start), 15*time.Millisecond)
+	})
+
+	s.Run("should give up when the context is done and return the token", func() {
+		bucket := ratelimit.NewTokenBucketWithClock(1, 1, s.clock)
+		s.True(bucket.Allow())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := bucket.Wait(ctx)
+
+		s.ErrorIs(err, context.DeadlineExceeded)
+		s.Equal(time.Second, bucket.RetryAfter())
+	})
+}
+
+func TestTokenBucketTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenBucketTestSuite))
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"appstorereviewsviewer/internal/application/jobs"
+)
+
+// Jobs runs queued background jobs, such as the first fetch of a new app's
+// reviews, on a fixed number of goroutines.
+type Jobs struct {
+	useCase   jobs.UseCase
+	workers   int
+	wg        sync.WaitGroup
+	cancel    context.CancelFunc
+	isRunning bool
+}
+
+// NewJobs returns a runner using the given number of workers; values below
+// one use a single worker.
+func NewJobs(useCase jobs.UseCase, workers int) *Jobs {
+	return &Jobs{useCase: useCase, workers: max(workers, 1)}
+}
+
+func (s *Jobs) Start() {
+	if s.isRunning {
+		return
+	}
+
+	s.isRunning = true
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for range s.workers {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.useCase.Run(ctx)
+		}()
+	}
+
+	slog.Info("Jobs started", "workers", s.workers)
+}
+
+// Stop cancels the jobs in progress and waits for their outcome to be
+// recorded. Jobs still queued are not run.
+func (s *Jobs) Stop(ctx context.Context) error {
+	if !s.isRunning {
+		return nil
+	}
+
+	s.isRunning = false
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("Jobs stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("jobs did not stop in time: %w", ctx.Err())
+	}
+}
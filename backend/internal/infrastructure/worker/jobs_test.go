@@ -0,0 +1,63 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"appstorereviewsviewer/internal/infrastructure/worker"
+	jobsmocks "appstorereviewsviewer/mocks/application/jobs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobsTestSuite struct {
+	suite.Suite
+	mockJobsUseCase *jobsmocks.UseCase
+}
+
+func (s *JobsTestSuite) SetupSubTest() {
+	s.mockJobsUseCase = jobsmocks.NewUseCase(s.T())
+}
+
+func (s *JobsTestSuite) TestStart() {
+	s.Run("should run every worker until stopped", func() {
+		started := make(chan struct{}, 3)
+		s.mockJobsUseCase.EXPECT().Run(mock.Anything).Run(func(ctx context.Context) {
+			started <- struct{}{}
+			<-ctx.Done()
+		}).Times(3)
+
+		jobs := worker.NewJobs(s.mockJobsUseCase, 3)
+		jobs.Start()
+		for range 3 {
+			<-started
+		}
+
+		s.NoError(jobs.Stop(context.Background()))
+	})
+}
+
+func (s *JobsTestSuite) TestStop() {
+	s.Run("should give up waiting when the context is done", func() {
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		s.mockJobsUseCase.EXPECT().Run(mock.Anything).Run(func(ctx context.Context) {
+			close(started)
+			<-release
+		})
+
+		jobs := worker.NewJobs(s.mockJobsUseCase, 1)
+		jobs.Start()
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		s.ErrorIs(jobs.Stop(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestJobsTestSuite(t *testing.T) {
+	suite.Run(t, new(JobsTestSuite))
+}
@@ -5,6 +5,10 @@
 package addappmocks
 
 import (
+	"appstorereviewsviewer/internal/domain/app"
+	"appstorereviewsviewer/internal/domain/job"
+	"context"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,20 +40,31 @@ func (_m *UseCase) EXPECT() *UseCase_Expecter {
 }
 
 // Execute provides a mock function for the type UseCase
-func (_mock *UseCase) Execute(appID string) error {
-	ret := _mock.Called(appID)
+func (_mock *UseCase) Execute(ctx context.Context, appID string, name string, sources []app.Source) (*job.Job, error) {
+	ret := _mock.Called(ctx, appID, name, sources)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Execute")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
-		r0 = returnFunc(appID)
+	var r0 *job.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []app.Source) (*job.Job, error)); ok {
+		return returnFunc(ctx, appID, name, sources)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []app.Source) *job.Job); ok {
+		r0 = returnFunc(ctx, appID, name, sources)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*job.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []app.Source) error); ok {
+		r1 = returnFunc(ctx, appID, name, sources)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
 // UseCase_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
@@ -58,30 +73,48 @@ type UseCase_Execute_Call struct {
 }
 
 // Execute is a helper method to define mock.On call
+//   - ctx context.Context
 //   - appID string
-func (_e *UseCase_Expecter) Execute(appID interface{}) *UseCase_Execute_Call {
-	return &UseCase_Execute_Call{Call: _e.mock.On("Execute", appID)}
+//   - name string
+//   - sources []app.Source
+func (_e *UseCase_Expecter) Execute(ctx interface{}, appID interface{}, name interface{}, sources interface{}) *UseCase_Execute_Call {
+	return &UseCase_Execute_Call{Call: _e.mock.On("Execute", ctx, appID, name, sources)}
 }
 
-func (_c *UseCase_Execute_Call) Run(run func(appID string)) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) Run(run func(ctx context.Context, appID string, name string, sources []app.Source)) *UseCase_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []app.Source
+		if args[3] != nil {
+			arg3 = args[3].([]app.Source)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *UseCase_Execute_Call) Return(err error) *UseCase_Execute_Call {
-	_c.Call.Return(err)
+func (_c *UseCase_Execute_Call) Return(job1 *job.Job, err error) *UseCase_Execute_Call {
+	_c.Call.Return(job1, err)
 	return _c
 }
 
-func (_c *UseCase_Execute_Call) RunAndReturn(run func(appID string) error) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) RunAndReturn(run func(ctx context.Context, appID string, name string, sources []app.Source) (*job.Job, error)) *UseCase_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
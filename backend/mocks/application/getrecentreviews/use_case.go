@@ -6,6 +6,7 @@ package getrecentreviewsmocks
 
 import (
 	"appstorereviewsviewer/internal/domain/review"
+	"context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,8 +39,8 @@ func (_m *UseCase) EXPECT() *UseCase_Expecter {
 }
 
 // Execute provides a mock function for the type UseCase
-func (_mock *UseCase) Execute(appID string) ([]*review.Review, error) {
-	ret := _mock.Called(appID)
+func (_mock *UseCase) Execute(ctx context.Context, appID string) ([]*review.Review, error) {
+	ret := _mock.Called(ctx, appID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Execute")
@@ -47,18 +48,18 @@ func (_mock *UseCase) Execute(appID string) ([]*review.Review, error) {
 
 	var r0 []*review.Review
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) ([]*review.Review, error)); ok {
-		return returnFunc(appID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*review.Review, error)); ok {
+		return returnFunc(ctx, appID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) []*review.Review); ok {
-		r0 = returnFunc(appID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*review.Review); ok {
+		r0 = returnFunc(ctx, appID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*review.Review)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(appID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, appID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,19 +72,25 @@ type UseCase_Execute_Call struct {
 }
 
 // Execute is a helper method to define mock.On call
+//   - ctx context.Context
 //   - appID string
-func (_e *UseCase_Expecter) Execute(appID interface{}) *UseCase_Execute_Call {
-	return &UseCase_Execute_Call{Call: _e.mock.On("Execute", appID)}
+func (_e *UseCase_Expecter) Execute(ctx interface{}, appID interface{}) *UseCase_Execute_Call {
+	return &UseCase_Execute_Call{Call: _e.mock.On("Execute", ctx, appID)}
 }
 
-func (_c *UseCase_Execute_Call) Run(run func(appID string)) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) Run(run func(ctx context.Context, appID string)) *UseCase_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -94,7 +101,7 @@ func (_c *UseCase_Execute_Call) Return(reviews []*review.Review, err error) *Use
 	return _c
 }
 
-func (_c *UseCase_Execute_Call) RunAndReturn(run func(appID string) ([]*review.Review, error)) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) RunAndReturn(run func(ctx context.Context, appID string) ([]*review.Review, error)) *UseCase_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
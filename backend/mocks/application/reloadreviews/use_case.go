@@ -5,6 +5,9 @@
 package reloadreviewsmocks
 
 import (
+	"appstorereviewsviewer/internal/application/reloadreviews"
+	"context"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -36,20 +39,37 @@ func (_m *UseCase) EXPECT() *UseCase_Expecter {
 }
 
 // Execute provides a mock function for the type UseCase
-func (_mock *UseCase) Execute() error {
-	ret := _mock.Called()
+func (_mock *UseCase) Execute(ctx context.Context, appIDs ...string) (*reloadreviews.Summary, error) {
+	var tmpRet mock.Arguments
+	if len(appIDs) > 0 {
+		tmpRet = _mock.Called(ctx, appIDs)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
 
 	if len(ret) == 0 {
 		panic("no return value specified for Execute")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
-		r0 = returnFunc()
+	var r0 *reloadreviews.Summary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) (*reloadreviews.Summary, error)); ok {
+		return returnFunc(ctx, appIDs...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...string) *reloadreviews.Summary); ok {
+		r0 = returnFunc(ctx, appIDs...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reloadreviews.Summary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = returnFunc(ctx, appIDs...)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
-	return r0
+	return r0, r1
 }
 
 // UseCase_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
@@ -58,23 +78,39 @@ type UseCase_Execute_Call struct {
 }
 
 // Execute is a helper method to define mock.On call
-func (_e *UseCase_Expecter) Execute() *UseCase_Execute_Call {
-	return &UseCase_Execute_Call{Call: _e.mock.On("Execute")}
+//   - ctx context.Context
+//   - appIDs ...string
+func (_e *UseCase_Expecter) Execute(ctx interface{}, appIDs ...interface{}) *UseCase_Execute_Call {
+	return &UseCase_Execute_Call{Call: _e.mock.On("Execute",
+		append([]interface{}{ctx}, appIDs...)...)}
 }
 
-func (_c *UseCase_Execute_Call) Run(run func()) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) Run(run func(ctx context.Context, appIDs ...string)) *UseCase_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
 	})
 	return _c
 }
 
-func (_c *UseCase_Execute_Call) Return(err error) *UseCase_Execute_Call {
-	_c.Call.Return(err)
+func (_c *UseCase_Execute_Call) Return(summary *reloadreviews.Summary, err error) *UseCase_Execute_Call {
+	_c.Call.Return(summary, err)
 	return _c
 }
 
-func (_c *UseCase_Execute_Call) RunAndReturn(run func() error) *UseCase_Execute_Call {
+func (_c *UseCase_Execute_Call) RunAndReturn(run func(ctx context.Context, appIDs ...string) (*reloadreviews.Summary, error)) *UseCase_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
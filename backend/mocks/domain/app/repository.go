@@ -6,6 +6,7 @@ package appmocks
 
 import (
 	"appstorereviewsviewer/internal/domain/app"
+	"context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,8 +39,8 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 }
 
 // FindAll provides a mock function for the type Repository
-func (_mock *Repository) FindAll() ([]*app.App, error) {
-	ret := _mock.Called()
+func (_mock *Repository) FindAll(ctx context.Context) ([]*app.App, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindAll")
@@ -47,18 +48,18 @@ func (_mock *Repository) FindAll() ([]*app.App, error) {
 
 	var r0 []*app.App
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]*app.App, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*app.App, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []*app.App); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*app.App); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*app.App)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,13 +72,20 @@ type Repository_FindAll_Call struct {
 }
 
 // FindAll is a helper method to define mock.On call
-func (_e *Repository_Expecter) FindAll() *Repository_FindAll_Call {
-	return &Repository_FindAll_Call{Call: _e.mock.On("FindAll")}
+//   - ctx context.Context
+func (_e *Repository_Expecter) FindAll(ctx interface{}) *Repository_FindAll_Call {
+	return &Repository_FindAll_Call{Call: _e.mock.On("FindAll", ctx)}
 }
 
-func (_c *Repository_FindAll_Call) Run(run func()) *Repository_FindAll_Call {
+func (_c *Repository_FindAll_Call) Run(run func(ctx context.Context)) *Repository_FindAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -87,22 +95,22 @@ func (_c *Repository_FindAll_Call) Return(apps []*app.App, err error) *Repositor
 	return _c
 }
 
-func (_c *Repository_FindAll_Call) RunAndReturn(run func() ([]*app.App, error)) *Repository_FindAll_Call {
+func (_c *Repository_FindAll_Call) RunAndReturn(run func(ctx context.Context) ([]*app.App, error)) *Repository_FindAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Save provides a mock function for the type Repository
-func (_mock *Repository) Save(app1 *app.App) error {
-	ret := _mock.Called(app1)
+func (_mock *Repository) Save(ctx context.Context, app1 *app.App) error {
+	ret := _mock.Called(ctx, app1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*app.App) error); ok {
-		r0 = returnFunc(app1)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *app.App) error); ok {
+		r0 = returnFunc(ctx, app1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -115,19 +123,25 @@ type Repository_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
+//   - ctx context.Context
 //   - app1 *app.App
-func (_e *Repository_Expecter) Save(app1 interface{}) *Repository_Save_Call {
-	return &Repository_Save_Call{Call: _e.mock.On("Save", app1)}
+func (_e *Repository_Expecter) Save(ctx interface{}, app1 interface{}) *Repository_Save_Call {
+	return &Repository_Save_Call{Call: _e.mock.On("Save", ctx, app1)}
 }
 
-func (_c *Repository_Save_Call) Run(run func(app1 *app.App)) *Repository_Save_Call {
+func (_c *Repository_Save_Call) Run(run func(ctx context.Context, app1 *app.App)) *Repository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *app.App
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*app.App)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *app.App
+		if args[1] != nil {
+			arg1 = args[1].(*app.App)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -138,7 +152,64 @@ func (_c *Repository_Save_Call) Return(err error) *Repository_Save_Call {
 	return _c
 }
 
-func (_c *Repository_Save_Call) RunAndReturn(run func(app1 *app.App) error) *Repository_Save_Call {
+func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, app1 *app.App) error) *Repository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type Repository
+func (_mock *Repository) Delete(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type Repository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) Delete(ctx interface{}, id interface{}) *Repository_Delete_Call {
+	return &Repository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *Repository_Delete_Call) Run(run func(ctx context.Context, id string)) *Repository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_Delete_Call) Return(err error) *Repository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6,6 +6,7 @@ package reviewmocks
 
 import (
 	"appstorereviewsviewer/internal/domain/review"
+	"context"
 	"time"
 
 	mock "github.com/stretchr/testify/mock"
@@ -39,8 +40,8 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 }
 
 // FindByAppIDSince provides a mock function for the type Repository
-func (_mock *Repository) FindByAppIDSince(appID string, since time.Time) ([]*review.Review, error) {
-	ret := _mock.Called(appID, since)
+func (_mock *Repository) FindByAppIDSince(ctx context.Context, appID string, since time.Time) ([]*review.Review, error) {
+	ret := _mock.Called(ctx, appID, since)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindByAppIDSince")
@@ -48,18 +49,18 @@ func (_mock *Repository) FindByAppIDSince(appID string, since time.Time) ([]*rev
 
 	var r0 []*review.Review
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string, time.Time) ([]*review.Review, error)); ok {
-		return returnFunc(appID, since)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*review.Review, error)); ok {
+		return returnFunc(ctx, appID, since)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string, time.Time) []*review.Review); ok {
-		r0 = returnFunc(appID, since)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []*review.Review); ok {
+		r0 = returnFunc(ctx, appID, since)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*review.Review)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string, time.Time) error); ok {
-		r1 = returnFunc(appID, since)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, appID, since)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,25 +73,31 @@ type Repository_FindByAppIDSince_Call struct {
 }
 
 // FindByAppIDSince is a helper method to define mock.On call
+//   - ctx context.Context
 //   - appID string
 //   - since time.Time
-func (_e *Repository_Expecter) FindByAppIDSince(appID interface{}, since interface{}) *Repository_FindByAppIDSince_Call {
-	return &Repository_FindByAppIDSince_Call{Call: _e.mock.On("FindByAppIDSince", appID, since)}
+func (_e *Repository_Expecter) FindByAppIDSince(ctx interface{}, appID interface{}, since interface{}) *Repository_FindByAppIDSince_Call {
+	return &Repository_FindByAppIDSince_Call{Call: _e.mock.On("FindByAppIDSince", ctx, appID, since)}
 }
 
-func (_c *Repository_FindByAppIDSince_Call) Run(run func(appID string, since time.Time)) *Repository_FindByAppIDSince_Call {
+func (_c *Repository_FindByAppIDSince_Call) Run(run func(ctx context.Context, appID string, since time.Time)) *Repository_FindByAppIDSince_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 time.Time
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(time.Time)
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -101,18 +108,93 @@ func (_c *Repository_FindByAppIDSince_Call) Return(reviews []*review.Review, err
 	return _c
 }
 
-func (_c *Repository_FindByAppIDSince_Call) RunAndReturn(run func(appID string, since time.Time) ([]*review.Review, error)) *Repository_FindByAppIDSince_Call {
+func (_c *Repository_FindByAppIDSince_Call) RunAndReturn(run func(ctx context.Context, appID string, since time.Time) ([]*review.Review, error)) *Repository_FindByAppIDSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamByAppIDBetween provides a mock function for the type Repository
+func (_mock *Repository) StreamByAppIDBetween(ctx context.Context, appID string, since time.Time, until time.Time, fn func(*review.Review) error) error {
+	ret := _mock.Called(ctx, appID, since, until, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamByAppIDBetween")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time, func(*review.Review) error) error); ok {
+		r0 = returnFunc(ctx, appID, since, until, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_StreamByAppIDBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamByAppIDBetween'
+type Repository_StreamByAppIDBetween_Call struct {
+	*mock.Call
+}
+
+// StreamByAppIDBetween is a helper method to define mock.On call
+//   - ctx context.Context
+//   - appID string
+//   - since time.Time
+//   - until time.Time
+//   - fn func(*review.Review) error
+func (_e *Repository_Expecter) StreamByAppIDBetween(ctx interface{}, appID interface{}, since interface{}, until interface{}, fn interface{}) *Repository_StreamByAppIDBetween_Call {
+	return &Repository_StreamByAppIDBetween_Call{Call: _e.mock.On("StreamByAppIDBetween", ctx, appID, since, until, fn)}
+}
+
+func (_c *Repository_StreamByAppIDBetween_Call) Run(run func(ctx context.Context, appID string, since time.Time, until time.Time, fn func(*review.Review) error)) *Repository_StreamByAppIDBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 func(*review.Review) error
+		if args[4] != nil {
+			arg4 = args[4].(func(*review.Review) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_StreamByAppIDBetween_Call) Return(err error) *Repository_StreamByAppIDBetween_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_StreamByAppIDBetween_Call) RunAndReturn(run func(ctx context.Context, appID string, since time.Time, until time.Time, fn func(*review.Review) error) error) *Repository_StreamByAppIDBetween_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Save provides a mock function for the type Repository
-func (_mock *Repository) Save(reviews ...*review.Review) error {
+func (_mock *Repository) Save(ctx context.Context, reviews ...*review.Review) error {
 	var tmpRet mock.Arguments
 	if len(reviews) > 0 {
-		tmpRet = _mock.Called(reviews)
+		tmpRet = _mock.Called(ctx, reviews)
 	} else {
-		tmpRet = _mock.Called()
+		tmpRet = _mock.Called(ctx)
 	}
 	ret := tmpRet
 
@@ -121,8 +203,8 @@ func (_mock *Repository) Save(reviews ...*review.Review) error {
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(...*review.Review) error); ok {
-		r0 = returnFunc(reviews...)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...*review.Review) error); ok {
+		r0 = returnFunc(ctx, reviews...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -135,22 +217,28 @@ type Repository_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
+//   - ctx context.Context
 //   - reviews ...*review.Review
-func (_e *Repository_Expecter) Save(reviews ...interface{}) *Repository_Save_Call {
+func (_e *Repository_Expecter) Save(ctx interface{}, reviews ...interface{}) *Repository_Save_Call {
 	return &Repository_Save_Call{Call: _e.mock.On("Save",
-		append([]interface{}{}, reviews...)...)}
+		append([]interface{}{ctx}, reviews...)...)}
 }
 
-func (_c *Repository_Save_Call) Run(run func(reviews ...*review.Review)) *Repository_Save_Call {
+func (_c *Repository_Save_Call) Run(run func(ctx context.Context, reviews ...*review.Review)) *Repository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 []*review.Review
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*review.Review
 		var variadicArgs []*review.Review
-		if len(args) > 0 {
-			variadicArgs = args[0].([]*review.Review)
+		if len(args) > 1 {
+			variadicArgs = args[1].([]*review.Review)
 		}
-		arg0 = variadicArgs
+		arg1 = variadicArgs
 		run(
-			arg0...,
+			arg0,
+			arg1...,
 		)
 	})
 	return _c
@@ -161,7 +249,88 @@ func (_c *Repository_Save_Call) Return(err error) *Repository_Save_Call {
 	return _c
 }
 
-func (_c *Repository_Save_Call) RunAndReturn(run func(reviews ...*review.Review) error) *Repository_Save_Call {
+func (_c *Repository_Save_Call) RunAndReturn(run func(ctx context.Context, reviews ...*review.Review) error) *Repository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type Repository
+func (_mock *Repository) Merge(ctx context.Context, reviews ...*review.Review) (int, int, error) {
+	var tmpRet mock.Arguments
+	if len(reviews) > 0 {
+		tmpRet = _mock.Called(ctx, reviews)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...*review.Review) (int, int, error)); ok {
+		return returnFunc(ctx, reviews...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...*review.Review) int); ok {
+		r0 = returnFunc(ctx, reviews...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...*review.Review) int); ok {
+		r1 = returnFunc(ctx, reviews...)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, ...*review.Review) error); ok {
+		r2 = returnFunc(ctx, reviews...)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// Repository_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type Repository_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviews ...*review.Review
+func (_e *Repository_Expecter) Merge(ctx interface{}, reviews ...interface{}) *Repository_Merge_Call {
+	return &Repository_Merge_Call{Call: _e.mock.On("Merge",
+		append([]interface{}{ctx}, reviews...)...)}
+}
+
+func (_c *Repository_Merge_Call) Run(run func(ctx context.Context, reviews ...*review.Review)) *Repository_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*review.Review
+		var variadicArgs []*review.Review
+		if len(args) > 1 {
+			variadicArgs = args[1].([]*review.Review)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_Merge_Call) Return(newReviews int, updatedReviews int, err error) *Repository_Merge_Call {
+	_c.Call.Return(newReviews, updatedReviews, err)
+	return _c
+}
+
+func (_c *Repository_Merge_Call) RunAndReturn(run func(ctx context.Context, reviews ...*review.Review) (int, int, error)) *Repository_Merge_Call {
 	_c.Call.Return(run)
 	return _c
 }
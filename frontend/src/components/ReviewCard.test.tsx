@@ -21,6 +21,18 @@ describe('ReviewCard', () => {
     expect(screen.getByText('(4/5)')).toBeInTheDocument();
   });
 
+  test('renders the developer reply with its status', () => {
+    const repliedReview: Review = {
+      ...mockReview,
+      replyStatus: 'pending',
+      reply: { content: 'Thanks for the feedback!', state: 'pending', updatedAt: '2023-12-02T10:30:00Z' },
+    };
+    render(<ReviewCard review={repliedReview} />);
+
+    expect(screen.getByText('Thanks for the feedback!')).toBeInTheDocument();
+    expect(screen.getByText('Developer reply (pending)')).toBeInTheDocument();
+  });
+
   test('renders star rating correctly', () => {
     render(<ReviewCard review={mockReview} />);
     
@@ -31,6 +31,14 @@ const ReviewCard: React.FC<ReviewCardProps> = ({ review }) => {
         </div>
       </div>
       <div className="review-content">{review.content}</div>
+      {review.reply && review.reply.state !== 'deleted' && (
+        <div className="review-reply">
+          <div className="review-reply-status">
+            Developer reply ({review.reply.state})
+          </div>
+          <div>{review.reply.content}</div>
+        </div>
+      )}
       <div className="review-date">
         Submitted: {formatDate(review.submittedAt)}
       </div>
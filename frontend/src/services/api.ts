@@ -1,16 +1,33 @@
 import { Review } from '../types';
 
 const API_BASE_URL = process.env.REACT_APP_API_URL || 'http://localhost:8080';
+const API_KEY = process.env.REACT_APP_API_KEY;
+// The viewer shows the default workspace unless REACT_APP_WORKSPACE names
+// another one.
+const WORKSPACE = process.env.REACT_APP_WORKSPACE;
+
+const workspacePath = (endpoint: string): string =>
+  WORKSPACE
+    ? endpoint.replace(/^\/api\/v1\//, `/api/v1/workspaces/${encodeURIComponent(WORKSPACE)}/`)
+    : endpoint;
+
+// An OIDC access token saved in session storage under accessToken, e.g. by
+// the SSO login page in front of the viewer, is preferred over the
+// build-time API key.
+const credential = (): string | undefined =>
+  sessionStorage.getItem('accessToken') || API_KEY;
 
 const apiRequest = async <T>(
   endpoint: string,
   options: RequestInit = {}
 ): Promise<T> => {
-  const url = `${API_BASE_URL}${endpoint}`;
+  const url = `${API_BASE_URL}${workspacePath(endpoint)}`;
+  const token = credential();
   
   const defaultOptions: RequestInit = {
     headers: {
       'Content-Type': 'application/json',
+      ...(token ? { Authorization: `Bearer ${token}` } : {}),
       ...options.headers,
     },
     ...options,
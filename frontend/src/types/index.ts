@@ -5,6 +5,14 @@ export interface Review {
   content: string;
   score: number;
   submittedAt: string;
+  replyStatus?: 'none' | 'pending' | 'published' | 'deleted';
+  reply?: ReviewReply;
+}
+
+export interface ReviewReply {
+  content: string;
+  state: 'pending' | 'published' | 'deleted';
+  updatedAt: string;
 }
 
 export interface App {